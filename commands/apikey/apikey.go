@@ -0,0 +1,179 @@
+package apikey
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"go-agent/gopkg/auth"
+	"go-agent/gopkg/utils"
+	"go-agent/internal/dao"
+	"go-agent/internal/dao/api_key"
+	"go-agent/internal/dao/user"
+	"go-agent/internal/model"
+	apikeyService "go-agent/internal/service/apikey"
+
+	"github.com/urfave/cli/v2"
+)
+
+// go run main.go apikey create --name report-service --owner admin --scopes agent:chat --expires 2160h
+// go run main.go apikey list --owner admin
+// go run main.go apikey revoke --id <key_id>
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:  "apikey",
+		Usage: "API Key 管理",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "create",
+				Usage: "创建 API Key，明文只输出一次",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "name",
+						Usage:    "名称，如调用方服务名",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "owner",
+						Usage:    "所属用户的登录名，额度及限流按该用户和 key 计算",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "scopes",
+						Usage: "授权范围，逗号分隔，* 表示全部",
+						Value: apikeyService.ScopeAgentChat,
+					},
+					&cli.DurationFlag{
+						Name:  "expires",
+						Usage: "有效期，0 表示不过期",
+					},
+				},
+				Action: create,
+			},
+			{
+				Name:  "list",
+				Usage: "查询 API Key",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "owner",
+						Usage: "所属用户的登录名，为空时查询全部",
+					},
+				},
+				Action: list,
+			},
+			{
+				Name:  "revoke",
+				Usage: "吊销 API Key",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "id",
+						Usage:    "key ID",
+						Required: true,
+					},
+				},
+				Action: revoke,
+			},
+		},
+	}
+}
+
+func create(ctx *cli.Context) error {
+	scopes := apikeyService.Scopes(ctx.String("scopes"))
+	if len(scopes) == 0 {
+		return errors.New("scopes 不能为空")
+	}
+	owner, err := findOwner(ctx, ctx.String("owner"))
+	if err != nil {
+		return err
+	}
+
+	key, prefix, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		return err
+	}
+	k := &model.SApiKey{
+		KeyId:   utils.GenUUIDWithoutUnderline(),
+		KeyHash: hash,
+		Prefix:  prefix,
+		Name:    ctx.String("name"),
+		OwnerId: owner.UserId,
+		TeamId:  owner.TeamId,
+		Scopes:  strings.Join(scopes, ","),
+		Status:  dao.APIKeyStatusOn,
+	}
+	if expires := ctx.Duration("expires"); expires > 0 {
+		expiresAt := time.Now().Add(expires)
+		k.ExpiresAt = &expiresAt
+	}
+	if err := api_key.NewDao().Create(ctx.Context, k); err != nil {
+		return err
+	}
+	fmt.Printf("已创建 API Key: %s, key ID: %s\n", k.Name, k.KeyId)
+	fmt.Printf("请妥善保存，之后无法再次查看: %s\n", key)
+	return nil
+}
+
+func list(ctx *cli.Context) error {
+	var ownerID string
+	if username := ctx.String("owner"); username != "" {
+		owner, err := findOwner(ctx, username)
+		if err != nil {
+			return err
+		}
+		ownerID = owner.UserId
+	}
+
+	keys, err := api_key.NewDao().List(ctx.Context, ownerID)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY_ID\tPREFIX\tNAME\tOWNER_ID\tSCOPES\tSTATUS\tEXPIRES_AT\tLAST_USED_AT")
+	for _, k := range keys {
+		fmt.Fprintf(w, "%s\t%s...\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			k.KeyId, k.Prefix, k.Name, k.OwnerId, k.Scopes, k.Status, formatTime(k.ExpiresAt), formatTime(k.LastUsedAt))
+	}
+	return w.Flush()
+}
+
+func revoke(ctx *cli.Context) error {
+	id := ctx.String("id")
+	keys := api_key.NewDao()
+	k, err := keys.FindByKeyID(ctx.Context, id)
+	if err != nil {
+		return err
+	}
+	if k == nil {
+		return fmt.Errorf("API Key 不存在: %s", id)
+	}
+	ok, err := keys.Revoke(ctx.Context, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("API Key 已吊销: %s", id)
+	}
+	fmt.Printf("已吊销 API Key: %s, key ID: %s\n", k.Name, k.KeyId)
+	return nil
+}
+
+func findOwner(ctx *cli.Context, username string) (*model.SUser, error) {
+	u, err := user.NewDao().FindByUsername(ctx.Context, username)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, fmt.Errorf("用户不存在: %s", username)
+	}
+	return u, nil
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.DateTime)
+}
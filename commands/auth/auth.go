@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go-agent/gopkg/auth"
+
+	"github.com/urfave/cli/v2"
+)
+
+// go run main.go auth keygen --alg ES256 --kid 2026-10 --out ./config/keys
+//
+// 生成密钥后添加到 auth.jwt.keys，设置 sign_from 为切换时间；旧密钥设置 verify_until 为切换时间加 refresh_ttl 后删除。
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:  "auth",
+		Usage: "认证管理",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "keygen",
+				Usage: "生成 jwt 签名密钥",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "alg",
+						Usage: "签名算法: RS256, ES256, EdDSA",
+						Value: auth.AlgorithmES256,
+					},
+					&cli.StringFlag{
+						Name:     "kid",
+						Usage:    "密钥ID",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "out",
+						Usage: "输出目录",
+						Value: "./config/keys",
+					},
+				},
+				Action: func(ctx *cli.Context) error {
+					privatePEM, publicPEM, err := auth.GenerateKey(ctx.String("alg"))
+					if err != nil {
+						return err
+					}
+
+					dir, kid := ctx.String("out"), ctx.String("kid")
+					if err := os.MkdirAll(dir, 0700); err != nil {
+						return err
+					}
+					privatePath := filepath.Join(dir, kid+".pem")
+					publicPath := filepath.Join(dir, kid+".pub.pem")
+					if _, err := os.Stat(privatePath); err == nil {
+						return fmt.Errorf("密钥已存在: %s", privatePath)
+					}
+					if err := os.WriteFile(privatePath, privatePEM, 0600); err != nil {
+						return err
+					}
+					if err := os.WriteFile(publicPath, publicPEM, 0644); err != nil {
+						return err
+					}
+					fmt.Printf("已生成密钥: %s, %s\n", privatePath, publicPath)
+					return nil
+				},
+			},
+		},
+	}
+}
@@ -1,10 +1,15 @@
 package commands
 
 import (
+	"go-agent/commands/apikey"
 	"go-agent/commands/ask"
+	"go-agent/commands/auth"
+	"go-agent/commands/cron"
 	"go-agent/commands/generate"
 	"go-agent/commands/gorm"
 	"go-agent/commands/migrate"
+	"go-agent/commands/role"
+	"go-agent/commands/user"
 	"go-agent/commands/worker"
 
 	"github.com/urfave/cli/v2"
@@ -13,10 +18,15 @@ import (
 func All() []*cli.Command {
 	commands := []*cli.Command{
 		ask.Command(),
+		auth.Command(),
 		migrate.Command(),
 		generate.Command(),
 		gorm.Command(),
 		worker.Command(),
+		cron.Command(),
+		user.Command(),
+		apikey.Command(),
+		role.Command(),
 	}
 	return commands
 }
@@ -0,0 +1,62 @@
+package cron
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// response 服务端统一响应结构
+type response struct {
+	Code int             `json:"code"`
+	Msg  string          `json:"msg"`
+	Data json.RawMessage `json:"data"`
+}
+
+// request 调用管理接口并将 data 解码到 out
+func request(ctx *cli.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx.Context, method, trimServer(ctx)+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if apiKey := ctx.String("api-key"); apiKey != "" {
+		req.Header.Set("X-API-Key", apiKey)
+	} else if token := ctx.String("token"); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var res response
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return fmt.Errorf("%s: %w", resp.Status, err)
+	}
+	if res.Code != 0 {
+		return fmt.Errorf("%s (code: %d)", res.Msg, res.Code)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(res.Data, out)
+}
@@ -0,0 +1,148 @@
+package cron
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	rxCron "go-agent/gopkg/cron/base"
+
+	"github.com/urfave/cli/v2"
+)
+
+// go run main.go cron --api-key sk-xxx list
+//
+// 通过服务端的管理接口操作正在运行的调度器，暂停、恢复及修改 spec 会同步到所有节点。
+// 管理接口需要 cron:read 及 cron:write 权限，通过 --token 或 --api-key（或对应环境变量）认证。
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:  "cron",
+		Usage: "定时任务管理",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "server",
+				Usage: "服务地址",
+				Value: "http://127.0.0.1:8081",
+			},
+			&cli.StringFlag{
+				Name:    "token",
+				Usage:   "access token，以 Authorization: Bearer 发送",
+				EnvVars: []string{"GO_AGENT_TOKEN"},
+			},
+			&cli.StringFlag{
+				Name:    "api-key",
+				Usage:   "API Key，以 X-API-Key 发送，优先于 token",
+				EnvVars: []string{"GO_AGENT_API_KEY"},
+			},
+		},
+		Subcommands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "查看定时任务",
+				Action: func(ctx *cli.Context) error {
+					var jobs []rxCron.JobInfo
+					if err := request(ctx, http.MethodGet, "/api/crons", nil, &jobs); err != nil {
+						return err
+					}
+					for _, job := range jobs {
+						printJob(job)
+					}
+					return nil
+				},
+			},
+			{
+				Name:      "run",
+				Usage:     "立即执行定时任务",
+				ArgsUsage: "<name>",
+				Action: func(ctx *cli.Context) error {
+					name, err := jobName(ctx)
+					if err != nil {
+						return err
+					}
+					if err := request(ctx, http.MethodPost, "/api/crons/"+name+"/run", nil, nil); err != nil {
+						return err
+					}
+					fmt.Printf("已触发定时任务: %s\n", name)
+					return nil
+				},
+			},
+			{
+				Name:      "pause",
+				Usage:     "暂停定时任务",
+				ArgsUsage: "<name>",
+				Action: func(ctx *cli.Context) error {
+					return update(ctx, "pause", nil)
+				},
+			},
+			{
+				Name:      "resume",
+				Usage:     "恢复定时任务",
+				ArgsUsage: "<name>",
+				Action: func(ctx *cli.Context) error {
+					return update(ctx, "resume", nil)
+				},
+			},
+			{
+				Name:      "spec",
+				Usage:     "修改定时任务 spec，不指定 spec 时恢复为配置中的值",
+				ArgsUsage: "<name> [spec]",
+				Action: func(ctx *cli.Context) error {
+					return update(ctx, "spec", map[string]string{"spec": ctx.Args().Get(1)})
+				},
+			},
+		},
+	}
+}
+
+func jobName(ctx *cli.Context) (string, error) {
+	name := ctx.Args().First()
+	if name == "" {
+		return "", fmt.Errorf("请指定任务名称")
+	}
+	return name, nil
+}
+
+// update 暂停、恢复或修改 spec，并打印任务最新状态
+func update(ctx *cli.Context, action string, body any) error {
+	name, err := jobName(ctx)
+	if err != nil {
+		return err
+	}
+
+	method := http.MethodPost
+	if action == "spec" {
+		method = http.MethodPut
+	}
+
+	var job rxCron.JobInfo
+	if err := request(ctx, method, "/api/crons/"+name+"/"+action, body, &job); err != nil {
+		return err
+	}
+	printJob(job)
+	return nil
+}
+
+func printJob(job rxCron.JobInfo) {
+	state := "运行中"
+	switch {
+	case !job.Enabled:
+		state = "未启用"
+	case job.Paused:
+		state = "已暂停"
+	}
+	fmt.Printf("名称: %s, spec: %s, 状态: %s, 执行中: %v, 超时: %s, 下次执行: %s, 上次执行: %s\n",
+		job.Name, job.Spec, state, job.Running, job.Timeout, formatTime(job.Next), formatTime(job.Prev))
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
+
+// trimServer 去掉服务地址末尾的斜杠
+func trimServer(ctx *cli.Context) string {
+	return strings.TrimRight(ctx.String("server"), "/")
+}
@@ -19,6 +19,14 @@ func Command() *cli.Command {
 			g.UseDB(gorms.Client())
 			g.ApplyBasic(
 				model.SPictureBook{},
+				model.SCronRun{},
+				model.SOutbox{},
+				model.SQuota{},
+				model.SUser{},
+				model.SApiKey{},
+				model.SRole{},
+				model.SRolePermission{},
+				model.SUserRole{},
 			)
 			g.Execute()
 			return nil
@@ -1,7 +1,12 @@
 package migrate
 
 import (
+	"fmt"
 	"go-agent/gopkg/gorms"
+	"go-agent/internal/dao/role"
+	"go-agent/internal/model"
+	"go-agent/internal/service/rbac"
+	"sort"
 
 	"github.com/urfave/cli/v2"
 )
@@ -18,10 +23,47 @@ func Command() *cli.Command {
 				Action: func(ctx *cli.Context) error {
 					tx := gorms.Client()
 					tx.DisableForeignKeyConstraintWhenMigrating = true
-					tables := []any{}
-					return tx.AutoMigrate(tables...)
+					tables := []any{
+						&model.SCronRun{},
+						&model.SOutbox{},
+						&model.SQuota{},
+						&model.SUser{},
+						&model.SApiKey{},
+						&model.SRole{},
+						&model.SRolePermission{},
+						&model.SUserRole{},
+					}
+					if err := tx.AutoMigrate(tables...); err != nil {
+						return err
+					}
+					return seedRoles(ctx)
 				},
 			},
 		},
 	}
 }
+
+// seedRoles 创建不存在的默认角色，已存在的角色保留其权限
+func seedRoles(ctx *cli.Context) error {
+	roles := role.NewDao()
+	names := make([]string, 0, len(rbac.DefaultRoles))
+	for name := range rbac.DefaultRoles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		r, err := roles.FindByName(ctx.Context, name)
+		if err != nil {
+			return err
+		}
+		if r != nil {
+			continue
+		}
+		if err := roles.Save(ctx.Context, &model.SRole{Name: name}, rbac.DefaultRoles[name]); err != nil {
+			return err
+		}
+		fmt.Printf("已创建默认角色: %s\n", name)
+	}
+	return nil
+}
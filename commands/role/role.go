@@ -0,0 +1,151 @@
+package role
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"go-agent/internal/dao/role"
+	"go-agent/internal/dao/user"
+	"go-agent/internal/model"
+	"go-agent/internal/service/rbac"
+
+	"github.com/urfave/cli/v2"
+)
+
+// go run main.go role save --name admin --permissions "*"
+// go run main.go role save --name operator --permissions "agent:chat,cron:*,task:*"
+// go run main.go role assign --username admin --role admin
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:  "role",
+		Usage: "角色管理",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "save",
+				Usage: "创建或更新角色，权限会被替换",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "name",
+						Usage:    "角色名",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "permissions",
+						Usage:    "权限，逗号分隔，如 agent:chat,admin:*，* 表示全部",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "description",
+						Usage: "描述",
+					},
+				},
+				Action: save,
+			},
+			{
+				Name:   "list",
+				Usage:  "查询角色",
+				Action: list,
+			},
+			{
+				Name:  "assign",
+				Usage: "为用户分配角色",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "username",
+						Usage:    "登录名",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "role",
+						Usage:    "角色名",
+						Required: true,
+					},
+				},
+				Action: assign,
+			},
+		},
+	}
+}
+
+func save(ctx *cli.Context) error {
+	var permissions []string
+	for _, p := range strings.Split(ctx.String("permissions"), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			permissions = append(permissions, p)
+		}
+	}
+	if len(permissions) == 0 {
+		return errors.New("permissions 不能为空")
+	}
+
+	r := &model.SRole{
+		Name:        ctx.String("name"),
+		Description: ctx.String("description"),
+	}
+	if err := role.NewDao().Save(ctx.Context, r, permissions); err != nil {
+		return err
+	}
+	if err := rbac.InvalidateAll(ctx.Context); err != nil {
+		return err
+	}
+	fmt.Printf("已保存角色: %s, 权限: %s\n", r.Name, strings.Join(permissions, ","))
+	return nil
+}
+
+func list(ctx *cli.Context) error {
+	roles := role.NewDao()
+	rows, err := roles.List(ctx.Context)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(rows))
+	for _, r := range rows {
+		names = append(names, r.Name)
+	}
+	permissions, err := roles.Permissions(ctx.Context, names...)
+	if err != nil {
+		return err
+	}
+	grouped := make(map[string][]string, len(rows))
+	for _, p := range permissions {
+		grouped[p.RoleName] = append(grouped[p.RoleName], p.Permission)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tPERMISSIONS\tDESCRIPTION")
+	for _, r := range rows {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.Name, strings.Join(grouped[r.Name], ","), r.Description)
+	}
+	return w.Flush()
+}
+
+func assign(ctx *cli.Context) error {
+	username, roleName := ctx.String("username"), ctx.String("role")
+	u, err := user.NewDao().FindByUsername(ctx.Context, username)
+	if err != nil {
+		return err
+	}
+	if u == nil {
+		return fmt.Errorf("用户不存在: %s", username)
+	}
+	roles := role.NewDao()
+	r, err := roles.FindByName(ctx.Context, roleName)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return fmt.Errorf("角色不存在: %s", roleName)
+	}
+
+	if err := roles.AssignUser(ctx.Context, u.UserId, r.Name); err != nil {
+		return err
+	}
+	if err := rbac.InvalidateUser(ctx.Context, u.UserId); err != nil {
+		return err
+	}
+	fmt.Printf("已为用户 %s 分配角色: %s\n", u.Username, r.Name)
+	return nil
+}
@@ -1,13 +1,21 @@
 package server
 
 import (
+	"go-agent/gopkg/auth"
+	"go-agent/gopkg/cache"
 	"go-agent/gopkg/cache/es"
-	"go-agent/gopkg/cron"
+	"go-agent/gopkg/cache/queue"
+	rxRedis "go-agent/gopkg/cache/redis"
 	"go-agent/gopkg/gorms"
 	"go-agent/gopkg/log"
 	"go-agent/gopkg/viper"
+	"go-agent/handler/middleware"
+	"go-agent/internal/agent/quota"
 	"go-agent/internal/dao"
+	"go-agent/internal/worker/base"
+	"go-agent/internal/worker/workflow"
 
+	rxViper "github.com/spf13/viper"
 	"github.com/urfave/cli/v2"
 )
 
@@ -29,17 +37,42 @@ func InitConfigFromConfigPath(configPath, envPath string) error {
 		return err
 	}
 	// 初始化Redis
-	//if err := rxRedis.InitFromViper(); err != nil {
-	//	return err
-	//}
-	//初始化cron定时任务
-	if err := cron.DoCron(); err != nil {
+	if rxViper.GetBool("redis.switch") {
+		if err := rxRedis.InitFromViper(); err != nil {
+			return err
+		}
+	}
+	// 初始化缓存，依赖 Redis 客户端
+	if err := cache.InitFromViper(); err != nil {
+		return err
+	}
+	// 初始化认证，吊销列表依赖 Redis 客户端
+	if err := auth.InitFromViper(); err != nil {
+		return err
+	}
+	// 初始化限流，依赖 Redis 客户端
+	if err := middleware.InitRateLimitFromViper(); err != nil {
+		return err
+	}
+	// 初始化 token 额度，依赖 Redis 客户端及缓存
+	if err := quota.InitFromViper(); err != nil {
+		return err
+	}
+	// 初始化任务队列
+	if err := base.InitFromViper(); err != nil {
+		return err
+	}
+	if err := workflow.InitFromViper(); err != nil {
 		return err
 	}
 	// ES配置
 	if err := es.Initialize(); err != nil {
 		return err
 	}
+	// RabbitMQ配置，连接断开后自动重连
+	if err := queue.Initialize(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -1,9 +1,13 @@
 package server
 
 import (
+	"go-agent/gopkg/cron"
 	"go-agent/gopkg/gins"
 	"go-agent/gopkg/graceful"
 	"go-agent/handler/api"
+	"go-agent/handler/wellknown"
+	"go-agent/internal/dao/cron_run"
+	"go-agent/internal/worker"
 	"net/http"
 
 	"github.com/urfave/cli/v2"
@@ -14,11 +18,24 @@ func Run(*cli.Context) error {
 		_ = http.ListenAndServe(":8999", nil)
 	}()
 
+	// 进程内的后台任务，收到退出信号时与 http 服务一起停止并等待任务执行完毕
+	backgroundWorker, err := worker.NewBackgroundWorkerFromViper()
+	if err != nil {
+		return err
+	}
+	worker.SetBackground(backgroundWorker)
+	// 定时任务，管理接口通过当前进程的调度器操作
+	if err := cron.DoCron(cron_run.NewDao()); err != nil {
+		return err
+	}
+
 	server := gins.NewHttpServer(":8081")
 	server.RegisterHandler(
 		api.NewHandler,
+		wellknown.NewHandler,
 	)
 	graceful.Start(server)
+	graceful.Start(backgroundWorker)
 	graceful.Wait()
 	return nil
 }
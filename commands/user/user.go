@@ -0,0 +1,77 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+
+	"go-agent/gopkg/utils"
+	"go-agent/internal/dao"
+	"go-agent/internal/dao/user"
+	"go-agent/internal/model"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// go run main.go user create --username admin --password ******
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:  "user",
+		Usage: "用户管理",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "create",
+				Usage: "创建用户",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "username",
+						Usage:    "登录名",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "password",
+						Usage:    "密码",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "team",
+						Usage: "所属团队ID",
+					},
+				},
+				Action: func(ctx *cli.Context) error {
+					users := user.NewDao()
+					username := ctx.String("username")
+					exists, err := users.FindByUsername(ctx.Context, username)
+					if err != nil {
+						return err
+					}
+					if exists != nil {
+						return fmt.Errorf("用户已存在: %s", username)
+					}
+
+					password := ctx.String("password")
+					if len(password) < 8 {
+						return errors.New("密码长度不能少于 8 位")
+					}
+					hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+					if err != nil {
+						return err
+					}
+
+					u := &model.SUser{
+						UserId:       utils.GenUUIDWithoutUnderline(),
+						Username:     username,
+						PasswordHash: string(hash),
+						TeamId:       ctx.String("team"),
+						Status:       dao.UserStatusOn,
+					}
+					if err := users.Create(ctx.Context, u); err != nil {
+						return err
+					}
+					fmt.Printf("已创建用户: %s, 用户ID: %s\n", u.Username, u.UserId)
+					return nil
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,104 @@
+package worker
+
+import (
+	"fmt"
+
+	"go-agent/internal/worker/base"
+
+	"github.com/urfave/cli/v2"
+)
+
+// go run main.go worker dead-letter list
+func deadLetterCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "dead-letter",
+		Usage: "死信任务管理",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "查看死信任务",
+				Flags: []cli.Flag{
+					&cli.IntFlag{Name: "offset", Usage: "偏移量"},
+					&cli.IntFlag{Name: "limit", Usage: "数量", Value: 20},
+				},
+				Action: func(ctx *cli.Context) error {
+					store := base.DefaultDeadLetters()
+					if store == nil {
+						return base.ErrQueueNotConfigured
+					}
+
+					letters, total, err := store.List(ctx.Context, ctx.Int("offset"), ctx.Int("limit"))
+					if err != nil {
+						return err
+					}
+
+					fmt.Printf("死信任务总数: %d\n", total)
+					for _, letter := range letters {
+						fmt.Printf("ID: %s, 名称: %s, 尝试次数: %d, 失败时间: %s, 可重新入队: %v, 错误: %s\n",
+							letter.ID, letter.Name, letter.Attempts, letter.FailedAt.Format("2006-01-02 15:04:05"), letter.Requeueable(), letter.Error)
+					}
+					return nil
+				},
+			},
+			{
+				Name:      "requeue",
+				Usage:     "重新入队死信任务",
+				ArgsUsage: "<id>",
+				Action: func(ctx *cli.Context) error {
+					id := ctx.Args().First()
+					if id == "" {
+						return cli.Exit("请指定死信任务ID", 1)
+					}
+
+					job, err := base.RequeueDeadLetter(ctx.Context, base.DefaultDeadLetters(), base.DefaultQueue(), id)
+					if err != nil {
+						return err
+					}
+
+					fmt.Printf("已重新入队: %s (类型: %s)\n", job.ID, job.Type)
+					return nil
+				},
+			},
+			{
+				Name:      "delete",
+				Usage:     "删除死信任务",
+				ArgsUsage: "<id>",
+				Action: func(ctx *cli.Context) error {
+					id := ctx.Args().First()
+					if id == "" {
+						return cli.Exit("请指定死信任务ID", 1)
+					}
+
+					store := base.DefaultDeadLetters()
+					if store == nil {
+						return base.ErrQueueNotConfigured
+					}
+					if err := store.Delete(ctx.Context, id); err != nil {
+						return err
+					}
+
+					fmt.Printf("已删除: %s\n", id)
+					return nil
+				},
+			},
+			{
+				Name:  "purge",
+				Usage: "清空死信任务",
+				Action: func(ctx *cli.Context) error {
+					store := base.DefaultDeadLetters()
+					if store == nil {
+						return base.ErrQueueNotConfigured
+					}
+
+					count, err := store.Purge(ctx.Context)
+					if err != nil {
+						return err
+					}
+
+					fmt.Printf("已清空 %d 个死信任务\n", count)
+					return nil
+				},
+			},
+		},
+	}
+}
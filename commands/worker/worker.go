@@ -1,83 +1,71 @@
 package worker
 
 import (
-	"fmt"
-	"strings"
-	"time"
-
+	"go-agent/gopkg/cron"
+	"go-agent/gopkg/graceful"
+	"go-agent/gopkg/log"
+	"go-agent/internal/dao/cron_run"
+	"go-agent/internal/worker"
 	"go-agent/internal/worker/base"
-	"go-agent/internal/worker/order"
+	"go-agent/internal/worker/outbox"
 
+	"github.com/spf13/viper"
 	"github.com/urfave/cli/v2"
 )
 
+// go run main.go worker --type order
 func Command() *cli.Command {
 	return &cli.Command{
 		Name:  "worker",
 		Usage: "工作进程",
 		Flags: []cli.Flag{
-			&cli.StringFlag{
+			&cli.StringSliceFlag{
 				Name:    "type",
-				Usage:   "指定要运行的worker类型 (product/order), 不指定则运行所有worker",
+				Usage:   "指定要消费的任务类型，支持完整类型 (order.process) 或前缀 (order)，可重复或逗号分隔，不指定则消费全部类型",
 				Aliases: []string{"t"},
 			},
 		},
+		Subcommands: []*cli.Command{
+			deadLetterCommand(),
+		},
 		Action: func(ctx *cli.Context) error {
-			// 创建任务管理器 (最大并发数: 10, 队列大小: 100)
-			taskManager := base.NewTaskManager(10, 100)
-			defer taskManager.Shutdown()
+			worker.RegisterHandlers(base.Registry())
 
-			workerType := strings.ToLower(ctx.String("t"))
-
-			switch workerType {
-			case "product":
-				fmt.Printf("这里需要处理 %s 类型数据\n", workerType)
-				// 示例：提交一些产品相关任务
-				for i := 1; i <= 5; i++ {
-					task := order.ProcessOrderTask(fmt.Sprintf("product-%d", i))
-					if err := taskManager.Submit(task); err != nil {
-						fmt.Printf("提交任务失败: %v\n", err)
-					}
-				}
-			case "order":
-				fmt.Printf("这里需要处理 %s 类型数据\n", workerType)
-				// 示例：提交一些订单相关任务
-				for i := 1; i <= 3; i++ {
-					task := order.ProcessOrderTask(fmt.Sprintf("order-%d", i))
-					if err := taskManager.Submit(task); err != nil {
-						fmt.Printf("提交任务失败: %v\n", err)
-					}
-				}
-			case "":
-				fmt.Println("这里需要处理所有数据")
-				// 示例：提交不同类型的任务
-				for i := 1; i <= 3; i++ {
-					orderTask := order.ProcessOrderTask(fmt.Sprintf("all-%d", i))
-					refundTask := order.ProcessRefundTask(fmt.Sprintf("refund-%d", i))
-
-					if err := taskManager.Submit(orderTask); err != nil {
-						fmt.Printf("提交订单任务失败: %v\n", err)
-					}
+			types, err := worker.SelectTypes(base.Registry(), ctx.StringSlice("type"))
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+			if base.DefaultQueue() == nil {
+				return base.ErrQueueNotConfigured
+			}
+			if _, ok := base.DefaultQueue().(*base.MemoryQueue); ok {
+				log.Sugar().Warn("worker.queue.backend 为 memory，只能消费当前进程提交的任务")
+			}
 
-					if err := taskManager.Submit(refundTask); err != nil {
-						fmt.Printf("提交退款任务失败: %v\n", err)
-					}
+			// 先创建全部组件，任一失败时直接返回，不会留下已在消费的协程
+			// 发件箱 relay，将事务中记录的事件发送到消息总线
+			var relay *outbox.Relay
+			if viper.GetBool("outbox.switch") {
+				if relay, err = outbox.NewRelayFromViper(); err != nil {
+					return err
 				}
-			default:
-				return cli.Exit("无效的 worker 类型。可用类型: product/order", 1)
 			}
-
-			// 等待一段时间以确保任务完成
-			time.Sleep(5 * time.Second)
-
-			// 显示任务统计信息
-			fmt.Println("\n任务统计信息:")
-			stats := taskManager.GetAllStats()
-			for taskID, stat := range stats {
-				fmt.Printf("任务ID: %s, 总执行次数: %d, 成功: %d, 失败: %d, 最后执行: %v\n",
-					taskID, stat.TotalExecutions, stat.SuccessCount, stat.ErrorCount, stat.LastExecution)
+			// 根据 worker 配置创建任务管理器
+			taskManager, err := base.NewTaskManagerFromViper()
+			if err != nil {
+				return err
+			}
+			// 定时任务，启动失败时尚未开始消费
+			if err := cron.DoCron(cron_run.NewDao()); err != nil {
+				taskManager.Shutdown()
+				return err
 			}
 
+			graceful.Start(worker.NewConsumer(taskManager, types))
+			if relay != nil {
+				graceful.Start(relay)
+			}
+			graceful.Wait()
 			return nil
 		},
 	}
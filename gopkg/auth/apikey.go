@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// APIKeyPrefix API Key 的固定前缀，用于区分 Authorization 中的 access token
+const APIKeyPrefix = "sk-"
+
+// apiKeyDisplayLen 保存及展示的 key 前缀长度，用于识别 key
+const apiKeyDisplayLen = len(APIKeyPrefix) + 8
+
+// GenerateAPIKey 生成 API Key，返回明文、展示用前缀及哈希；明文只在创建时返回，数据库只保存哈希
+func GenerateAPIKey() (key, prefix, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", "", err
+	}
+	key = APIKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return key, key[:apiKeyDisplayLen], HashAPIKey(key), nil
+}
+
+// HashAPIKey API Key 的 SHA-256 哈希，key 为 256 位随机数，无需加盐或慢哈希
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsAPIKey 是否为 API Key 格式
+func IsAPIKey(s string) bool {
+	return strings.HasPrefix(s, APIKeyPrefix)
+}
@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Denylist 已吊销的 jti 及会话，记录保留到对应 token 过期
+type Denylist interface {
+	// Revoke 吊销 id，until 之后自动删除
+	Revoke(ctx context.Context, id string, until time.Time) error
+	// Revoked 任一 id 已被吊销时返回 true
+	Revoked(ctx context.Context, ids ...string) (bool, error)
+	// Claim 原子地吊销 id，id 已被吊销时返回 false，用于保证 refresh token 只能轮换一次
+	Claim(ctx context.Context, id string, until time.Time) (bool, error)
+}
+
+// RedisDenylist 基于 Redis 的吊销列表，多节点共享
+type RedisDenylist struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisDenylist 实例化RedisDenylist，prefix 为空时使用 go-agent:auth:denylist
+func NewRedisDenylist(client redis.UniversalClient, prefix string) *RedisDenylist {
+	if prefix == "" {
+		prefix = "go-agent:auth:denylist"
+	}
+	return &RedisDenylist{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+func (d *RedisDenylist) Revoke(ctx context.Context, id string, until time.Time) error {
+	ttl := time.Until(until)
+	if ttl <= 0 {
+		return nil
+	}
+	return d.client.Set(ctx, d.prefix+":"+id, 1, ttl).Err()
+}
+
+func (d *RedisDenylist) Claim(ctx context.Context, id string, until time.Time) (bool, error) {
+	ttl := time.Until(until)
+	if ttl <= 0 {
+		// 已过期的 token 无法通过校验，不会走到这里
+		return false, nil
+	}
+	return d.client.SetNX(ctx, d.prefix+":"+id, 1, ttl).Result()
+}
+
+func (d *RedisDenylist) Revoked(ctx context.Context, ids ...string) (bool, error) {
+	// cluster 模式下多个键可能不在同一个 slot，逐个查询
+	for _, id := range ids {
+		n, err := d.client.Exists(ctx, d.prefix+":"+id).Result()
+		if err != nil {
+			return false, err
+		}
+		if n > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// MemoryDenylist 进程内的吊销列表，只适用于单节点部署及测试
+type MemoryDenylist struct {
+	mu    sync.Mutex
+	items map[string]time.Time
+}
+
+// NewMemoryDenylist 实例化MemoryDenylist
+func NewMemoryDenylist() *MemoryDenylist {
+	return &MemoryDenylist{
+		items: make(map[string]time.Time),
+	}
+}
+
+func (d *MemoryDenylist) Revoke(_ context.Context, id string, until time.Time) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := time.Now()
+	for k, v := range d.items {
+		if now.After(v) {
+			delete(d.items, k)
+		}
+	}
+	if until.After(now) {
+		d.items[id] = until
+	}
+	return nil
+}
+
+func (d *MemoryDenylist) Claim(_ context.Context, id string, until time.Time) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := time.Now()
+	if v, ok := d.items[id]; ok && now.Before(v) {
+		return false, nil
+	}
+	if !until.After(now) {
+		return false, nil
+	}
+	d.items[id] = until
+	return true, nil
+}
+
+func (d *MemoryDenylist) Revoked(_ context.Context, ids ...string) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := time.Now()
+	for _, id := range ids {
+		if until, ok := d.items[id]; ok && now.Before(until) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
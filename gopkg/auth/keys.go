@@ -0,0 +1,302 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrKeyNotFound kid 对应的密钥不存在或已过期
+var ErrKeyNotFound = errors.New("auth: signing key not found")
+
+// 签名算法
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// legacyKid 只配置 secret 时 HS256 密钥的 kid，未携带 kid 的旧 token 使用该密钥验签
+const legacyKid = "default"
+
+// KeyConfig 签名密钥配置
+type KeyConfig struct {
+	Kid         string `json:"kid" mapstructure:"kid"`
+	Algorithm   string `json:"algorithm" mapstructure:"algorithm"`       // RS256, ES256, EdDSA, HS256
+	PrivateKey  string `json:"private_key" mapstructure:"private_key"`   // 私钥 PEM 文件，只用于验签的旧密钥可不配置
+	PublicKey   string `json:"public_key" mapstructure:"public_key"`     // 公钥 PEM 文件，为空时由私钥导出
+	Secret      string `json:"secret" mapstructure:"secret"`             // HS256 的密钥
+	SignFrom    string `json:"sign_from" mapstructure:"sign_from"`       // RFC3339，从该时间起用于签名，为空表示立即
+	VerifyUntil string `json:"verify_until" mapstructure:"verify_until"` // RFC3339，超过后不再用于验签及发布到 JWKS，为空表示不限
+}
+
+// Key 已加载的密钥
+type Key struct {
+	Kid         string
+	Method      jwt.SigningMethod
+	SignFrom    time.Time
+	VerifyUntil time.Time
+	signKey     any // []byte, *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey，nil 表示只用于验签
+	verifyKey   any // []byte, *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey
+}
+
+// canSign now 时是否可用于签名
+func (k *Key) canSign(now time.Time) bool {
+	return k.signKey != nil && !now.Before(k.SignFrom) && k.canVerify(now)
+}
+
+// canVerify now 时是否可用于验签
+func (k *Key) canVerify(now time.Time) bool {
+	return k.VerifyUntil.IsZero() || now.Before(k.VerifyUntil)
+}
+
+// KeySet 签名密钥集合，通过 kid 区分
+//
+// 已生效（sign_from 不晚于当前时间）的密钥中 sign_from 最晚的用于签名，sign_from 相同时取配置中靠后的；
+// 未过期的密钥都可用于验签。新密钥可提前配置 sign_from，到期后自动切换，旧密钥保留到 verify_until 以验证已签发的 token。
+type KeySet struct {
+	mu   sync.RWMutex
+	keys []*Key
+	now  func() time.Time
+}
+
+// NewKeySet 加载密钥，secret 不为空时追加 kid 为 default 的 HS256 密钥
+func NewKeySet(configs []KeyConfig, secret string) (*KeySet, error) {
+	ks := &KeySet{now: time.Now}
+	if err := ks.Reload(configs, secret); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// Reload 重新加载密钥，失败时保留原有密钥
+func (ks *KeySet) Reload(configs []KeyConfig, secret string) error {
+	var keys []*Key
+	if secret != "" {
+		// 放在最前，配置了其他密钥时不用于签名
+		keys = append(keys, &Key{
+			Kid:       legacyKid,
+			Method:    jwt.SigningMethodHS256,
+			signKey:   []byte(secret),
+			verifyKey: []byte(secret),
+		})
+	}
+	seen := make(map[string]bool)
+	for _, cfg := range configs {
+		if cfg.Kid == "" || seen[cfg.Kid] || (secret != "" && cfg.Kid == legacyKid) {
+			return fmt.Errorf("auth: kid is empty or duplicated: %q", cfg.Kid)
+		}
+		seen[cfg.Kid] = true
+		key, err := loadKey(cfg)
+		if err != nil {
+			return fmt.Errorf("auth: load key %s: %w", cfg.Kid, err)
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return errors.New("auth: auth.jwt.keys or auth.jwt.secret is required")
+	}
+
+	ks.mu.Lock()
+	ks.keys = keys
+	ks.mu.Unlock()
+	return nil
+}
+
+// SigningKey 当前用于签名的密钥
+func (ks *KeySet) SigningKey() (*Key, error) {
+	now := ks.now()
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	var current *Key
+	for _, key := range ks.keys {
+		if key.canSign(now) && (current == nil || !key.SignFrom.Before(current.SignFrom)) {
+			current = key
+		}
+	}
+	if current == nil {
+		return nil, ErrKeyNotFound
+	}
+	return current, nil
+}
+
+// VerificationKey kid 对应的验签密钥，kid 为空时使用 default 密钥
+func (ks *KeySet) VerificationKey(kid string) (*Key, error) {
+	if kid == "" {
+		kid = legacyKid
+	}
+	now := ks.now()
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	for _, key := range ks.keys {
+		if key.Kid == kid && key.canVerify(now) {
+			return key, nil
+		}
+	}
+	return nil, ErrKeyNotFound
+}
+
+// Algorithms 密钥使用的全部算法，用于限制验签时接受的 alg
+func (ks *KeySet) Algorithms() []string {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	seen := make(map[string]bool)
+	var algs []string
+	for _, key := range ks.keys {
+		if alg := key.Method.Alg(); !seen[alg] {
+			seen[alg] = true
+			algs = append(algs, alg)
+		}
+	}
+	sort.Strings(algs)
+	return algs
+}
+
+func loadKey(cfg KeyConfig) (*Key, error) {
+	key := &Key{Kid: cfg.Kid}
+	var err error
+	if key.SignFrom, err = parseTime(cfg.SignFrom); err != nil {
+		return nil, fmt.Errorf("sign_from: %w", err)
+	}
+	if key.VerifyUntil, err = parseTime(cfg.VerifyUntil); err != nil {
+		return nil, fmt.Errorf("verify_until: %w", err)
+	}
+
+	if cfg.Algorithm == AlgorithmHS256 {
+		if cfg.Secret == "" {
+			return nil, errors.New("secret is required for HS256")
+		}
+		key.Method = jwt.SigningMethodHS256
+		key.signKey, key.verifyKey = []byte(cfg.Secret), []byte(cfg.Secret)
+		return key, nil
+	}
+
+	var privatePEM, publicPEM []byte
+	if cfg.PrivateKey != "" {
+		if privatePEM, err = os.ReadFile(cfg.PrivateKey); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.PublicKey != "" {
+		if publicPEM, err = os.ReadFile(cfg.PublicKey); err != nil {
+			return nil, err
+		}
+	}
+	if privatePEM == nil && publicPEM == nil {
+		return nil, errors.New("private_key or public_key is required")
+	}
+
+	switch cfg.Algorithm {
+	case AlgorithmRS256:
+		key.Method = jwt.SigningMethodRS256
+		if privatePEM != nil {
+			if key.signKey, err = jwt.ParseRSAPrivateKeyFromPEM(privatePEM); err != nil {
+				return nil, err
+			}
+		}
+		if publicPEM != nil {
+			key.verifyKey, err = jwt.ParseRSAPublicKeyFromPEM(publicPEM)
+		}
+	case AlgorithmES256:
+		key.Method = jwt.SigningMethodES256
+		if privatePEM != nil {
+			if key.signKey, err = jwt.ParseECPrivateKeyFromPEM(privatePEM); err != nil {
+				return nil, err
+			}
+		}
+		if publicPEM != nil {
+			key.verifyKey, err = jwt.ParseECPublicKeyFromPEM(publicPEM)
+		}
+	case AlgorithmEdDSA:
+		key.Method = jwt.SigningMethodEdDSA
+		if privatePEM != nil {
+			if key.signKey, err = jwt.ParseEdPrivateKeyFromPEM(privatePEM); err != nil {
+				return nil, err
+			}
+		}
+		if publicPEM != nil {
+			key.verifyKey, err = jwt.ParseEdPublicKeyFromPEM(publicPEM)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported algorithm: %q", cfg.Algorithm)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if key.verifyKey == nil {
+		key.verifyKey = key.signKey.(crypto.Signer).Public()
+	}
+	if err := checkKeyType(cfg.Algorithm, key.verifyKey); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// checkKeyType 确认公钥与算法匹配，ES256 只接受 P-256
+func checkKeyType(alg string, public any) error {
+	var ok bool
+	switch alg {
+	case AlgorithmRS256:
+		_, ok = public.(*rsa.PublicKey)
+	case AlgorithmES256:
+		var pub *ecdsa.PublicKey
+		pub, ok = public.(*ecdsa.PublicKey)
+		ok = ok && pub.Curve == elliptic.P256()
+	case AlgorithmEdDSA:
+		_, ok = public.(ed25519.PublicKey)
+	}
+	if !ok {
+		return fmt.Errorf("key type %T does not match algorithm %s", public, alg)
+	}
+	return nil
+}
+
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// GenerateKey 生成 alg 对应的密钥对，返回 PKCS#8 私钥及 PKIX 公钥的 PEM
+func GenerateKey(alg string) (privatePEM, publicPEM []byte, err error) {
+	var signer crypto.Signer
+	switch alg {
+	case AlgorithmRS256:
+		signer, err = rsa.GenerateKey(rand.Reader, 2048)
+	case AlgorithmES256:
+		signer, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case AlgorithmEdDSA:
+		_, signer, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, nil, fmt.Errorf("unsupported algorithm: %q", alg)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(signer)
+	if err != nil {
+		return nil, nil, err
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), nil
+}
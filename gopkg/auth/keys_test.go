@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeKey(t *testing.T, dir, kid, alg string) KeyConfig {
+	private, public, err := GenerateKey(alg)
+	require.NoError(t, err)
+	cfg := KeyConfig{
+		Kid:        kid,
+		Algorithm:  alg,
+		PrivateKey: filepath.Join(dir, kid+".pem"),
+		PublicKey:  filepath.Join(dir, kid+".pub.pem"),
+	}
+	require.NoError(t, os.WriteFile(cfg.PrivateKey, private, 0600))
+	require.NoError(t, os.WriteFile(cfg.PublicKey, public, 0644))
+	return cfg
+}
+
+func TestKeySet_Rotation(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	rs := writeKey(t, dir, "rs", AlgorithmRS256)
+	es := writeKey(t, dir, "es", AlgorithmES256)
+	es.SignFrom = now.Add(time.Hour).Format(time.RFC3339)
+	ed := writeKey(t, dir, "ed", AlgorithmEdDSA)
+	ed.PublicKey = "" // 由私钥导出
+	ed.SignFrom = now.Add(2 * time.Hour).Format(time.RFC3339)
+
+	m, err := NewTokenManager(JWTConfig{
+		Secret:    "legacy",
+		Issuer:    "go-agent",
+		Audience:  "go-agent",
+		AccessTTL: 24 * time.Hour,
+		Keys:      []KeyConfig{rs, es, ed},
+	}, nil)
+	require.NoError(t, err)
+	clock := now
+	m.now = func() time.Time { return clock }
+	m.keys.now = func() time.Time { return clock }
+
+	issue := func() (string, string) {
+		pair, err := m.Issue("u1", "")
+		require.NoError(t, err)
+		token, _, err := jwt.NewParser().ParseUnverified(pair.AccessToken, &Claims{})
+		require.NoError(t, err)
+		return pair.AccessToken, token.Header["kid"].(string)
+	}
+
+	rsToken, kid := issue()
+	assert.Equal(t, "rs", kid)
+	clock = now.Add(time.Hour)
+	esToken, kid := issue()
+	assert.Equal(t, "es", kid)
+	clock = now.Add(2 * time.Hour)
+	_, kid = issue()
+	assert.Equal(t, "ed", kid)
+
+	// 轮换后旧密钥签发的 token 仍可验证
+	clock = now.Add(2*time.Hour + time.Minute)
+	_, err = m.ParseAccessToken(ctx, rsToken)
+	assert.NoError(t, err)
+	_, err = m.ParseAccessToken(ctx, esToken)
+	assert.NoError(t, err)
+
+	// verify_until 之后旧密钥不再可用，也不再发布到 JWKS
+	rs.VerifyUntil = now.Add(2 * time.Hour).Format(time.RFC3339)
+	require.NoError(t, m.Keys().Reload([]KeyConfig{rs, es, ed}, "legacy"))
+	_, err = m.ParseAccessToken(ctx, rsToken)
+	assert.ErrorIs(t, err, ErrTokenInvalid)
+
+	jwks := m.Keys().JWKS()
+	var kids []string
+	for _, key := range jwks.Keys {
+		kids = append(kids, key.Kid+"/"+key.Kty)
+	}
+	assert.Equal(t, []string{"es/EC", "ed/OKP"}, kids)
+}
+
+func TestKeySet_AlgorithmConfusion(t *testing.T) {
+	ctx := context.Background()
+	rs := writeKey(t, t.TempDir(), "rs", AlgorithmRS256)
+	m, err := NewTokenManager(JWTConfig{Secret: "legacy", Keys: []KeyConfig{rs}}, nil)
+	require.NoError(t, err)
+
+	// 使用公钥作为 HMAC 密钥伪造 rs 的 token
+	public, err := os.ReadFile(rs.PublicKey)
+	require.NoError(t, err)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		UserID: "u1",
+		Type:   TokenTypeAccess,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "forged",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+	})
+	token.Header["kid"] = "rs"
+	forged, err := token.SignedString(public)
+	require.NoError(t, err)
+	_, err = m.ParseAccessToken(ctx, forged)
+	assert.ErrorIs(t, err, ErrTokenInvalid)
+}
@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"strings"
+)
+
+// PermissionAll 全部权限
+const PermissionAll = "*"
+
+// MatchPermission granted 是否包含 required
+//
+// 权限格式为 资源:操作，如 agent:chat；以 :* 结尾表示该前缀下的全部权限，如 admin:* 包含 admin:roles，* 包含全部权限。
+func MatchPermission(granted, required string) bool {
+	if granted == PermissionAll || granted == required {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(granted, "*"); ok && strings.HasSuffix(prefix, ":") {
+		return strings.HasPrefix(required, prefix)
+	}
+	return false
+}
+
+// HasPermission granted 中是否有权限包含 required
+func HasPermission(granted []string, required string) bool {
+	for _, g := range granted {
+		if MatchPermission(g, required) {
+			return true
+		}
+	}
+	return false
+}
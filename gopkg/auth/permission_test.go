@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchPermission(t *testing.T) {
+	cases := []struct {
+		granted, required string
+		want              bool
+	}{
+		{"agent:chat", "agent:chat", true},
+		{"agent:chat", "agent:admin", false},
+		{"admin:*", "admin:roles", true},
+		{"admin:*", "admin:roles:write", true},
+		{"admin:*", "admin", false},
+		{"admin:*", "administrator:roles", false},
+		{"*", "picturebook:write", true},
+		{"picturebook*", "picturebook:write", false},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, MatchPermission(c.granted, c.required), "%s -> %s", c.granted, c.required)
+	}
+	assert.True(t, HasPermission([]string{"agent:chat", "cron:*"}, "cron:write"))
+	assert.False(t, HasPermission(nil, "cron:write"))
+}
@@ -0,0 +1,377 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	rxRedis "go-agent/gopkg/cache/redis"
+	"go-agent/gopkg/graceful"
+	"go-agent/gopkg/log"
+	"go-agent/gopkg/utils"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/viper"
+)
+
+var (
+	// ErrTokenInvalid token 格式、签名或声明错误
+	ErrTokenInvalid = errors.New("auth: token invalid")
+	// ErrTokenExpired token 已过期
+	ErrTokenExpired = errors.New("auth: token expired")
+	// ErrTokenRevoked token 或所属会话已被吊销
+	ErrTokenRevoked = errors.New("auth: token revoked")
+)
+
+// token 类型
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
+// Config 认证配置，对应 auth
+type Config struct {
+	JWT      JWTConfig      `json:"jwt" mapstructure:"jwt"`
+	Denylist DenylistConfig `json:"denylist" mapstructure:"denylist"`
+}
+
+// JWTConfig token 配置
+type JWTConfig struct {
+	Secret            string        `json:"secret" mapstructure:"secret"` // HS256 密钥，kid 为 default；配置 keys 后只用于验证旧 token
+	Issuer            string        `json:"issuer" mapstructure:"issuer"`
+	Audience          string        `json:"audience" mapstructure:"audience"`
+	AccessTTL         time.Duration `json:"access_ttl" mapstructure:"access_ttl"`                   // access token 有效期，默认 15m
+	RefreshTTL        time.Duration `json:"refresh_ttl" mapstructure:"refresh_ttl"`                 // refresh token 有效期，默认 expire_hour
+	ExpireHour        int           `json:"expire_hour" mapstructure:"expire_hour"`                 // 未配置 refresh_ttl 时作为其默认值
+	Keys              []KeyConfig   `json:"keys" mapstructure:"keys"`                               // 签名密钥
+	KeyReloadInterval time.Duration `json:"key_reload_interval" mapstructure:"key_reload_interval"` // 重新读取密钥配置及文件的间隔，0 表示不重新读取
+}
+
+// DenylistConfig 吊销列表配置
+type DenylistConfig struct {
+	Client string `json:"client" mapstructure:"client"` // redis 客户端名称，为空时使用进程内存，只适用于单节点
+	Prefix string `json:"prefix" mapstructure:"prefix"`
+}
+
+// Claims access token 及 refresh token 的声明
+//
+// Id 为 jti；Session 为登录会话ID，同一次登录轮换出的 token 共享，登出时吊销整个会话。
+type Claims struct {
+	UserID  string `json:"user_id"`
+	TeamID  string `json:"team_id,omitempty"`
+	Type    string `json:"typ"`
+	Session string `json:"sid"`
+	jwt.RegisteredClaims
+}
+
+// ExpiresTime 过期时间
+func (c *Claims) ExpiresTime() time.Time {
+	if c.ExpiresAt == nil {
+		return time.Time{}
+	}
+	return c.ExpiresAt.Time
+}
+
+// TokenPair 登录或刷新后返回的 token
+type TokenPair struct {
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int64  `json:"expires_in"`         // access token 有效秒数
+	RefreshExpiresIn int64  `json:"refresh_expires_in"` // refresh token 有效秒数
+}
+
+// TokenManager 签发、校验、刷新及吊销 token
+//
+// refresh token 每次刷新后即被吊销（轮换）；已吊销的 refresh token 再次使用时视为泄露，吊销整个会话。
+type TokenManager struct {
+	cfg      JWTConfig
+	keys     *KeySet
+	denylist Denylist
+	now      func() time.Time
+}
+
+// NewTokenManager 实例化TokenManager，cfg.Keys 及 cfg.Secret 至少配置一个
+func NewTokenManager(cfg JWTConfig, denylist Denylist) (*TokenManager, error) {
+	keys, err := NewKeySet(cfg.Keys, cfg.Secret)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.AccessTTL <= 0 {
+		cfg.AccessTTL = 15 * time.Minute
+	}
+	if cfg.RefreshTTL <= 0 {
+		cfg.RefreshTTL = time.Duration(cfg.ExpireHour) * time.Hour
+	}
+	if cfg.RefreshTTL <= 0 {
+		cfg.RefreshTTL = 30 * 24 * time.Hour
+	}
+	if denylist == nil {
+		denylist = NewMemoryDenylist()
+	}
+	return &TokenManager{
+		cfg:      cfg,
+		keys:     keys,
+		denylist: denylist,
+		now:      time.Now,
+	}, nil
+}
+
+// Keys 签名密钥
+func (m *TokenManager) Keys() *KeySet {
+	return m.keys
+}
+
+var defaultManager *TokenManager
+
+// InitFromViper 根据 auth 配置初始化默认 TokenManager，denylist 使用 Redis 时依赖 Redis 客户端
+//
+// 未配置 auth.jwt.keys 及 auth.jwt.secret 时不开启认证。secret 可通过环境变量 AUTH_JWT_SECRET 设置。
+func InitFromViper() error {
+	cfg, err := configFromViper()
+	if err != nil {
+		return err
+	}
+	if len(cfg.JWT.Keys) == 0 && cfg.JWT.Secret == "" {
+		log.Sugar().Warnf("未配置 auth.jwt.keys 及 auth.jwt.secret，不开启认证")
+		defaultManager = nil
+		return nil
+	}
+
+	var denylist Denylist
+	if cfg.Denylist.Client != "" {
+		client, err := rxRedis.ClientAndErr(cfg.Denylist.Client)
+		if err != nil {
+			return fmt.Errorf("auth.denylist client %s: %w", cfg.Denylist.Client, err)
+		}
+		denylist = NewRedisDenylist(client, cfg.Denylist.Prefix)
+	}
+	manager, err := NewTokenManager(cfg.JWT, denylist)
+	if err != nil {
+		return err
+	}
+	defaultManager = manager
+
+	if interval := cfg.JWT.KeyReloadInterval; interval > 0 {
+		graceful.StartFunc(func(ctx context.Context) {
+			manager.watchKeys(ctx, interval)
+		})
+	}
+	return nil
+}
+
+func configFromViper() (Config, error) {
+	var cfg Config
+	if err := viper.UnmarshalKey("auth", &cfg); err != nil {
+		return cfg, err
+	}
+	// UnmarshalKey 不会读取嵌套键的环境变量
+	cfg.JWT.Secret = viper.GetString("auth.jwt.secret")
+	return cfg, nil
+}
+
+// watchKeys 定时重新读取密钥配置及 PEM 文件，用于不重启服务添加新密钥；sign_from 到期的切换不依赖重新读取
+func (m *TokenManager) watchKeys(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		cfg, err := configFromViper()
+		if err == nil {
+			err = m.keys.Reload(cfg.JWT.Keys, cfg.JWT.Secret)
+		}
+		if err != nil {
+			log.Sugar().Errorf("重新加载 jwt 密钥失败，继续使用原有密钥: %v", err)
+		}
+	}
+}
+
+// Default 默认 TokenManager，未初始化时为 nil
+func Default() *TokenManager {
+	return defaultManager
+}
+
+// SetDefault 设置默认 TokenManager，用于测试
+func SetDefault(m *TokenManager) {
+	defaultManager = m
+}
+
+// Issue 为用户签发新会话的 token
+func (m *TokenManager) Issue(userID, teamID string) (*TokenPair, error) {
+	return m.issue(userID, teamID, utils.GenUUIDWithoutUnderline())
+}
+
+func (m *TokenManager) issue(userID, teamID, session string) (*TokenPair, error) {
+	now := m.now()
+	access, err := m.sign(userID, teamID, session, TokenTypeAccess, now, m.cfg.AccessTTL)
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := m.sign(userID, teamID, session, TokenTypeRefresh, now, m.cfg.RefreshTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenPair{
+		AccessToken:      access,
+		RefreshToken:     refresh,
+		TokenType:        "Bearer",
+		ExpiresIn:        int64(m.cfg.AccessTTL.Seconds()),
+		RefreshExpiresIn: int64(m.cfg.RefreshTTL.Seconds()),
+	}, nil
+}
+
+// sign 使用当前签名密钥签名，header 中携带 kid
+func (m *TokenManager) sign(userID, teamID, session, typ string, now time.Time, ttl time.Duration) (string, error) {
+	key, err := m.keys.SigningKey()
+	if err != nil {
+		return "", err
+	}
+	claims := Claims{
+		UserID:  userID,
+		TeamID:  teamID,
+		Type:    typ,
+		Session: session,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        utils.GenUUIDWithoutUnderline(),
+			Issuer:    m.cfg.Issuer,
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	if m.cfg.Audience != "" {
+		claims.Audience = jwt.ClaimStrings{m.cfg.Audience}
+	}
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.Kid
+	return token.SignedString(key.signKey)
+}
+
+// parse 按 kid 选择密钥校验签名，并校验有效期、签发方、受众及类型，不检查吊销
+func (m *TokenManager) parse(tokenStr, typ string) (*Claims, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(m.keys.Algorithms()),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(m.now),
+	}
+	if m.cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(m.cfg.Issuer))
+	}
+	if m.cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(m.cfg.Audience))
+	}
+
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := m.keys.VerificationKey(kid)
+		if err != nil {
+			return nil, err
+		}
+		// alg 必须与 kid 对应的密钥一致，避免用公钥作为 HMAC 密钥等算法混淆
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method %s for kid %q", token.Method.Alg(), kid)
+		}
+		return key.verifyKey, nil
+	}, opts...)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrTokenExpired
+		}
+		return nil, fmt.Errorf("%w: %v", ErrTokenInvalid, err)
+	}
+	if claims.Type != typ || claims.UserID == "" || claims.ID == "" {
+		return nil, ErrTokenInvalid
+	}
+	return claims, nil
+}
+
+// ParseAccessToken 校验 access token，包括是否已被吊销
+func (m *TokenManager) ParseAccessToken(ctx context.Context, tokenStr string) (*Claims, error) {
+	claims, err := m.parse(tokenStr, TokenTypeAccess)
+	if err != nil {
+		return nil, err
+	}
+	revoked, err := m.denylist.Revoked(ctx, claims.ID, sessionKey(claims.Session))
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, ErrTokenRevoked
+	}
+	return claims, nil
+}
+
+// ParseRefreshToken 校验 refresh token，已轮换的 refresh token 再次使用时吊销整个会话
+func (m *TokenManager) ParseRefreshToken(ctx context.Context, tokenStr string) (*Claims, error) {
+	claims, err := m.parse(tokenStr, TokenTypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+	revoked, err := m.denylist.Revoked(ctx, sessionKey(claims.Session))
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, ErrTokenRevoked
+	}
+	revoked, err = m.denylist.Revoked(ctx, claims.ID)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		// 已轮换的 refresh token 被再次使用，可能已泄露，吊销整个会话
+		if err := m.RevokeSession(ctx, claims); err != nil {
+			return nil, err
+		}
+		return nil, ErrTokenRevoked
+	}
+	return claims, nil
+}
+
+// Rotate 吊销 ParseRefreshToken 返回的 refresh token，并在同一会话中签发新的 token
+//
+// 签发前原子地吊销原 token，同一 refresh token 的并发请求只有一个成功，其余视为重复使用并吊销整个会话。
+func (m *TokenManager) Rotate(ctx context.Context, claims *Claims) (*TokenPair, error) {
+	claimed, err := m.denylist.Claim(ctx, claims.ID, claims.ExpiresTime())
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		if err := m.RevokeSession(ctx, claims); err != nil {
+			return nil, err
+		}
+		return nil, ErrTokenRevoked
+	}
+	return m.issue(claims.UserID, claims.TeamID, claims.Session)
+}
+
+// Refresh 使用 refresh token 换取新的 token，原 refresh token 被吊销
+func (m *TokenManager) Refresh(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	claims, err := m.ParseRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	return m.Rotate(ctx, claims)
+}
+
+// Revoke 吊销单个 token，直到其过期
+func (m *TokenManager) Revoke(ctx context.Context, claims *Claims) error {
+	return m.denylist.Revoke(ctx, claims.ID, claims.ExpiresTime())
+}
+
+// RevokeSession 吊销 claims 所属的会话，会话内的 access token 及 refresh token 都将失效
+func (m *TokenManager) RevokeSession(ctx context.Context, claims *Claims) error {
+	// 会话内最晚签发的 refresh token 不会晚于当前时间加 refresh_ttl
+	return m.denylist.Revoke(ctx, sessionKey(claims.Session), m.now().Add(m.cfg.RefreshTTL))
+}
+
+func sessionKey(session string) string {
+	return "sid:" + session
+}
@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestManager(t *testing.T) *TokenManager {
+	m, err := NewTokenManager(JWTConfig{
+		Secret:     "test-secret",
+		Issuer:     "go-agent",
+		Audience:   "go-agent",
+		AccessTTL:  time.Minute,
+		RefreshTTL: time.Hour,
+	}, NewMemoryDenylist())
+	assert.NoError(t, err)
+	return m
+}
+
+func TestTokenManager_Refresh(t *testing.T) {
+	ctx := context.Background()
+	m := newTestManager(t)
+
+	pair, err := m.Issue("u1", "t1")
+	assert.NoError(t, err)
+	claims, err := m.ParseAccessToken(ctx, pair.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "u1", claims.UserID)
+	assert.Equal(t, "t1", claims.TeamID)
+
+	// refresh token 不能作为 access token 使用
+	_, err = m.ParseAccessToken(ctx, pair.RefreshToken)
+	assert.ErrorIs(t, err, ErrTokenInvalid)
+
+	rotated, err := m.Refresh(ctx, pair.RefreshToken)
+	assert.NoError(t, err)
+	_, err = m.ParseAccessToken(ctx, rotated.AccessToken)
+	assert.NoError(t, err)
+
+	// 已轮换的 refresh token 再次使用时吊销整个会话
+	_, err = m.Refresh(ctx, pair.RefreshToken)
+	assert.ErrorIs(t, err, ErrTokenRevoked)
+	_, err = m.ParseAccessToken(ctx, rotated.AccessToken)
+	assert.ErrorIs(t, err, ErrTokenRevoked)
+	_, err = m.Refresh(ctx, rotated.RefreshToken)
+	assert.ErrorIs(t, err, ErrTokenRevoked)
+}
+
+func TestTokenManager_RevokeSession(t *testing.T) {
+	ctx := context.Background()
+	m := newTestManager(t)
+
+	pair, err := m.Issue("u1", "")
+	assert.NoError(t, err)
+	other, err := m.Issue("u1", "")
+	assert.NoError(t, err)
+
+	claims, err := m.ParseAccessToken(ctx, pair.AccessToken)
+	assert.NoError(t, err)
+	assert.NoError(t, m.RevokeSession(ctx, claims))
+
+	_, err = m.ParseAccessToken(ctx, pair.AccessToken)
+	assert.ErrorIs(t, err, ErrTokenRevoked)
+	_, err = m.Refresh(ctx, pair.RefreshToken)
+	assert.ErrorIs(t, err, ErrTokenRevoked)
+
+	// 其他会话不受影响
+	_, err = m.ParseAccessToken(ctx, other.AccessToken)
+	assert.NoError(t, err)
+}
+
+func TestTokenManager_Invalid(t *testing.T) {
+	ctx := context.Background()
+	m := newTestManager(t)
+
+	m.now = func() time.Time { return time.Now().Add(-2 * time.Minute) }
+	expired, err := m.Issue("u1", "")
+	assert.NoError(t, err)
+	m.now = time.Now
+	_, err = m.ParseAccessToken(ctx, expired.AccessToken)
+	assert.ErrorIs(t, err, ErrTokenExpired)
+
+	other, err := NewTokenManager(JWTConfig{Secret: "other-secret", Issuer: "go-agent", Audience: "go-agent"}, nil)
+	assert.NoError(t, err)
+	pair, err := other.Issue("u1", "")
+	assert.NoError(t, err)
+	_, err = m.ParseAccessToken(ctx, pair.AccessToken)
+	assert.ErrorIs(t, err, ErrTokenInvalid)
+}
+
+func TestTokenManager_ConcurrentRefresh(t *testing.T) {
+	mr := miniredis.RunT(t)
+	denylists := map[string]Denylist{
+		"memory": NewMemoryDenylist(),
+		"redis":  NewRedisDenylist(redis.NewClient(&redis.Options{Addr: mr.Addr()}), ""),
+	}
+	for name, denylist := range denylists {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			m, err := NewTokenManager(JWTConfig{
+				Secret:     "test-secret",
+				AccessTTL:  time.Minute,
+				RefreshTTL: time.Hour,
+			}, denylist)
+			assert.NoError(t, err)
+			pair, err := m.Issue("u1", "t1")
+			assert.NoError(t, err)
+
+			// 同一 refresh token 的并发请求只有一个成功
+			var wg sync.WaitGroup
+			var succeeded, revoked int32
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, err := m.Refresh(ctx, pair.RefreshToken)
+					switch {
+					case err == nil:
+						atomic.AddInt32(&succeeded, 1)
+					case errors.Is(err, ErrTokenRevoked):
+						atomic.AddInt32(&revoked, 1)
+					}
+				}()
+			}
+			wg.Wait()
+			assert.Equal(t, int32(1), succeeded)
+			assert.Equal(t, int32(9), revoked)
+
+			// 重复使用吊销整个会话
+			_, err = m.ParseAccessToken(ctx, pair.AccessToken)
+			assert.ErrorIs(t, err, ErrTokenRevoked)
+		})
+	}
+}
@@ -0,0 +1,394 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	rxRedis "go-agent/gopkg/cache/redis"
+	"go-agent/gopkg/log"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+// ErrNotFound loader 返回该错误表示数据不存在，结果会按 negative_ttl 缓存，避免缓存穿透
+var ErrNotFound = errors.New("cache: not found")
+
+// 缓存值的首字节，区分正常值与不存在标记
+const (
+	flagValue    byte = 0
+	flagNotFound byte = 1
+)
+
+// tagScript 将键加入标签集合，集合的过期时间只延长不缩短，保证不早于其中任何键过期
+var tagScript = redis.NewScript(`
+redis.call('SADD', KEYS[1], ARGV[1])
+local ttl = tonumber(ARGV[2])
+local pttl = redis.call('PTTL', KEYS[1])
+if pttl < ttl then
+	redis.call('PEXPIRE', KEYS[1], ttl)
+end
+return 1
+`)
+
+// Config 缓存配置，对应 cache
+type Config struct {
+	Switch      bool          `json:"switch" mapstructure:"switch"`             // 关闭时 GetOrLoad 直接调用 loader
+	Client      string        `json:"client" mapstructure:"client"`             // redis 客户端名称，为空时只使用进程内缓存
+	Prefix      string        `json:"prefix" mapstructure:"prefix"`             // redis 键前缀
+	Codec       string        `json:"codec" mapstructure:"codec"`               // json, msgpack，默认 json
+	Jitter      float64       `json:"jitter" mapstructure:"jitter"`             // 过期时间随机增加的比例，避免同时过期，默认 0.1
+	NegativeTTL time.Duration `json:"negative_ttl" mapstructure:"negative_ttl"` // 不存在结果的缓存时间，默认 1m，小于 0 表示不缓存
+	Local       LocalConfig   `json:"local" mapstructure:"local"`               // 进程内 LRU 缓存
+}
+
+// LocalConfig 进程内 LRU 缓存配置
+//
+// 其他进程的失效操作不会通知到本进程，ttl 应明显小于 redis 缓存时间。
+type LocalConfig struct {
+	Size int           `json:"size" mapstructure:"size"` // 最大缓存数，0 表示不启用
+	TTL  time.Duration `json:"ttl" mapstructure:"ttl"`   // 最长缓存时间，默认 10s
+}
+
+const (
+	defaultPrefix      = "go-agent:cache"
+	defaultJitter      = 0.1
+	defaultNegativeTTL = time.Minute
+	defaultLocalTTL    = 10 * time.Second
+)
+
+// Cache 基于 Redis 的旁路缓存，可选进程内 LRU 作为一级缓存
+type Cache struct {
+	client      redis.UniversalClient
+	prefix      string
+	codec       Codec
+	jitter      float64
+	negativeTTL time.Duration
+	local       *lru
+	group       singleflight.Group
+	logger      *zap.SugaredLogger
+}
+
+// Option 缓存选项
+type Option func(*Cache)
+
+// WithPrefix 设置 redis 键前缀
+func WithPrefix(prefix string) Option {
+	return func(c *Cache) {
+		c.prefix = prefix
+	}
+}
+
+// WithCodec 设置默认编解码
+func WithCodec(codec Codec) Option {
+	return func(c *Cache) {
+		c.codec = codec
+	}
+}
+
+// WithJitter 设置过期时间随机增加的比例
+func WithJitter(jitter float64) Option {
+	return func(c *Cache) {
+		c.jitter = jitter
+	}
+}
+
+// WithNegativeTTL 设置不存在结果的缓存时间，小于 0 表示不缓存
+func WithNegativeTTL(ttl time.Duration) Option {
+	return func(c *Cache) {
+		c.negativeTTL = ttl
+	}
+}
+
+// WithLocal 启用进程内 LRU 缓存
+func WithLocal(size int, ttl time.Duration) Option {
+	return func(c *Cache) {
+		if size <= 0 {
+			return
+		}
+		if ttl <= 0 {
+			ttl = defaultLocalTTL
+		}
+		c.local = newLRU(size, ttl)
+	}
+}
+
+// New 实例化Cache，client 为空时只使用进程内缓存
+func New(client redis.UniversalClient, opts ...Option) *Cache {
+	c := &Cache{
+		client:      client,
+		prefix:      defaultPrefix,
+		codec:       JSON,
+		jitter:      defaultJitter,
+		negativeTTL: defaultNegativeTTL,
+		logger:      log.Sugar(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+var defaultCache *Cache
+
+// InitFromViper 根据 cache 配置初始化默认缓存
+func InitFromViper() error {
+	var cfg Config
+	if err := viper.UnmarshalKey("cache", &cfg); err != nil {
+		return err
+	}
+	if !cfg.Switch {
+		defaultCache = nil
+		return nil
+	}
+
+	codec, err := CodecByName(cfg.Codec)
+	if err != nil {
+		return err
+	}
+	opts := []Option{WithCodec(codec), WithLocal(cfg.Local.Size, cfg.Local.TTL)}
+	if cfg.Prefix != "" {
+		opts = append(opts, WithPrefix(cfg.Prefix))
+	}
+	if cfg.Jitter > 0 {
+		opts = append(opts, WithJitter(cfg.Jitter))
+	}
+	if cfg.NegativeTTL != 0 {
+		opts = append(opts, WithNegativeTTL(cfg.NegativeTTL))
+	}
+
+	var client redis.UniversalClient
+	if cfg.Client != "" {
+		if client, err = rxRedis.ClientAndErr(cfg.Client); err != nil {
+			return err
+		}
+	}
+	defaultCache = New(client, opts...)
+	return nil
+}
+
+// Default 默认缓存，未启用时为 nil
+func Default() *Cache {
+	return defaultCache
+}
+
+// SetDefault 设置默认缓存，用于测试
+func SetDefault(c *Cache) {
+	defaultCache = c
+}
+
+// loadOptions 单次读取的选项
+type loadOptions struct {
+	tags  []string
+	codec Codec
+}
+
+// LoadOption 单次读取的选项
+type LoadOption func(*loadOptions)
+
+// WithTags 缓存写入时关联标签，通过 InvalidateTags 批量失效
+func WithTags(tags ...string) LoadOption {
+	return func(o *loadOptions) {
+		o.tags = append(o.tags, tags...)
+	}
+}
+
+// WithLoadCodec 本次读取使用的编解码，需与写入时一致
+func WithLoadCodec(codec Codec) LoadOption {
+	return func(o *loadOptions) {
+		o.codec = codec
+	}
+}
+
+// GetOrLoad 使用默认缓存读取 key，未命中时调用 loader 并写入缓存，未启用缓存时直接调用 loader
+func GetOrLoad[T any](ctx context.Context, key string, ttl time.Duration, loader func(ctx context.Context) (T, error), opts ...LoadOption) (T, error) {
+	if defaultCache == nil {
+		return loader(ctx)
+	}
+	return Load(ctx, defaultCache, key, ttl, loader, opts...)
+}
+
+// Load 读取 key，依次查询进程内缓存、Redis，都未命中时调用 loader
+//
+// 同一进程内相同 key 的并发未命中只调用一次 loader；loader 返回 ErrNotFound 时缓存不存在的结果。
+// 缓存读写失败只记录日志，不影响返回 loader 的结果。
+func Load[T any](ctx context.Context, c *Cache, key string, ttl time.Duration, loader func(ctx context.Context) (T, error), opts ...LoadOption) (T, error) {
+	o := loadOptions{codec: c.codec}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var zero T
+	data, ok := c.get(ctx, key, o.tags)
+	if !ok {
+		// singleflight 中的 loader 不随单个调用方取消，各调用方按自己的 ctx 返回
+		ch := c.group.DoChan(key, func() (any, error) {
+			return c.load(context.WithoutCancel(ctx), key, ttl, o, func(ctx context.Context) (any, error) {
+				return loader(ctx)
+			})
+		})
+		select {
+		case res := <-ch:
+			if res.Err != nil {
+				return zero, res.Err
+			}
+			data = res.Val.([]byte)
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		}
+	}
+
+	if data[0] == flagNotFound {
+		return zero, ErrNotFound
+	}
+	var v T
+	if err := o.codec.Unmarshal(data[1:], &v); err != nil {
+		return zero, fmt.Errorf("cache: decode %s: %w", key, err)
+	}
+	return v, nil
+}
+
+// get 依次查询进程内缓存及 Redis，Redis 命中时按 tags 写入进程内缓存
+func (c *Cache) get(ctx context.Context, key string, tags []string) ([]byte, bool) {
+	if c.local != nil {
+		if data, ok := c.local.get(key); ok {
+			return data, true
+		}
+	}
+	if c.client == nil {
+		return nil, false
+	}
+
+	data, err := c.client.Get(ctx, c.key(key)).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			c.logger.Warnf("读取缓存失败: %s, 错误: %v", key, err)
+		}
+		return nil, false
+	}
+	if len(data) == 0 {
+		return nil, false
+	}
+	if c.local != nil {
+		c.local.set(key, data, 0, tags)
+	}
+	return data, true
+}
+
+// load 调用 loader 并写入缓存，返回编码后的值
+func (c *Cache) load(ctx context.Context, key string, ttl time.Duration, o loadOptions, loader func(ctx context.Context) (any, error)) ([]byte, error) {
+	v, err := loader(ctx)
+	var data []byte
+	switch {
+	case errors.Is(err, ErrNotFound):
+		if c.negativeTTL < 0 {
+			return nil, err
+		}
+		data, ttl = []byte{flagNotFound}, c.negativeTTL
+	case err != nil:
+		return nil, err
+	default:
+		encoded, err := o.codec.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("cache: encode %s: %w", key, err)
+		}
+		data = append([]byte{flagValue}, encoded...)
+	}
+
+	c.set(ctx, key, data, c.withJitter(ttl), o.tags)
+	return data, nil
+}
+
+// set 写入 Redis 及进程内缓存，并记录标签
+func (c *Cache) set(ctx context.Context, key string, data []byte, ttl time.Duration, tags []string) {
+	if c.local != nil {
+		c.local.set(key, data, ttl, tags)
+	}
+	if c.client == nil {
+		return
+	}
+
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, c.key(key), data, ttl)
+		for _, tag := range tags {
+			// 集合中残留已过期的键不影响失效
+			tagScript.Eval(ctx, pipe, []string{c.tagKey(tag)}, key, ttl.Milliseconds())
+		}
+		return nil
+	})
+	if err != nil {
+		c.logger.Warnf("写入缓存失败: %s, 错误: %v", key, err)
+	}
+}
+
+// Delete 删除缓存
+func (c *Cache) Delete(ctx context.Context, keys ...string) error {
+	if c.local != nil {
+		c.local.delete(keys...)
+	}
+	if c.client == nil || len(keys) == 0 {
+		return nil
+	}
+	redisKeys := make([]string, len(keys))
+	for i, key := range keys {
+		redisKeys[i] = c.key(key)
+	}
+	return c.client.Del(ctx, redisKeys...).Err()
+}
+
+// InvalidateTags 删除与标签关联的全部缓存
+func (c *Cache) InvalidateTags(ctx context.Context, tags ...string) error {
+	if c.local != nil {
+		c.local.invalidateTags(tags...)
+	}
+	if c.client == nil {
+		return nil
+	}
+
+	for _, tag := range tags {
+		keys, err := c.client.SMembers(ctx, c.tagKey(tag)).Result()
+		if err != nil {
+			return err
+		}
+		if err := c.Delete(ctx, keys...); err != nil {
+			return err
+		}
+		if err := c.client.Del(ctx, c.tagKey(tag)).Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InvalidateTags 使用默认缓存删除与标签关联的全部缓存，未启用缓存时不做任何事
+func InvalidateTags(ctx context.Context, tags ...string) error {
+	if defaultCache == nil {
+		return nil
+	}
+	return defaultCache.InvalidateTags(ctx, tags...)
+}
+
+func (c *Cache) key(key string) string {
+	return c.prefix + ":" + key
+}
+
+func (c *Cache) tagKey(tag string) string {
+	return c.prefix + ":tag:" + tag
+}
+
+// withJitter 过期时间随机增加 [0, ttl*jitter)
+func (c *Cache) withJitter(ttl time.Duration) time.Duration {
+	if c.jitter <= 0 || ttl <= 0 {
+		return ttl
+	}
+	max := int64(float64(ttl) * c.jitter)
+	if max <= 0 {
+		return ttl
+	}
+	return ttl + time.Duration(rand.Int63n(max))
+}
@@ -0,0 +1,165 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+type book struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+func TestLoad_Singleflight(t *testing.T) {
+	c := New(nil, WithLocal(10, time.Minute))
+	var calls int32
+	loader := func(ctx context.Context) (*book, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(50 * time.Millisecond)
+		return &book{ID: 1, Title: "a"}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := Load(context.Background(), c, "book:1", time.Minute, loader)
+			assert.NoError(t, err)
+			assert.Equal(t, "a", v.Title)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// 已缓存
+	_, err := Load(context.Background(), c, "book:1", time.Minute, loader)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestLoad_NotFound(t *testing.T) {
+	c := New(nil, WithLocal(10, time.Minute))
+	var calls int
+	loader := func(ctx context.Context) (book, error) {
+		calls++
+		return book{}, ErrNotFound
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err := Load(context.Background(), c, "book:404", time.Minute, loader)
+		assert.ErrorIs(t, err, ErrNotFound)
+	}
+	assert.Equal(t, 1, calls)
+
+	// 其他错误不缓存
+	failed := errors.New("db down")
+	for i := 0; i < 2; i++ {
+		_, err := Load(context.Background(), c, "book:500", time.Minute, func(ctx context.Context) (book, error) {
+			calls++
+			return book{}, failed
+		})
+		assert.ErrorIs(t, err, failed)
+	}
+	assert.Equal(t, 3, calls)
+}
+
+func TestInvalidateTags(t *testing.T) {
+	c := New(nil, WithLocal(10, time.Minute), WithCodec(Msgpack))
+	var calls int
+	loader := func(ctx context.Context) ([]book, error) {
+		calls++
+		return []book{{ID: calls}}, nil
+	}
+	ctx := context.Background()
+
+	v, err := Load(ctx, c, "books", time.Minute, loader, WithTags("book"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v[0].ID)
+
+	assert.NoError(t, c.InvalidateTags(ctx, "book"))
+	v, err = Load(ctx, c, "books", time.Minute, loader, WithTags("book"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v[0].ID)
+}
+
+func TestLRU_Evict(t *testing.T) {
+	l := newLRU(2, time.Minute)
+	l.set("a", []byte("1"), 0, nil)
+	l.set("b", []byte("2"), 0, []string{"book"})
+	_, _ = l.get("a")
+	l.set("c", []byte("3"), 0, nil)
+
+	_, ok := l.get("b")
+	assert.False(t, ok)
+	_, ok = l.get("a")
+	assert.True(t, ok)
+	// 淘汰时同时移除标签索引
+	assert.Empty(t, l.tags)
+
+	l.set("d", []byte("4"), time.Millisecond, []string{"book"})
+	time.Sleep(5 * time.Millisecond)
+	_, ok = l.get("d")
+	assert.False(t, ok)
+	assert.Empty(t, l.tags)
+}
+
+func TestInvalidateTags_LocalFromRedis(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	ctx := context.Background()
+	loader := func(id int) func(ctx context.Context) (book, error) {
+		return func(ctx context.Context) (book, error) {
+			return book{ID: id}, nil
+		}
+	}
+
+	// a 写入 Redis，b 从 Redis 读取后写入进程内缓存，同样需要关联标签
+	a := New(client, WithLocal(10, time.Minute))
+	b := New(client, WithLocal(10, time.Minute))
+	_, err := Load(ctx, a, "book:1", time.Hour, loader(1), WithTags("book"))
+	assert.NoError(t, err)
+	v, err := Load(ctx, b, "book:1", time.Hour, loader(2), WithTags("book"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v.ID)
+
+	assert.NoError(t, b.InvalidateTags(ctx, "book"))
+	v, err = Load(ctx, b, "book:1", time.Hour, loader(3), WithTags("book"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, v.ID)
+}
+
+func TestInvalidateTags_TagTTL(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	c := New(client, WithJitter(0))
+	ctx := context.Background()
+	loader := func(id int) func(ctx context.Context) (book, error) {
+		return func(ctx context.Context) (book, error) {
+			return book{ID: id}, nil
+		}
+	}
+
+	// 短过期时间的写入不能缩短标签集合的过期时间
+	_, err := Load(ctx, c, "book:1", time.Hour, loader(1), WithTags("book"))
+	assert.NoError(t, err)
+	_, err = Load(ctx, c, "book:2", time.Second, loader(2), WithTags("book"))
+	assert.NoError(t, err)
+	mr.FastForward(2 * time.Second)
+	assert.True(t, mr.Exists(c.key("book:1")))
+	assert.True(t, mr.Exists(c.tagKey("book")))
+
+	assert.NoError(t, c.InvalidateTags(ctx, "book"))
+	assert.False(t, mr.Exists(c.key("book:1")))
+	v, err := Load(ctx, c, "book:1", time.Hour, loader(3), WithTags("book"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, v.ID)
+}
@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Codec 缓存值的编解码
+type Codec interface {
+	Name() string
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+var (
+	// JSON 按 json 标签编码，json:"-" 的字段不会被缓存
+	JSON Codec = jsonCodec{}
+	// Msgpack 按 msgpack 标签编码，体积更小且忽略 json 标签，适合 gorms.Paging 等字段标记为 json:"-" 的结构
+	Msgpack Codec = msgpackCodec{}
+)
+
+// codecs 按名称查找编解码，用于配置
+var codecs = map[string]Codec{
+	JSON.Name():    JSON,
+	Msgpack.Name(): Msgpack,
+}
+
+// CodecByName 按名称获取编解码，名称为空时返回 JSON
+func CodecByName(name string) (Codec, error) {
+	if name == "" {
+		return JSON, nil
+	}
+	if codec, ok := codecs[name]; ok {
+		return codec, nil
+	}
+	return nil, fmt.Errorf("unknown cache codec: %s", name)
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string {
+	return "json"
+}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+type msgpackCodec struct{}
+
+func (msgpackCodec) Name() string {
+	return "msgpack"
+}
+
+func (msgpackCodec) Marshal(v any) ([]byte, error) {
+	return msgpack.Marshal(v)
+}
+
+func (msgpackCodec) Unmarshal(data []byte, v any) error {
+	return msgpack.Unmarshal(data, v)
+}
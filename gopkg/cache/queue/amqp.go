@@ -1,21 +1,41 @@
 package queue
 
 import (
-	"fmt"
-	"github.com/spf13/viper"
-	"log"
+	"context"
+	"errors"
+	"sort"
 	"sync"
+	"time"
+
+	"go-agent/gopkg/graceful"
 
+	"github.com/spf13/viper"
 	"github.com/streadway/amqp"
 )
 
 type Config struct {
-	Addresses string `json:"addresses" mapstructure:"addresses"`   // 服务地址集合
-	Username  string `json:"username" mapstructure:"username"`     // 用户名
-	Password  string `json:"password" mapstructure:"password"`     // 密码
-	QueueName string `json:"queue-name" mapstructure:"queue-name"` //队列名
+	Addresses         string        `json:"addresses" mapstructure:"addresses"`                     // 服务地址集合
+	Username          string        `json:"username" mapstructure:"username"`                       // 用户名
+	Password          string        `json:"password" mapstructure:"password"`                       // 密码
+	QueueName         string        `json:"queue-name" mapstructure:"queue-name"`                   //队列名
+	DialTimeout       time.Duration `json:"dial-timeout" mapstructure:"dial-timeout"`               // 建立连接超时，默认 10s
+	Heartbeat         time.Duration `json:"heartbeat" mapstructure:"heartbeat"`                     // 心跳间隔，默认 10s
+	ReconnectDelay    time.Duration `json:"reconnect-delay" mapstructure:"reconnect-delay"`         // 首次重连等待时间，默认 1s，之后指数增长
+	MaxReconnectDelay time.Duration `json:"max-reconnect-delay" mapstructure:"max-reconnect-delay"` // 重连等待时间上限，默认 30s
+	Durable           bool          `json:"durable" mapstructure:"durable"`                         // 队列是否持久化
+	AutoDelete        bool          `json:"auto-delete" mapstructure:"auto-delete"`                 // 没有消费者时是否自动删除队列
+	Confirm           bool          `json:"confirm" mapstructure:"confirm"`                         // 是否开启 publisher confirms，开启后 Publish 等待 broker 确认
+	Prefetch          int           `json:"prefetch" mapstructure:"prefetch"`                       // 每个消费者未确认消息数上限，0 表示不限制
+	AppID             string        `json:"app-id" mapstructure:"app-id"`                           // 消息的 AppId
+	Headers           amqp.Table    `json:"headers" mapstructure:"headers"`                         // 每条消息附带的默认 headers
+
+	Exchanges map[string]ExchangeConfig `json:"exchanges" mapstructure:"exchanges"` // 连接时声明的交换机
+	Queues    map[string]QueueConfig    `json:"queues" mapstructure:"queues"`       // 连接时声明的队列及绑定
 }
 
+// ErrClientNotExists 客户端不存在
+var ErrClientNotExists = errors.New("rabbitmq client not exists")
+
 type ClientManager struct {
 	mu      sync.RWMutex
 	clients map[string]*RabbitMQ
@@ -33,6 +53,32 @@ func (cm *ClientManager) Get(name string) *RabbitMQ {
 	return cm.clients[name]
 }
 
+// States 所有客户端的连接状态，用于健康检查
+func (cm *ClientManager) States() map[string]State {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	states := make(map[string]State, len(cm.clients))
+	for name, client := range cm.clients {
+		states[name] = client.State()
+	}
+	return states
+}
+
+// Close 关闭所有客户端
+func (cm *ClientManager) Close() {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	names := make([]string, 0, len(cm.clients))
+	for name := range cm.clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		_ = cm.clients[name].Close()
+		delete(cm.clients, name)
+	}
+}
+
 func NewClientManager() *ClientManager {
 	return &ClientManager{
 		clients: make(map[string]*RabbitMQ),
@@ -41,97 +87,51 @@ func NewClientManager() *ClientManager {
 
 var clientManager = NewClientManager()
 
-type RabbitMQ struct {
-	conn    *amqp.Connection
-	channel *amqp.Channel
-	queue   amqp.Queue
-}
-
+// Initialize 根据配置文件加载RabbitMQ实例及消息总线，未配置时不做任何事
 func Initialize() error {
-	// 解析ES配置
+	// 解析RabbitMQ配置
 	var cfgMap map[string]Config
 	if err := viper.UnmarshalKey("rabbitmq", &cfgMap); err != nil {
 		return err
 	}
-	// 数据库客户端链接添加到ClientManager
+	// 客户端链接添加到ClientManager
 	for name, cfg := range cfgMap {
-		// 实例化数据库链接组
+		// 实例化客户端，连接断开后自动重连
 		client, err := NewClient(cfg)
 		if err != nil {
 			return err
 		}
-		// 添加到GroupManager
+		// 添加到ClientManager
 		clientManager.Add(name, client)
 	}
-	return nil
-}
-
-func failOnError(err error, msg string) {
-	if err != nil {
-		log.Fatalf("%s: %s", msg, err)
-	}
-}
-
-func NewClient(cfg Config) (client *RabbitMQ, err error) {
-	conn, err := amqp.Dial(fmt.Sprintf("amqp://%s:%s@%s", cfg.Username, cfg.Password, cfg.Addresses))
-	if err != nil {
-		return nil, err
-	}
-
-	ch, err := conn.Channel()
-	if err != nil {
-		return nil, err
-	}
-
-	q, err := ch.QueueDeclare(
-		cfg.QueueName, // 队列名称
-		false,         // 消息是否持久化
-		false,         // 未使用队列是否自动删除
-		false,         // 是否为排他队列
-		false,         // 队列是否阻塞
-		nil,           // 额外的属性
-	)
-	if err != nil {
-		return nil, err
+	// 消息总线依赖上面的 rabbitmq 客户端及已初始化的 redis 客户端
+	if err := initBus(); err != nil {
+		return err
 	}
-	client = &RabbitMQ{
-		conn:    conn,
-		channel: ch,
-		queue:   q,
+	if len(cfgMap) > 0 {
+		// 进程退出时关闭连接，避免退出过程中继续重连
+		graceful.StartFunc(func(ctx context.Context) {
+			<-ctx.Done()
+			Close()
+		})
 	}
-
-	return
+	return nil
 }
 
-func (r *RabbitMQ) Close() {
-	r.channel.Close()
-	r.conn.Close()
+// Client 获取指定的RabbitMQ客户端
+func Client(name string) (*RabbitMQ, error) {
+	if client := clientManager.Get(name); client != nil {
+		return client, nil
+	}
+	return nil, ErrClientNotExists
 }
 
-func (r *RabbitMQ) Publish(body string) {
-	err := r.channel.Publish(
-		"",           // 交换机名称
-		r.queue.Name, // 路由键
-		false,        // 如果无法路由到队列则返回消息
-		false,        // 如果交换到队列的路由无匹配则丢弃消息
-		amqp.Publishing{
-			ContentType: "text/plain",
-			Body:        []byte(body),
-		})
-	failOnError(err, "Failed to publish a message")
-	fmt.Printf(" [x] Sent %s\n", body)
+// States 所有客户端的连接状态，用于健康检查
+func States() map[string]State {
+	return clientManager.States()
 }
 
-func (r *RabbitMQ) Consume() (<-chan amqp.Delivery, error) {
-	msgs, err := r.channel.Consume(
-		r.queue.Name, // 队列名称
-		"",           // 消费者标签
-		true,         // 自动确认消息
-		false,        // 是否为独占消费者
-		false,        // 是否等待服务器确认
-		false,        // 是否阻塞
-		nil,          // 额外的属性
-	)
-	failOnError(err, "Failed to register a consumer")
-	return msgs, err
+// Close 关闭所有客户端
+func Close() {
+	clientManager.Close()
 }
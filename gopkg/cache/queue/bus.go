@@ -0,0 +1,155 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	rxRedis "go-agent/gopkg/cache/redis"
+
+	"github.com/spf13/viper"
+)
+
+// ErrBusNotExists 消息总线不存在
+var ErrBusNotExists = errors.New("message bus not exists")
+
+// Msg 与具体 broker 无关的消息
+type Msg struct {
+	ID        string            // 消息ID，为空时发送时自动生成
+	Topic     string            // 主题，AMQP 为路由键/队列名，Redis 为 stream
+	Body      []byte            // 消息内容
+	Headers   map[string]string // 消息头
+	Timestamp time.Time         // 发送时间
+	Attempt   int               // 第几次投递，从 1 开始，AMQP 只能区分首次及重新投递
+}
+
+// MsgHandler 消息处理函数，返回 nil 时确认消息，返回错误时稍后重新投递，返回 Reject 包装的错误时不再投递
+type MsgHandler func(ctx context.Context, msg *Msg) error
+
+// Publisher 消息发送方
+type Publisher interface {
+	// Publish 发送消息到 topic
+	Publish(ctx context.Context, topic string, msg *Msg) error
+}
+
+// Subscriber 消息订阅方，同一 topic 的多个订阅者竞争消费，每条消息只由其中一个处理
+type Subscriber interface {
+	// Subscribe 订阅 topic 并阻塞处理消息，直到 ctx 取消
+	Subscribe(ctx context.Context, topic string, handler MsgHandler) error
+}
+
+// MessageBus 与具体 broker 无关的消息总线
+type MessageBus interface {
+	Publisher
+	Subscriber
+	Close() error
+}
+
+// BusConfig 消息总线配置，对应 bus.<name>
+type BusConfig struct {
+	Backend       string        `json:"backend" mapstructure:"backend"`               // memory, amqp, redis，默认 memory
+	Client        string        `json:"client" mapstructure:"client"`                 // amqp 为 rabbitmq 客户端名称，redis 为 redis 客户端名称
+	Exchange      string        `json:"exchange" mapstructure:"exchange"`             // amqp 发送使用的交换机，为空时使用默认交换机
+	Prefix        string        `json:"prefix" mapstructure:"prefix"`                 // redis stream 键前缀
+	Group         string        `json:"group" mapstructure:"group"`                   // redis 消费组名称
+	MaxLen        int64         `json:"max-len" mapstructure:"max-len"`               // redis stream 保留的最大消息数(近似)，0 表示不限制
+	Block         time.Duration `json:"block" mapstructure:"block"`                   // redis XREADGROUP 阻塞等待时间
+	ClaimIdle     time.Duration `json:"claim-idle" mapstructure:"claim-idle"`         // redis 消息未确认超过该时间后由其他消费者 XCLAIM
+	ClaimInterval time.Duration `json:"claim-interval" mapstructure:"claim-interval"` // redis 检查未确认消息的间隔
+	MaxDeliveries int           `json:"max-deliveries" mapstructure:"max-deliveries"` // redis 及 memory 最大投递次数，超过后进入死信
+}
+
+const (
+	defaultBusPrefix     = "go-agent:bus"
+	defaultBusGroup      = "go-agent"
+	defaultBusBlock      = 5 * time.Second
+	defaultClaimIdle     = time.Minute
+	defaultClaimInterval = 30 * time.Second
+	defaultMaxDeliveries = 5
+)
+
+func (c *BusConfig) format() {
+	if c.Prefix == "" {
+		c.Prefix = defaultBusPrefix
+	}
+	if c.Group == "" {
+		c.Group = defaultBusGroup
+	}
+	if c.Block <= 0 {
+		c.Block = defaultBusBlock
+	}
+	if c.ClaimIdle <= 0 {
+		c.ClaimIdle = defaultClaimIdle
+	}
+	if c.ClaimInterval <= 0 {
+		c.ClaimInterval = defaultClaimInterval
+	}
+	if c.MaxDeliveries <= 0 {
+		c.MaxDeliveries = defaultMaxDeliveries
+	}
+}
+
+// NewBus 根据配置创建消息总线，amqp 及 redis 后端使用已初始化的同名客户端
+func NewBus(cfg BusConfig) (MessageBus, error) {
+	cfg.format()
+	switch cfg.Backend {
+	case "amqp":
+		client, err := Client(cfg.Client)
+		if err != nil {
+			return nil, err
+		}
+		return NewAMQPBus(client, cfg.Exchange), nil
+	case "redis":
+		client, err := rxRedis.ClientAndErr(cfg.Client)
+		if err != nil {
+			return nil, err
+		}
+		return NewRedisBus(client, cfg), nil
+	case "", "memory":
+		return NewMemoryBus(cfg.MaxDeliveries), nil
+	default:
+		return nil, fmt.Errorf("unknown message bus backend: %s", cfg.Backend)
+	}
+}
+
+var (
+	busMu sync.RWMutex
+	buses = make(map[string]MessageBus)
+)
+
+// initBus 根据 bus 配置初始化消息总线
+func initBus() error {
+	var cfgMap map[string]BusConfig
+	if err := viper.UnmarshalKey("bus", &cfgMap); err != nil {
+		return err
+	}
+	busMu.Lock()
+	defer busMu.Unlock()
+	for name, cfg := range cfgMap {
+		bus, err := NewBus(cfg)
+		if err != nil {
+			return fmt.Errorf("message bus %s: %w", name, err)
+		}
+		buses[name] = bus
+	}
+	return nil
+}
+
+// Bus 获取指定的消息总线
+func Bus(name string) (MessageBus, error) {
+	busMu.RLock()
+	defer busMu.RUnlock()
+	if bus, ok := buses[name]; ok {
+		return bus, nil
+	}
+	return nil, ErrBusNotExists
+}
+
+// SetBus 设置指定名称的消息总线，用于测试时替换为 MemoryBus
+func SetBus(name string, bus MessageBus) {
+	busMu.Lock()
+	defer busMu.Unlock()
+	buses[name] = bus
+}
@@ -0,0 +1,68 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/streadway/amqp"
+)
+
+// AMQPBus 基于 RabbitMQ 的消息总线
+//
+// 发送时 topic 作为路由键，订阅时 topic 作为队列名；使用默认交换机时两者一致，
+// 使用其他交换机时需在 rabbitmq 配置中声明队列及绑定。重试依赖 broker 重新投递，死信依赖队列的死信交换机。
+type AMQPBus struct {
+	client   *RabbitMQ
+	exchange string
+}
+
+// NewAMQPBus 实例化AMQPBus
+func NewAMQPBus(client *RabbitMQ, exchange string) *AMQPBus {
+	return &AMQPBus{client: client, exchange: exchange}
+}
+
+// Publish 发送消息
+func (b *AMQPBus) Publish(ctx context.Context, topic string, msg *Msg) error {
+	headers := make(amqp.Table, len(msg.Headers))
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	return b.client.Publish(ctx, Message{
+		Exchange:   b.exchange,
+		RoutingKey: topic,
+		Body:       msg.Body,
+		MessageID:  msg.ID,
+		Headers:    headers,
+	})
+}
+
+// Subscribe 订阅以 topic 命名的队列
+func (b *AMQPBus) Subscribe(ctx context.Context, topic string, handler MsgHandler) error {
+	return b.client.Subscribe(ctx, topic, func(ctx context.Context, d amqp.Delivery) error {
+		return handler(ctx, deliveryMsg(topic, d))
+	})
+}
+
+// Close 客户端由 ClientManager 管理，这里不关闭
+func (b *AMQPBus) Close() error {
+	return nil
+}
+
+// deliveryMsg amqp.Delivery 转换为 Msg
+func deliveryMsg(topic string, d amqp.Delivery) *Msg {
+	msg := &Msg{
+		ID:        d.MessageId,
+		Topic:     topic,
+		Body:      d.Body,
+		Headers:   make(map[string]string, len(d.Headers)),
+		Timestamp: d.Timestamp,
+		Attempt:   1,
+	}
+	if d.Redelivered {
+		msg.Attempt = 2
+	}
+	for k, v := range d.Headers {
+		msg.Headers[k] = fmt.Sprint(v)
+	}
+	return msg
+}
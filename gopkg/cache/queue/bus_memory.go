@@ -0,0 +1,111 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go-agent/gopkg/log"
+	"go-agent/gopkg/utils"
+
+	"go.uber.org/zap"
+)
+
+// memoryBuffer 每个 topic 的缓冲大小，缓冲满时 Publish 阻塞
+const memoryBuffer = 1024
+
+// MemoryBus 基于内存的消息总线，只在当前进程内有效，用于本地开发及测试
+type MemoryBus struct {
+	mu            sync.Mutex
+	topics        map[string]chan *Msg
+	dead          map[string][]*Msg
+	maxDeliveries int
+	logger        *zap.SugaredLogger
+}
+
+// NewMemoryBus 实例化MemoryBus，maxDeliveries 为最大投递次数，超过后进入死信
+func NewMemoryBus(maxDeliveries int) *MemoryBus {
+	if maxDeliveries <= 0 {
+		maxDeliveries = defaultMaxDeliveries
+	}
+	return &MemoryBus{
+		topics:        make(map[string]chan *Msg),
+		dead:          make(map[string][]*Msg),
+		maxDeliveries: maxDeliveries,
+		logger:        log.Sugar(),
+	}
+}
+
+func (b *MemoryBus) topic(name string) chan *Msg {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ch, ok := b.topics[name]
+	if !ok {
+		ch = make(chan *Msg, memoryBuffer)
+		b.topics[name] = ch
+	}
+	return ch
+}
+
+// Publish 发送消息
+func (b *MemoryBus) Publish(ctx context.Context, topic string, msg *Msg) error {
+	m := *msg
+	m.Topic = topic
+	m.Attempt = 1
+	if m.ID == "" {
+		m.ID = utils.GenUUIDWithoutUnderline()
+	}
+	if m.Timestamp.IsZero() {
+		m.Timestamp = time.Now()
+	}
+	select {
+	case b.topic(topic) <- &m:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Subscribe 订阅 topic，处理失败的消息重新放回队列
+func (b *MemoryBus) Subscribe(ctx context.Context, topic string, handler MsgHandler) error {
+	ch := b.topic(topic)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg := <-ch:
+			err := recoverReject(func() error { return handler(ctx, msg) })
+			if err == nil {
+				continue
+			}
+			if IsReject(err) || msg.Attempt >= b.maxDeliveries {
+				b.logger.Errorf("消息处理失败，进入死信: %s (ID: %s), 错误: %v", topic, msg.ID, err)
+				b.mu.Lock()
+				b.dead[topic] = append(b.dead[topic], msg)
+				b.mu.Unlock()
+				continue
+			}
+			retry := *msg
+			retry.Attempt++
+			// 异步放回，避免缓冲已满时阻塞消费
+			go func() {
+				select {
+				case ch <- &retry:
+				case <-ctx.Done():
+				}
+			}()
+		}
+	}
+}
+
+// DeadLetters 进入死信的消息
+func (b *MemoryBus) DeadLetters(topic string) []*Msg {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*Msg(nil), b.dead[topic]...)
+}
+
+// Close 无需释放资源
+func (b *MemoryBus) Close() error {
+	return nil
+}
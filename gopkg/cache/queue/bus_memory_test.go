@@ -0,0 +1,54 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryBus(t *testing.T) {
+	bus := NewMemoryBus(3)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var handled atomic.Int32
+	done := make(chan struct{})
+	go func() {
+		_ = bus.Subscribe(ctx, "order", func(ctx context.Context, msg *Msg) error {
+			switch string(msg.Body) {
+			case "retry":
+				if msg.Attempt < 2 {
+					return errors.New("temporary")
+				}
+			case "poison":
+				return Reject(errors.New("invalid"))
+			case "flaky":
+				return errors.New("always failing")
+			}
+			if handled.Add(1) == 2 {
+				close(done)
+			}
+			return nil
+		})
+	}()
+
+	for _, body := range []string{"ok", "retry", "poison", "flaky"} {
+		assert.NoError(t, bus.Publish(ctx, "order", &Msg{Body: []byte(body)}))
+	}
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		t.Fatal("timeout")
+	}
+	assert.Eventually(t, func() bool { return len(bus.DeadLetters("order")) == 2 }, time.Second, 10*time.Millisecond)
+	for _, msg := range bus.DeadLetters("order") {
+		if string(msg.Body) == "flaky" {
+			assert.Equal(t, 3, msg.Attempt)
+		}
+	}
+}
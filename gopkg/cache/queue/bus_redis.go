@@ -0,0 +1,244 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-agent/gopkg/log"
+	"go-agent/gopkg/utils"
+
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+)
+
+// 每次读取及认领的最大消息数
+const (
+	redisReadCount  = 10
+	redisClaimCount = 100
+)
+
+// RedisBus 基于 Redis Streams 的消息总线
+//
+// 每个 topic 对应一个 stream，订阅者通过消费组竞争消费；处理失败的消息保持未确认，
+// 超过 claim-idle 后由任一订阅者 XCLAIM 重新处理，投递次数超过 max-deliveries 后写入死信 stream。
+type RedisBus struct {
+	client   redis.UniversalClient
+	cfg      BusConfig
+	consumer string
+	logger   *zap.SugaredLogger
+}
+
+// NewRedisBus 实例化RedisBus
+func NewRedisBus(client redis.UniversalClient, cfg BusConfig) *RedisBus {
+	cfg.format()
+	hostname, _ := os.Hostname()
+	return &RedisBus{
+		client:   client,
+		cfg:      cfg,
+		consumer: fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		logger:   log.Sugar(),
+	}
+}
+
+func (b *RedisBus) streamKey(topic string) string {
+	return b.cfg.Prefix + ":stream:{" + topic + "}"
+}
+
+func (b *RedisBus) deadKey(topic string) string {
+	return b.streamKey(topic) + ":dead"
+}
+
+// Publish 发送消息
+func (b *RedisBus) Publish(ctx context.Context, topic string, msg *Msg) error {
+	return b.add(ctx, b.streamKey(topic), msg, b.cfg.MaxLen)
+}
+
+func (b *RedisBus) add(ctx context.Context, stream string, msg *Msg, maxLen int64) error {
+	id := msg.ID
+	if id == "" {
+		id = utils.GenUUIDWithoutUnderline()
+	}
+	timestamp := msg.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	headers, err := json.Marshal(msg.Headers)
+	if err != nil {
+		return err
+	}
+	return b.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
+		MaxLen: maxLen,
+		Approx: maxLen > 0,
+		Values: map[string]interface{}{
+			"id":        id,
+			"body":      msg.Body,
+			"headers":   headers,
+			"timestamp": timestamp.UnixMilli(),
+		},
+	}).Err()
+}
+
+// Subscribe 以消费组订阅 topic，首次创建消费组时从 stream 起始位置开始消费
+func (b *RedisBus) Subscribe(ctx context.Context, topic string, handler MsgHandler) error {
+	stream := b.streamKey(topic)
+	err := b.client.XGroupCreateMkStream(ctx, stream, b.cfg.Group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+
+	var lastClaim time.Time
+	for ctx.Err() == nil {
+		if time.Since(lastClaim) >= b.cfg.ClaimInterval {
+			lastClaim = time.Now()
+			if err := b.claim(ctx, topic, handler); err != nil && ctx.Err() == nil {
+				b.logger.Errorf("认领未确认消息失败: %s, 错误: %v", topic, err)
+			}
+		}
+
+		streams, err := b.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    b.cfg.Group,
+			Consumer: b.consumer,
+			Streams:  []string{stream, ">"},
+			Count:    redisReadCount,
+			Block:    b.cfg.Block,
+		}).Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			b.logger.Errorf("读取消息失败: %s, 错误: %v", topic, err)
+			sleep(ctx, time.Second)
+			continue
+		}
+		for _, s := range streams {
+			for _, message := range s.Messages {
+				b.handle(ctx, topic, message, 1, handler)
+			}
+		}
+	}
+	return nil
+}
+
+// claim 认领空闲超过 claim-idle 的未确认消息并重新处理，投递次数超限的写入死信
+func (b *RedisBus) claim(ctx context.Context, topic string, handler MsgHandler) error {
+	stream := b.streamKey(topic)
+	pending, err := b.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: stream,
+		Group:  b.cfg.Group,
+		Start:  "-",
+		End:    "+",
+		Count:  redisClaimCount,
+	}).Result()
+	if err != nil {
+		return err
+	}
+
+	attempts := make(map[string]int64, len(pending))
+	ids := make([]string, 0, len(pending))
+	for _, p := range pending {
+		// 兼容 6.2 以下版本，在客户端按空闲时间过滤
+		if p.Idle < b.cfg.ClaimIdle {
+			continue
+		}
+		attempts[p.ID] = p.RetryCount
+		ids = append(ids, p.ID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	messages, err := b.client.XClaim(ctx, &redis.XClaimArgs{
+		Stream:   stream,
+		Group:    b.cfg.Group,
+		Consumer: b.consumer,
+		MinIdle:  b.cfg.ClaimIdle,
+		Messages: ids,
+	}).Result()
+	if err != nil {
+		return err
+	}
+	for _, message := range messages {
+		attempt := int(attempts[message.ID]) + 1
+		if attempt > b.cfg.MaxDeliveries {
+			b.dead(ctx, topic, message, fmt.Errorf("exceeded max deliveries %d", b.cfg.MaxDeliveries))
+			continue
+		}
+		b.handle(ctx, topic, message, attempt, handler)
+	}
+	return nil
+}
+
+// handle 处理消息，成功时 XACK，Reject 时写入死信并 XACK，其他错误保持未确认等待认领
+func (b *RedisBus) handle(ctx context.Context, topic string, message redis.XMessage, attempt int, handler MsgHandler) {
+	msg := streamMsg(topic, message)
+	msg.Attempt = attempt
+	err := recoverReject(func() error { return handler(ctx, msg) })
+	switch {
+	case err == nil:
+		if err := b.client.XAck(ctx, b.streamKey(topic), b.cfg.Group, message.ID).Err(); err != nil {
+			b.logger.Errorf("消息确认失败: %s (ID: %s), 错误: %v", topic, msg.ID, err)
+		}
+	case IsReject(err):
+		b.dead(ctx, topic, message, err)
+	default:
+		b.logger.Warnf("消息处理失败，%v 后重新投递: %s (ID: %s), 错误: %v", b.cfg.ClaimIdle, topic, msg.ID, err)
+	}
+}
+
+// dead 写入死信 stream 并确认原消息
+func (b *RedisBus) dead(ctx context.Context, topic string, message redis.XMessage, reason error) {
+	b.logger.Errorf("消息处理失败，进入死信: %s (ID: %s), 错误: %v", topic, message.ID, reason)
+	msg := streamMsg(topic, message)
+	if msg.Headers == nil {
+		msg.Headers = make(map[string]string)
+	}
+	msg.Headers["x-dead-reason"] = reason.Error()
+	if err := b.add(ctx, b.deadKey(topic), msg, 0); err != nil {
+		b.logger.Errorf("写入死信失败: %s (ID: %s), 错误: %v", topic, msg.ID, err)
+		return
+	}
+	if err := b.client.XAck(ctx, b.streamKey(topic), b.cfg.Group, message.ID).Err(); err != nil {
+		b.logger.Errorf("消息确认失败: %s (ID: %s), 错误: %v", topic, msg.ID, err)
+	}
+}
+
+// Close 客户端由 redis 包管理，这里不关闭
+func (b *RedisBus) Close() error {
+	return nil
+}
+
+// streamMsg stream 消息转换为 Msg
+func streamMsg(topic string, message redis.XMessage) *Msg {
+	msg := &Msg{Topic: topic, Attempt: 1}
+	msg.ID, _ = message.Values["id"].(string)
+	if body, ok := message.Values["body"].(string); ok {
+		msg.Body = []byte(body)
+	}
+	if headers, ok := message.Values["headers"].(string); ok {
+		_ = json.Unmarshal([]byte(headers), &msg.Headers)
+	}
+	if ts, ok := message.Values["timestamp"].(string); ok {
+		if ms, err := strconv.ParseInt(ts, 10, 64); err == nil {
+			msg.Timestamp = time.UnixMilli(ms)
+		}
+	}
+	return msg
+}
+
+// sleep 等待 d 或 ctx 取消
+func sleep(ctx context.Context, d time.Duration) {
+	select {
+	case <-time.After(d):
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,79 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisBus(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	bus := NewRedisBus(client, BusConfig{
+		Block:         20 * time.Millisecond,
+		ClaimIdle:     50 * time.Millisecond,
+		ClaimInterval: 20 * time.Millisecond,
+		MaxDeliveries: 3,
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var mu sync.Mutex
+	attempts := make(map[string][]int)
+	go func() {
+		_ = bus.Subscribe(ctx, "order", func(ctx context.Context, msg *Msg) error {
+			mu.Lock()
+			attempts[string(msg.Body)] = append(attempts[string(msg.Body)], msg.Attempt)
+			mu.Unlock()
+			switch string(msg.Body) {
+			case "retry":
+				if msg.Attempt < 2 {
+					return errors.New("temporary")
+				}
+			case "poison":
+				return Reject(errors.New("invalid"))
+			case "flaky":
+				return errors.New("always failing")
+			}
+			return nil
+		})
+	}()
+
+	for _, body := range []string{"ok", "retry", "poison", "flaky"} {
+		assert.NoError(t, bus.Publish(ctx, "order", &Msg{ID: body, Body: []byte(body)}))
+	}
+
+	// 失败的消息超过 claim-idle 后被认领重新处理，超过 max-deliveries 后进入死信
+	assert.Eventually(t, func() bool {
+		n, err := client.XLen(ctx, bus.deadKey("order")).Result()
+		return err == nil && n == 2
+	}, 3*time.Second, 20*time.Millisecond)
+
+	mu.Lock()
+	assert.Equal(t, []int{1}, attempts["ok"])
+	assert.Equal(t, []int{1, 2}, attempts["retry"])
+	assert.Equal(t, []int{1}, attempts["poison"])
+	assert.Equal(t, []int{1, 2, 3}, attempts["flaky"])
+	mu.Unlock()
+
+	// 全部消息已确认
+	pending, err := client.XPending(ctx, bus.streamKey("order"), bus.cfg.Group).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), pending.Count)
+
+	dead, err := client.XRange(ctx, bus.deadKey("order"), "-", "+").Result()
+	assert.NoError(t, err)
+	reasons := make(map[string]string)
+	for _, message := range dead {
+		msg := streamMsg("order", message)
+		reasons[msg.ID] = msg.Headers["x-dead-reason"]
+	}
+	assert.Contains(t, reasons["poison"], "invalid")
+	assert.Contains(t, reasons["flaky"], "exceeded max deliveries 3")
+}
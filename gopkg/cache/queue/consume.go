@@ -0,0 +1,173 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"go-agent/gopkg/utils"
+
+	"github.com/streadway/amqp"
+)
+
+// Handler 消息处理函数，返回 nil 时 ack，返回错误时 nack 并重新入队，返回 Reject 包装的错误时不重新入队
+type Handler func(ctx context.Context, d amqp.Delivery) error
+
+// rejectError 不重新入队的错误
+type rejectError struct {
+	err error
+}
+
+func (e *rejectError) Error() string {
+	return e.err.Error()
+}
+
+func (e *rejectError) Unwrap() error {
+	return e.err
+}
+
+// Reject 包装错误，消息 nack 后不重新入队（配置了死信交换机时进入死信队列）
+func Reject(err error) error {
+	return &rejectError{err: err}
+}
+
+// IsReject 错误是否为 Reject 包装的错误
+func IsReject(err error) bool {
+	var target *rejectError
+	return errors.As(err, &target)
+}
+
+// Consume 订阅配置的队列，消息需手动 Ack/Nack，连接断开重连后自动重新订阅
+//
+// 重连后未确认的消息会由 broker 重新投递，旧通道上的消息无法再确认。
+// 返回的通道在 ctx 取消或客户端关闭后关闭。
+func (r *RabbitMQ) Consume(ctx context.Context) (<-chan amqp.Delivery, error) {
+	return r.consume(ctx, r.cfg.QueueName)
+}
+
+// ConsumeFunc 订阅配置的队列并调用 handler 处理，阻塞直到 ctx 取消或客户端关闭
+//
+// handler 返回 nil 时 ack，返回错误时 nack 并重新入队，返回 Reject 包装的错误或 panic 时不重新入队。
+func (r *RabbitMQ) ConsumeFunc(ctx context.Context, handler Handler) error {
+	return r.Subscribe(ctx, r.cfg.QueueName, handler)
+}
+
+// handle 调用 handler 并确认消息
+func (r *RabbitMQ) handle(ctx context.Context, d amqp.Delivery, handler Handler) {
+	err := recoverReject(func() error { return handler(ctx, d) })
+	switch {
+	case err == nil:
+		if ackErr := d.Ack(false); ackErr != nil {
+			r.logger.Errorf("rabbitmq ack 失败: %s, 错误: %v", d.MessageId, ackErr)
+		}
+		return
+	case IsReject(err):
+		r.logger.Errorf("rabbitmq 消息处理失败，不再重试: %s, 错误: %v", d.MessageId, err)
+		err = d.Nack(false, false)
+	default:
+		r.logger.Warnf("rabbitmq 消息处理失败，重新入队: %s, 错误: %v", d.MessageId, err)
+		err = d.Nack(false, true)
+	}
+	if err != nil {
+		r.logger.Errorf("rabbitmq nack 失败: %s, 错误: %v", d.MessageId, err)
+	}
+}
+
+// recoverReject 执行 fn，panic 转换为 Reject 错误，避免毒消息反复重新投递
+func recoverReject(fn func() error) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = Reject(fmt.Errorf("panic: %v\n%s", p, debug.Stack()))
+		}
+	}()
+	return fn()
+}
+
+// consume 订阅队列，连接断开重连后自动重新订阅
+func (r *RabbitMQ) consume(ctx context.Context, queue string) (<-chan amqp.Delivery, error) {
+	tag := "go-agent-" + utils.GenUUIDWithoutUnderline()
+	deliveries, err := r.subscribe(queue, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan amqp.Delivery)
+	go func() {
+		defer close(out)
+		defer r.cancel(tag)
+		for {
+			if !r.forward(ctx, deliveries, out) {
+				return
+			}
+			// 通道已关闭，等待重连后重新订阅
+			for {
+				if err := r.wait(ctx); err != nil {
+					return
+				}
+				if deliveries, err = r.subscribe(queue, tag); err == nil {
+					r.logger.Infof("rabbitmq 重新订阅成功: %s", queue)
+					break
+				}
+				r.logger.Warnf("rabbitmq 重新订阅失败: %s, 错误: %v", queue, err)
+				select {
+				case <-time.After(r.cfg.ReconnectDelay):
+				case <-ctx.Done():
+					return
+				case <-r.done:
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// subscribe 在当前通道上注册消费者，消息需手动确认
+func (r *RabbitMQ) subscribe(queue, tag string) (<-chan amqp.Delivery, error) {
+	ch, err := r.current()
+	if err != nil {
+		return nil, err
+	}
+	return ch.Consume(
+		queue, // 队列名称
+		tag,   // 消费者标签
+		false, // 自动确认消息
+		false, // 是否为独占消费者
+		false, // 是否等待服务器确认
+		false, // 是否阻塞
+		nil,   // 额外的属性
+	)
+}
+
+// forward 转发消息直到通道关闭（返回 true）或 ctx 取消、客户端关闭（返回 false）
+func (r *RabbitMQ) forward(ctx context.Context, deliveries <-chan amqp.Delivery, out chan<- amqp.Delivery) bool {
+	for {
+		select {
+		case d, ok := <-deliveries:
+			if !ok {
+				return true
+			}
+			select {
+			case out <- d:
+			case <-ctx.Done():
+				_ = d.Nack(false, true)
+				return false
+			case <-r.done:
+				return false
+			}
+		case <-ctx.Done():
+			return false
+		case <-r.done:
+			return false
+		}
+	}
+}
+
+// cancel 取消消费者，连接已断开时忽略
+func (r *RabbitMQ) cancel(tag string) {
+	if ch, err := r.current(); err == nil {
+		_ = ch.Cancel(tag, false)
+	}
+}
@@ -0,0 +1,279 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go-agent/gopkg/utils"
+
+	"github.com/getsentry/sentry-go"
+)
+
+// 消息头中的类型及版本，便于不解码消息体时过滤
+const (
+	HeaderMessageType    = "x-message-type"
+	HeaderMessageVersion = "x-message-version"
+)
+
+var (
+	// ErrUnknownType 没有处理该类型的 handler
+	ErrUnknownType = errors.New("unknown message type")
+	// ErrNoUpcaster 缺少从旧版本升级的 upcaster
+	ErrNoUpcaster = errors.New("no upcaster for message version")
+	// ErrTopicTypeConflict topic 上已有处理其他类型集合的订阅
+	ErrTopicTypeConflict = errors.New("topic already subscribed with different message types")
+)
+
+// Contract 消息契约，类型名称及当前版本由生产方与消费方共同约定
+//
+// 修改 payload 结构时应递增版本并为旧版本注册 upcaster。
+type Contract interface {
+	MessageType() string
+	MessageVersion() int
+}
+
+// Envelope 消息信封，payload 以 JSON 编码
+type Envelope struct {
+	ID        string            `json:"id"`
+	Type      string            `json:"type"`
+	Version   int               `json:"version"`
+	Timestamp time.Time         `json:"timestamp"`
+	RequestID string            `json:"request_id,omitempty"`
+	Trace     map[string]string `json:"trace,omitempty"` // sentry-trace 及 baggage，用于串联生产方与消费方的链路
+	Payload   json.RawMessage   `json:"payload"`
+}
+
+// NewEnvelope 将 payload 包装为信封，请求ID及链路信息取自 ctx
+func NewEnvelope[T Contract](ctx context.Context, payload T) (*Envelope, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshal %s: %w", payload.MessageType(), err)
+	}
+	env := &Envelope{
+		ID:        utils.GenUUIDWithoutUnderline(),
+		Type:      payload.MessageType(),
+		Version:   payload.MessageVersion(),
+		Timestamp: time.Now(),
+		RequestID: utils.GetRequestID(ctx),
+		Payload:   data,
+	}
+	if span := sentry.SpanFromContext(ctx); span != nil {
+		env.Trace = map[string]string{
+			sentry.SentryTraceHeader:   span.ToSentryTrace(),
+			sentry.SentryBaggageHeader: span.ToBaggage(),
+		}
+	}
+	return env, nil
+}
+
+// Msg 编码为待发送的消息，消息ID与信封ID一致
+func (e *Envelope) Msg() (*Msg, error) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return &Msg{
+		ID:        e.ID,
+		Body:      body,
+		Timestamp: e.Timestamp,
+		Headers: map[string]string{
+			HeaderMessageType:    e.Type,
+			HeaderMessageVersion: strconv.Itoa(e.Version),
+		},
+	}, nil
+}
+
+// Publish 将 payload 包装为信封并发送到 topic
+func Publish[T Contract](ctx context.Context, p Publisher, topic string, payload T) error {
+	env, err := NewEnvelope(ctx, payload)
+	if err != nil {
+		return err
+	}
+	msg, err := env.Msg()
+	if err != nil {
+		return err
+	}
+	return p.Publish(ctx, topic, msg)
+}
+
+// Subscribe 订阅 topic 上类型为 T 的消息，其他类型的消息不再投递
+//
+// topic 只能承载类型 T，承载多种类型时应将各类型注册到同一个 Mux 后通过 Mux.Subscribe 订阅，见 Mux.Subscribe。
+func Subscribe[T Contract](ctx context.Context, s Subscriber, topic string, handler func(ctx context.Context, payload T, env *Envelope) error) error {
+	mux := NewMux()
+	Handle(mux, handler)
+	return mux.Subscribe(ctx, s, topic)
+}
+
+// topicSubscription topic 上订阅的类型集合及订阅数
+type topicSubscription struct {
+	types string
+	count int
+}
+
+var (
+	subscriptionsMu sync.Mutex
+	subscriptions   = make(map[Subscriber]map[string]*topicSubscription)
+)
+
+// Upcaster 将 payload 从 from 版本升级到 from+1 版本
+type Upcaster func(payload json.RawMessage) (json.RawMessage, error)
+
+// typeHandler 某一类型的处理函数
+type typeHandler struct {
+	version   int
+	handle    func(ctx context.Context, env *Envelope) error
+	upcasters map[int]Upcaster
+}
+
+// Mux 按信封中的类型将消息分发到对应的处理函数，旧版本的消息逐级升级到当前版本后处理
+type Mux struct {
+	handlers map[string]*typeHandler
+}
+
+// NewMux 实例化Mux
+func NewMux() *Mux {
+	return &Mux{handlers: make(map[string]*typeHandler)}
+}
+
+// Handle 注册类型 T 的处理函数，同一类型重复注册时覆盖
+func Handle[T Contract](m *Mux, handler func(ctx context.Context, payload T, env *Envelope) error) *Mux {
+	var zero T
+	th := m.handler(zero.MessageType())
+	th.version = zero.MessageVersion()
+	th.handle = func(ctx context.Context, env *Envelope) error {
+		var payload T
+		if err := json.Unmarshal(env.Payload, &payload); err != nil {
+			return Reject(fmt.Errorf("decode %s v%d: %w", env.Type, env.Version, err))
+		}
+		return handler(ctx, payload, env)
+	}
+	return m
+}
+
+// Upcast 注册 msgType 从 from 版本升级到 from+1 版本的 upcaster
+func (m *Mux) Upcast(msgType string, from int, upcaster Upcaster) *Mux {
+	m.handler(msgType).upcasters[from] = upcaster
+	return m
+}
+
+func (m *Mux) handler(msgType string) *typeHandler {
+	th, ok := m.handlers[msgType]
+	if !ok {
+		th = &typeHandler{upcasters: make(map[int]Upcaster)}
+		m.handlers[msgType] = th
+	}
+	return th
+}
+
+// Subscribe 订阅 topic 并阻塞处理消息，直到 ctx 取消
+//
+// 同一 topic 的订阅者竞争消费，Mux 会拒绝未注册的类型并写入死信，因此一个 topic 上的全部订阅者
+// 必须处理相同的类型集合。同一进程内以不同类型集合订阅同一 Subscriber 的 topic 时返回 ErrTopicTypeConflict；
+// 跨进程无法检查，部署时需保证各消费者注册的类型一致。
+func (m *Mux) Subscribe(ctx context.Context, s Subscriber, topic string) error {
+	if err := m.register(s, topic); err != nil {
+		return err
+	}
+	defer m.unregister(s, topic)
+	return s.Subscribe(ctx, topic, m.Dispatch)
+}
+
+// types 已注册处理函数的类型，按名称排序
+func (m *Mux) types() string {
+	types := make([]string, 0, len(m.handlers))
+	for msgType, th := range m.handlers {
+		if th.handle != nil {
+			types = append(types, msgType)
+		}
+	}
+	sort.Strings(types)
+	return strings.Join(types, ",")
+}
+
+func (m *Mux) register(s Subscriber, topic string) error {
+	types := m.types()
+	subscriptionsMu.Lock()
+	defer subscriptionsMu.Unlock()
+	topics, ok := subscriptions[s]
+	if !ok {
+		topics = make(map[string]*topicSubscription)
+		subscriptions[s] = topics
+	}
+	sub, ok := topics[topic]
+	if !ok {
+		topics[topic] = &topicSubscription{types: types, count: 1}
+		return nil
+	}
+	if sub.types != types {
+		return fmt.Errorf("%w: %s subscribed with [%s], got [%s]", ErrTopicTypeConflict, topic, sub.types, types)
+	}
+	sub.count++
+	return nil
+}
+
+func (m *Mux) unregister(s Subscriber, topic string) {
+	subscriptionsMu.Lock()
+	defer subscriptionsMu.Unlock()
+	sub, ok := subscriptions[s][topic]
+	if !ok {
+		return
+	}
+	if sub.count--; sub.count == 0 {
+		delete(subscriptions[s], topic)
+		if len(subscriptions[s]) == 0 {
+			delete(subscriptions, s)
+		}
+	}
+}
+
+// Dispatch 实现 MsgHandler
+//
+// 无法解码、未知类型及缺少 upcaster 的消息不再投递；版本高于当前版本的消息返回普通错误，
+// 滚动发布期间可由已升级的消费者处理。
+func (m *Mux) Dispatch(ctx context.Context, msg *Msg) error {
+	env := &Envelope{}
+	if err := json.Unmarshal(msg.Body, env); err != nil {
+		return Reject(fmt.Errorf("decode envelope: %w", err))
+	}
+	th, ok := m.handlers[env.Type]
+	if !ok || th.handle == nil {
+		return Reject(fmt.Errorf("%w: %s", ErrUnknownType, env.Type))
+	}
+	if env.Version > th.version {
+		return fmt.Errorf("%s v%d is newer than supported v%d", env.Type, env.Version, th.version)
+	}
+	for env.Version < th.version {
+		upcaster, ok := th.upcasters[env.Version]
+		if !ok {
+			return Reject(fmt.Errorf("%w: %s v%d", ErrNoUpcaster, env.Type, env.Version))
+		}
+		payload, err := upcaster(env.Payload)
+		if err != nil {
+			return Reject(fmt.Errorf("upcast %s v%d: %w", env.Type, env.Version, err))
+		}
+		env.Payload = payload
+		env.Version++
+	}
+
+	if env.RequestID != "" {
+		ctx = utils.SetRequestID(ctx, env.RequestID)
+	}
+	if trace := env.Trace[sentry.SentryTraceHeader]; trace != "" {
+		tx := sentry.StartTransaction(ctx, "queue.process "+env.Type,
+			sentry.WithOpName("queue.process"),
+			sentry.WithTransactionSource(sentry.SourceTask),
+			sentry.ContinueFromHeaders(trace, env.Trace[sentry.SentryBaggageHeader]),
+		)
+		defer tx.Finish()
+		ctx = tx.Context()
+	}
+	return th.handle(ctx, env)
+}
@@ -0,0 +1,101 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go-agent/gopkg/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// orderCreated v2 将 v1 的 amount(元) 改为 amount_cents(分)
+type orderCreated struct {
+	OrderID     int64 `json:"order_id"`
+	AmountCents int64 `json:"amount_cents"`
+}
+
+func (orderCreated) MessageType() string { return "order.created" }
+func (orderCreated) MessageVersion() int { return 2 }
+
+func TestEnvelopeDispatch(t *testing.T) {
+	bus := NewMemoryBus(1)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	got := make(chan orderCreated, 2)
+	mux := NewMux().Upcast("order.created", 1, func(payload json.RawMessage) (json.RawMessage, error) {
+		var v1 struct {
+			OrderID int64 `json:"order_id"`
+			Amount  int64 `json:"amount"`
+		}
+		if err := json.Unmarshal(payload, &v1); err != nil {
+			return nil, err
+		}
+		return json.Marshal(orderCreated{OrderID: v1.OrderID, AmountCents: v1.Amount * 100})
+	})
+	Handle(mux, func(ctx context.Context, payload orderCreated, env *Envelope) error {
+		assert.Equal(t, "req-1", utils.GetRequestID(ctx))
+		got <- payload
+		return nil
+	})
+	go func() { _ = bus.Subscribe(ctx, "orders", mux.Dispatch) }()
+
+	assert.NoError(t, Publish(utils.SetRequestID(ctx, "req-1"), bus, "orders", orderCreated{OrderID: 1, AmountCents: 250}))
+	v1, _ := json.Marshal(Envelope{ID: "old", Type: "order.created", Version: 1, RequestID: "req-1", Payload: json.RawMessage(`{"order_id":2,"amount":3}`)})
+	assert.NoError(t, bus.Publish(ctx, "orders", &Msg{Body: v1}))
+	unknown, _ := json.Marshal(Envelope{Type: "order.deleted", Version: 1})
+	assert.NoError(t, bus.Publish(ctx, "orders", &Msg{Body: unknown}))
+
+	assert.Equal(t, orderCreated{OrderID: 1, AmountCents: 250}, <-got)
+	assert.Equal(t, orderCreated{OrderID: 2, AmountCents: 300}, <-got)
+	assert.Eventually(t, func() bool { return len(bus.DeadLetters("orders")) == 1 }, time.Second, 10*time.Millisecond)
+}
+
+type orderPaid struct {
+	OrderID int64 `json:"order_id"`
+}
+
+func (orderPaid) MessageType() string { return "order.paid" }
+func (orderPaid) MessageVersion() int { return 1 }
+
+func TestSubscribe_TopicTypeConflict(t *testing.T) {
+	bus := NewMemoryBus(1)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	subscribed := func() bool {
+		subscriptionsMu.Lock()
+		defer subscriptionsMu.Unlock()
+		return subscriptions[bus]["orders"] != nil
+	}
+	go func() {
+		_ = Subscribe(ctx, bus, "orders", func(ctx context.Context, payload orderCreated, env *Envelope) error {
+			return nil
+		})
+	}()
+	assert.Eventually(t, subscribed, time.Second, 10*time.Millisecond)
+
+	// 竞争消费时会拒绝对方的类型，同一 topic 不能以不同类型订阅
+	err := Subscribe(ctx, bus, "orders", func(ctx context.Context, payload orderPaid, env *Envelope) error {
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrTopicTypeConflict)
+
+	// 各类型注册到同一个 Mux 后订阅
+	got := make(chan int64, 1)
+	mux := NewMux()
+	Handle(mux, func(ctx context.Context, payload orderCreated, env *Envelope) error { return nil })
+	Handle(mux, func(ctx context.Context, payload orderPaid, env *Envelope) error {
+		got <- payload.OrderID
+		return nil
+	})
+	go func() { _ = mux.Subscribe(ctx, bus, "payments") }()
+	assert.NoError(t, Publish(ctx, bus, "payments", orderPaid{OrderID: 7}))
+	assert.Equal(t, int64(7), <-got)
+
+	cancel()
+	assert.Eventually(t, func() bool { return !subscribed() }, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,52 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// processingTTL 处理中标记的过期时间，消费者崩溃后该时间内重新投递的消息会稍后重试
+const processingTTL = 5 * time.Minute
+
+// 去重标记的值
+const (
+	idempotentProcessing = "processing"
+	idempotentDone       = "done"
+)
+
+// Idempotent 按消息ID去重，配合至少一次投递（如 outbox）实现效果上的恰好一次
+//
+// 处理成功的消息在 ttl 内再次投递时直接确认；其他消费者处理中的消息返回错误稍后重试；
+// 处理失败时清除标记，允许重新投递后再次处理。
+func Idempotent(client redis.UniversalClient, prefix string, ttl time.Duration, handler MsgHandler) MsgHandler {
+	return func(ctx context.Context, msg *Msg) error {
+		if msg.ID == "" {
+			return handler(ctx, msg)
+		}
+
+		key := prefix + ":processed:" + msg.ID
+		ok, err := client.SetNX(ctx, key, idempotentProcessing, processingTTL).Result()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			state, err := client.Get(ctx, key).Result()
+			if err != nil && err != redis.Nil {
+				return err
+			}
+			if state == idempotentDone {
+				return nil
+			}
+			return fmt.Errorf("message %s is being processed", msg.ID)
+		}
+
+		if err := recoverReject(func() error { return handler(ctx, msg) }); err != nil {
+			client.Del(context.Background(), key)
+			return err
+		}
+		return client.Set(context.Background(), key, idempotentDone, ttl).Err()
+	}
+}
@@ -0,0 +1,56 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotent(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	ctx := context.Background()
+
+	var calls int
+	fail := true
+	handler := Idempotent(client, "test", time.Hour, func(ctx context.Context, msg *Msg) error {
+		calls++
+		if fail {
+			return errors.New("temporary")
+		}
+		return nil
+	})
+
+	// 处理失败时清除标记，重新投递后再次处理
+	assert.Error(t, handler(ctx, &Msg{ID: "m1"}))
+	assert.False(t, mr.Exists("test:processed:m1"))
+
+	fail = false
+	assert.NoError(t, handler(ctx, &Msg{ID: "m1"}))
+	assert.Equal(t, 2, calls)
+	v, err := mr.Get("test:processed:m1")
+	assert.NoError(t, err)
+	assert.Equal(t, idempotentDone, v)
+	assert.Equal(t, time.Hour, mr.TTL("test:processed:m1"))
+
+	// 已处理的消息直接确认
+	assert.NoError(t, handler(ctx, &Msg{ID: "m1"}))
+	assert.Equal(t, 2, calls)
+
+	// 其他消费者处理中的消息返回错误稍后重试
+	assert.NoError(t, mr.Set("test:processed:m2", idempotentProcessing))
+	assert.Error(t, handler(ctx, &Msg{ID: "m2"}))
+	assert.Equal(t, 2, calls)
+
+	// 处理中标记过期后可再次处理
+	assert.NoError(t, mr.Set("test:processed:m3", idempotentProcessing))
+	mr.SetTTL("test:processed:m3", time.Second)
+	mr.FastForward(2 * time.Second)
+	assert.NoError(t, handler(ctx, &Msg{ID: "m3"}))
+	assert.Equal(t, 3, calls)
+}
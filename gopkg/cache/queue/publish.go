@@ -0,0 +1,181 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go-agent/gopkg/utils"
+
+	"github.com/streadway/amqp"
+)
+
+// confirmBuffer publisher confirms 通知通道的缓冲大小
+const confirmBuffer = 128
+
+var (
+	// ErrNacked broker 拒绝了消息（nack）
+	ErrNacked = errors.New("rabbitmq message nacked")
+	// ErrConfirmLost 等待确认期间通道关闭，消息是否送达未知，调用方可重试
+	ErrConfirmLost = errors.New("rabbitmq confirm lost")
+)
+
+// Message 待发送的消息
+type Message struct {
+	Exchange      string     // 交换机，为空时使用默认交换机
+	RoutingKey    string     // 路由键，为空时使用配置的队列名
+	Body          []byte     // 消息内容
+	ContentType   string     // 默认 application/json
+	MessageID     string     // 为空时自动生成
+	CorrelationID string     // 为空时使用 ctx 中的请求ID
+	Headers       amqp.Table // 与配置中的默认 headers 合并，同名时以消息为准
+	Transient     bool       // 为 true 时不持久化，broker 重启后丢失
+	Expiration    time.Duration
+}
+
+// Publish 发送消息，开启 publisher confirms 时等待 broker 确认，ctx 取消时返回 ctx.Err()
+//
+// 连接断开时返回 ErrNotConnected，等待确认期间连接断开返回 ErrConfirmLost，均可重试。
+func (r *RabbitMQ) Publish(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	ch, confirms, err := r.publisher()
+	if err != nil {
+		return err
+	}
+
+	routingKey := msg.RoutingKey
+	if routingKey == "" {
+		routingKey = r.cfg.QueueName
+	}
+	publishing := r.publishing(ctx, msg)
+	publish := func() error {
+		return ch.Publish(
+			msg.Exchange, // 交换机名称
+			routingKey,   // 路由键
+			false,        // 如果无法路由到队列则返回消息
+			false,        // 如果交换到队列的路由无匹配则丢弃消息
+			publishing,
+		)
+	}
+
+	if confirms == nil {
+		if err := publish(); err != nil {
+			return fmt.Errorf("publish to %s: %w", routingKey, err)
+		}
+		return nil
+	}
+
+	wait, err := confirms.publish(publish)
+	if err != nil {
+		return fmt.Errorf("publish to %s: %w", routingKey, err)
+	}
+	select {
+	case err := <-wait:
+		if err != nil {
+			return fmt.Errorf("publish to %s (message %s): %w", routingKey, publishing.MessageId, err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// publishing 构造 amqp.Publishing，补全默认值及配置中的 headers
+func (r *RabbitMQ) publishing(ctx context.Context, msg Message) amqp.Publishing {
+	headers := make(amqp.Table, len(r.cfg.Headers)+len(msg.Headers))
+	for k, v := range r.cfg.Headers {
+		headers[k] = v
+	}
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+
+	publishing := amqp.Publishing{
+		Headers:       headers,
+		ContentType:   msg.ContentType,
+		DeliveryMode:  amqp.Persistent,
+		CorrelationId: msg.CorrelationID,
+		MessageId:     msg.MessageID,
+		Timestamp:     time.Now(),
+		AppId:         r.cfg.AppID,
+		Body:          msg.Body,
+	}
+	if publishing.ContentType == "" {
+		publishing.ContentType = "application/json"
+	}
+	if msg.Transient {
+		publishing.DeliveryMode = amqp.Transient
+	}
+	if publishing.MessageId == "" {
+		publishing.MessageId = utils.GenUUIDWithoutUnderline()
+	}
+	if publishing.CorrelationId == "" {
+		publishing.CorrelationId = utils.GetRequestID(ctx)
+	}
+	if msg.Expiration > 0 {
+		publishing.Expiration = fmt.Sprint(msg.Expiration.Milliseconds())
+	}
+	return publishing
+}
+
+// confirmer 按 delivery tag 分发 publisher confirms，每个通道一个
+type confirmer struct {
+	mu      sync.Mutex
+	next    uint64
+	pending map[uint64]chan error
+	closed  bool
+}
+
+// newConfirmer 实例化confirmer并开始监听确认，通道关闭后未确认的消息返回 ErrConfirmLost
+func newConfirmer(confirms <-chan amqp.Confirmation) *confirmer {
+	c := &confirmer{pending: make(map[uint64]chan error)}
+	go c.listen(confirms)
+	return c
+}
+
+// publish 发送消息并登记 delivery tag，返回等待确认结果的通道
+//
+// delivery tag 按发送顺序从 1 递增，发送与登记需在同一把锁内完成。
+func (c *confirmer) publish(publish func() error) (<-chan error, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, ErrNotConnected
+	}
+	if err := publish(); err != nil {
+		return nil, err
+	}
+	c.next++
+	wait := make(chan error, 1)
+	c.pending[c.next] = wait
+	return wait, nil
+}
+
+func (c *confirmer) listen(confirms <-chan amqp.Confirmation) {
+	for confirm := range confirms {
+		c.mu.Lock()
+		wait, ok := c.pending[confirm.DeliveryTag]
+		delete(c.pending, confirm.DeliveryTag)
+		c.mu.Unlock()
+		if !ok {
+			continue
+		}
+		if confirm.Ack {
+			wait <- nil
+		} else {
+			wait <- ErrNacked
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	for tag, wait := range c.pending {
+		wait <- ErrConfirmLost
+		delete(c.pending, tag)
+	}
+}
@@ -0,0 +1,57 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go-agent/gopkg/utils"
+
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishing(t *testing.T) {
+	r := &RabbitMQ{cfg: Config{AppID: "go-agent", Headers: amqp.Table{"source": "config", "env": "test"}}}
+	ctx := utils.SetRequestID(context.Background(), "req-1")
+
+	p := r.publishing(ctx, Message{Body: []byte("{}"), Headers: amqp.Table{"source": "message"}})
+	assert.Equal(t, amqp.Persistent, p.DeliveryMode)
+	assert.Equal(t, "application/json", p.ContentType)
+	assert.Equal(t, "req-1", p.CorrelationId)
+	assert.NotEmpty(t, p.MessageId)
+	assert.Equal(t, amqp.Table{"source": "message", "env": "test"}, p.Headers)
+
+	p = r.publishing(ctx, Message{Transient: true, CorrelationID: "c-1"})
+	assert.Equal(t, amqp.Transient, p.DeliveryMode)
+	assert.Equal(t, "c-1", p.CorrelationId)
+}
+
+func TestConfirmer(t *testing.T) {
+	confirms := make(chan amqp.Confirmation)
+	c := newConfirmer(confirms)
+	publish := func() error { return nil }
+
+	first, _ := c.publish(publish)
+	second, _ := c.publish(publish)
+	third, _ := c.publish(publish)
+	confirms <- amqp.Confirmation{DeliveryTag: 2, Ack: false}
+	confirms <- amqp.Confirmation{DeliveryTag: 1, Ack: true}
+	assert.NoError(t, <-first)
+	assert.ErrorIs(t, <-second, ErrNacked)
+
+	close(confirms)
+	assert.ErrorIs(t, <-third, ErrConfirmLost)
+	_, err := c.publish(publish)
+	assert.ErrorIs(t, err, ErrNotConnected)
+}
+
+func TestReject(t *testing.T) {
+	boom := errors.New("boom")
+	assert.True(t, IsReject(Reject(boom)))
+	assert.ErrorIs(t, Reject(boom), boom)
+	assert.False(t, IsReject(boom))
+
+	err := recoverReject(func() error { panic("bad message") })
+	assert.True(t, IsReject(err))
+}
@@ -0,0 +1,333 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go-agent/gopkg/log"
+
+	"github.com/streadway/amqp"
+	"go.uber.org/zap"
+)
+
+// 重连相关默认值
+const (
+	defaultDialTimeout       = 10 * time.Second
+	defaultHeartbeat         = 10 * time.Second
+	defaultReconnectDelay    = time.Second
+	defaultMaxReconnectDelay = 30 * time.Second
+)
+
+var (
+	// ErrClosed 客户端已关闭
+	ErrClosed = errors.New("rabbitmq client closed")
+	// ErrNotConnected 连接断开，正在重连
+	ErrNotConnected = errors.New("rabbitmq not connected")
+)
+
+// State 连接状态
+type State int32
+
+const (
+	StateConnecting   State = iota // 首次连接中
+	StateConnected                 // 已连接
+	StateReconnecting              // 连接断开，正在重连
+	StateClosed                    // 已关闭
+)
+
+func (s State) String() string {
+	switch s {
+	case StateConnecting:
+		return "connecting"
+	case StateConnected:
+		return "connected"
+	case StateReconnecting:
+		return "reconnecting"
+	case StateClosed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+// Topology 声明交换机、队列等拓扑，每次（重新）连接成功后都会执行，需保证幂等
+type Topology func(ch *amqp.Channel) error
+
+// RabbitMQ 自动重连的 RabbitMQ 客户端
+//
+// 连接或通道关闭后按指数退避重连，重连成功后重新声明拓扑，
+// 通过 Consume 订阅的消费者会自动重新订阅。
+type RabbitMQ struct {
+	cfg      Config
+	url      string
+	logger   *zap.SugaredLogger
+	mu       sync.RWMutex
+	conn     *amqp.Connection
+	channel  *amqp.Channel
+	confirms *confirmer    // 开启 publisher confirms 时不为空
+	ready    chan struct{} // 连接可用时关闭，断开后重建
+	topology []Topology
+	state    atomic.Int32
+	done     chan struct{}
+	once     sync.Once
+}
+
+// NewClient 实例化RabbitMQ并建立连接，首次连接失败直接返回错误
+func NewClient(cfg Config) (*RabbitMQ, error) {
+	if cfg.DialTimeout <= 0 {
+		cfg.DialTimeout = defaultDialTimeout
+	}
+	if cfg.Heartbeat <= 0 {
+		cfg.Heartbeat = defaultHeartbeat
+	}
+	if cfg.ReconnectDelay <= 0 {
+		cfg.ReconnectDelay = defaultReconnectDelay
+	}
+	if cfg.MaxReconnectDelay < cfg.ReconnectDelay {
+		cfg.MaxReconnectDelay = defaultMaxReconnectDelay
+	}
+
+	r := &RabbitMQ{
+		cfg:    cfg,
+		url:    fmt.Sprintf("amqp://%s:%s@%s", cfg.Username, cfg.Password, cfg.Addresses),
+		logger: log.Sugar(),
+		ready:  make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	if err := validateTopology(cfg); err != nil {
+		return nil, err
+	}
+	if len(cfg.Exchanges) > 0 || len(cfg.Queues) > 0 {
+		r.topology = append(r.topology, declareTopology(cfg))
+	}
+	// queue-name 未在 queues 中配置时按队列选项单独声明
+	if _, ok := cfg.Queues[cfg.QueueName]; cfg.QueueName != "" && !ok {
+		r.topology = append(r.topology, func(ch *amqp.Channel) error {
+			_, err := ch.QueueDeclare(
+				cfg.QueueName,  // 队列名称
+				cfg.Durable,    // 队列是否持久化
+				cfg.AutoDelete, // 未使用队列是否自动删除
+				false,          // 是否为排他队列
+				false,          // 队列是否阻塞
+				nil,            // 额外的属性
+			)
+			return err
+		})
+	}
+
+	r.state.Store(int32(StateConnecting))
+	connClose, chClose, err := r.connect()
+	if err != nil {
+		return nil, err
+	}
+	go r.watch(connClose, chClose)
+	return r, nil
+}
+
+// connect 建立连接及通道并声明拓扑
+func (r *RabbitMQ) connect() (chan *amqp.Error, chan *amqp.Error, error) {
+	conn, err := amqp.DialConfig(r.url, amqp.Config{
+		Heartbeat: r.cfg.Heartbeat,
+		Locale:    "en_US",
+		Dial:      amqp.DefaultDial(r.cfg.DialTimeout),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		_ = conn.Close()
+		return nil, nil, err
+	}
+
+	// 预取数量限制未确认的消息数，避免消费者一次拿走过多消息
+	if r.cfg.Prefetch > 0 {
+		if err := ch.Qos(r.cfg.Prefetch, 0, false); err != nil {
+			_ = conn.Close()
+			return nil, nil, err
+		}
+	}
+	var confirms *confirmer
+	if r.cfg.Confirm {
+		if err := ch.Confirm(false); err != nil {
+			_ = conn.Close()
+			return nil, nil, err
+		}
+		confirms = newConfirmer(ch.NotifyPublish(make(chan amqp.Confirmation, confirmBuffer)))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.State() == StateClosed {
+		_ = conn.Close()
+		return nil, nil, ErrClosed
+	}
+	for _, declare := range r.topology {
+		if err := declare(ch); err != nil {
+			_ = conn.Close()
+			return nil, nil, err
+		}
+	}
+	connClose := conn.NotifyClose(make(chan *amqp.Error, 1))
+	chClose := ch.NotifyClose(make(chan *amqp.Error, 1))
+
+	r.conn = conn
+	r.channel = ch
+	r.confirms = confirms
+	close(r.ready)
+	r.state.Store(int32(StateConnected))
+	return connClose, chClose, nil
+}
+
+// watch 监听连接及通道关闭并重连，直到客户端关闭
+func (r *RabbitMQ) watch(connClose, chClose chan *amqp.Error) {
+	for {
+		var reason *amqp.Error
+		select {
+		case <-r.done:
+			return
+		case reason = <-connClose:
+		case reason = <-chClose:
+		}
+
+		select {
+		case <-r.done:
+			return
+		default:
+		}
+		r.logger.Warnf("rabbitmq 连接断开，准备重连: %s, 原因: %v", r.cfg.Addresses, reason)
+		r.disconnect()
+
+		var ok bool
+		if connClose, chClose, ok = r.reconnect(); !ok {
+			return
+		}
+		r.logger.Infof("rabbitmq 重连成功: %s", r.cfg.Addresses)
+	}
+}
+
+// disconnect 关闭旧连接并标记为重连中
+func (r *RabbitMQ) disconnect() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.state.Store(int32(StateReconnecting))
+	r.ready = make(chan struct{})
+	if r.conn != nil {
+		_ = r.conn.Close()
+	}
+	r.conn, r.channel, r.confirms = nil, nil, nil
+}
+
+// reconnect 按指数退避重连，客户端关闭时返回 false
+func (r *RabbitMQ) reconnect() (chan *amqp.Error, chan *amqp.Error, bool) {
+	delay := r.cfg.ReconnectDelay
+	for {
+		select {
+		case <-r.done:
+			return nil, nil, false
+		case <-time.After(delay):
+		}
+
+		connClose, chClose, err := r.connect()
+		if err == nil {
+			return connClose, chClose, true
+		}
+		r.logger.Errorf("rabbitmq 重连失败: %s, %v 后重试, 错误: %v", r.cfg.Addresses, delay, err)
+		delay = nextDelay(delay, r.cfg.MaxReconnectDelay)
+	}
+}
+
+// nextDelay 指数退避，不超过 max
+func nextDelay(delay, max time.Duration) time.Duration {
+	if delay *= 2; delay > max {
+		return max
+	}
+	return delay
+}
+
+// State 当前连接状态
+func (r *RabbitMQ) State() State {
+	return State(r.state.Load())
+}
+
+// Ping 用于健康检查，未连接时返回错误
+func (r *RabbitMQ) Ping() error {
+	switch r.State() {
+	case StateConnected:
+		return nil
+	case StateClosed:
+		return ErrClosed
+	default:
+		return ErrNotConnected
+	}
+}
+
+// Declare 声明拓扑，立即在当前连接上执行并在每次重连后重新执行
+func (r *RabbitMQ) Declare(declare Topology) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.State() == StateClosed {
+		return ErrClosed
+	}
+	if r.channel != nil {
+		if err := declare(r.channel); err != nil {
+			return err
+		}
+	}
+	r.topology = append(r.topology, declare)
+	return nil
+}
+
+// current 当前可用的通道，连接断开时返回 ErrNotConnected
+func (r *RabbitMQ) current() (*amqp.Channel, error) {
+	ch, _, err := r.publisher()
+	return ch, err
+}
+
+// publisher 当前可用的通道及对应的 confirmer
+func (r *RabbitMQ) publisher() (*amqp.Channel, *confirmer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.channel == nil {
+		if r.State() == StateClosed {
+			return nil, nil, ErrClosed
+		}
+		return nil, nil, ErrNotConnected
+	}
+	return r.channel, r.confirms, nil
+}
+
+// wait 等待连接可用
+func (r *RabbitMQ) wait(ctx context.Context) error {
+	r.mu.RLock()
+	ready := r.ready
+	r.mu.RUnlock()
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-r.done:
+		return ErrClosed
+	}
+}
+
+// Close 关闭客户端，不再重连
+func (r *RabbitMQ) Close() error {
+	var err error
+	r.once.Do(func() {
+		close(r.done)
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.state.Store(int32(StateClosed))
+		if r.conn != nil {
+			err = r.conn.Close()
+		}
+		r.conn, r.channel, r.confirms = nil, nil, nil
+	})
+	return err
+}
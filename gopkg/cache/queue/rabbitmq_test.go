@@ -0,0 +1,21 @@
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextDelay(t *testing.T) {
+	assert.Equal(t, 2*time.Second, nextDelay(time.Second, 30*time.Second))
+	assert.Equal(t, 30*time.Second, nextDelay(20*time.Second, 30*time.Second))
+}
+
+func TestNewClientDialError(t *testing.T) {
+	_, err := NewClient(Config{Addresses: "127.0.0.1:1", DialTimeout: time.Second})
+	assert.Error(t, err)
+
+	_, err = Client("missing")
+	assert.ErrorIs(t, err, ErrClientNotExists)
+}
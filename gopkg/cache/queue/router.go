@@ -0,0 +1,98 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/streadway/amqp"
+)
+
+// ErrNoRoute 没有与路由键匹配的处理函数
+var ErrNoRoute = errors.New("rabbitmq no handler for routing key")
+
+// route 路由规则
+type route struct {
+	pattern string
+	handler Handler
+}
+
+// Router 按路由键将消息分发到注册的处理函数，按注册顺序匹配第一个
+//
+// pattern 使用 topic 交换机的语法：单词以 . 分隔，* 匹配一个单词，# 匹配零个或多个单词。
+type Router struct {
+	routes []route
+}
+
+// NewRouter 实例化Router
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// Handle 注册处理函数
+func (rt *Router) Handle(pattern string, handler Handler) *Router {
+	rt.routes = append(rt.routes, route{pattern: pattern, handler: handler})
+	return rt
+}
+
+// HandleJSON 注册按 JSON 解码消息体的处理函数，解码失败的消息不重新入队
+func HandleJSON[T any](rt *Router, pattern string, handler func(ctx context.Context, msg T, d amqp.Delivery) error) *Router {
+	return rt.Handle(pattern, func(ctx context.Context, d amqp.Delivery) error {
+		var msg T
+		if err := json.Unmarshal(d.Body, &msg); err != nil {
+			return Reject(fmt.Errorf("decode %s: %w", d.RoutingKey, err))
+		}
+		return handler(ctx, msg, d)
+	})
+}
+
+// Dispatch 实现 Handler，没有匹配的处理函数时返回 Reject 包装的 ErrNoRoute
+func (rt *Router) Dispatch(ctx context.Context, d amqp.Delivery) error {
+	for _, r := range rt.routes {
+		if matchRoutingKey(r.pattern, d.RoutingKey) {
+			return r.handler(ctx, d)
+		}
+	}
+	return Reject(fmt.Errorf("%w: %s", ErrNoRoute, d.RoutingKey))
+}
+
+// matchRoutingKey 按 topic 交换机的规则匹配路由键
+func matchRoutingKey(pattern, key string) bool {
+	return matchWords(strings.Split(pattern, "."), strings.Split(key, "."))
+}
+
+func matchWords(pattern, words []string) bool {
+	if len(pattern) == 0 {
+		return len(words) == 0
+	}
+	switch pattern[0] {
+	case "#":
+		// # 匹配零个或多个单词
+		for i := 0; i <= len(words); i++ {
+			if matchWords(pattern[1:], words[i:]) {
+				return true
+			}
+		}
+		return false
+	case "*":
+		return len(words) > 0 && matchWords(pattern[1:], words[1:])
+	default:
+		return len(words) > 0 && pattern[0] == words[0] && matchWords(pattern[1:], words[1:])
+	}
+}
+
+// Subscribe 订阅指定队列并交给 handler（通常为 Router.Dispatch）处理，阻塞直到 ctx 取消或客户端关闭
+//
+// 确认规则同 ConsumeFunc。
+func (r *RabbitMQ) Subscribe(ctx context.Context, queue string, handler Handler) error {
+	deliveries, err := r.consume(ctx, queue)
+	if err != nil {
+		return err
+	}
+	for d := range deliveries {
+		r.handle(ctx, d, handler)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchRoutingKey(t *testing.T) {
+	assert.True(t, matchRoutingKey("order.created", "order.created"))
+	assert.True(t, matchRoutingKey("order.*", "order.created"))
+	assert.False(t, matchRoutingKey("order.*", "order.created.v2"))
+	assert.True(t, matchRoutingKey("order.#", "order"))
+	assert.True(t, matchRoutingKey("order.#", "order.created.v2"))
+	assert.True(t, matchRoutingKey("#.v2", "order.created.v2"))
+	assert.False(t, matchRoutingKey("order.created", "order.refund"))
+}
+
+func TestRouterDispatch(t *testing.T) {
+	type created struct {
+		ID int64 `json:"id"`
+	}
+	var got int64
+	rt := NewRouter()
+	HandleJSON(rt, "order.created", func(ctx context.Context, msg created, d amqp.Delivery) error {
+		got = msg.ID
+		return nil
+	})
+	rt.Handle("order.#", func(ctx context.Context, d amqp.Delivery) error { return errors.New("retry") })
+
+	assert.NoError(t, rt.Dispatch(context.Background(), amqp.Delivery{RoutingKey: "order.created", Body: []byte(`{"id":7}`)}))
+	assert.Equal(t, int64(7), got)
+	assert.True(t, IsReject(rt.Dispatch(context.Background(), amqp.Delivery{RoutingKey: "order.created", Body: []byte("x")})))
+	assert.False(t, IsReject(rt.Dispatch(context.Background(), amqp.Delivery{RoutingKey: "order.refund"})))
+
+	err := rt.Dispatch(context.Background(), amqp.Delivery{RoutingKey: "user.created"})
+	assert.True(t, IsReject(err))
+	assert.ErrorIs(t, err, ErrNoRoute)
+}
+
+func TestTopologyConfig(t *testing.T) {
+	cfg := Config{
+		Exchanges: map[string]ExchangeConfig{"orders": {Type: "topic"}, "orders.dlx": {Type: "fanout"}},
+		Queues: map[string]QueueConfig{
+			"orders.created": {
+				TTL:                time.Hour,
+				DeadLetterExchange: "orders.dlx",
+				Bindings:           []BindingConfig{{Exchange: "orders", RoutingKey: "order.*"}},
+			},
+		},
+	}
+	assert.NoError(t, validateTopology(cfg))
+	assert.Equal(t, amqp.Table{"x-message-ttl": int64(3600000), "x-dead-letter-exchange": "orders.dlx"}, queueArguments(cfg.Queues["orders.created"]))
+
+	cfg.Exchanges["orders"] = ExchangeConfig{Type: "unknown"}
+	assert.Error(t, validateTopology(cfg))
+	delete(cfg.Exchanges, "orders")
+	assert.Error(t, validateTopology(cfg))
+}
@@ -0,0 +1,156 @@
+package queue
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/streadway/amqp"
+)
+
+// ExchangeConfig 交换机配置，对应 rabbitmq.<name>.exchanges.<exchange>
+type ExchangeConfig struct {
+	Type       string     `json:"type" mapstructure:"type"` // direct, topic, fanout, headers，默认 direct
+	Durable    bool       `json:"durable" mapstructure:"durable"`
+	AutoDelete bool       `json:"auto-delete" mapstructure:"auto-delete"`
+	Internal   bool       `json:"internal" mapstructure:"internal"` // 内部交换机只能由其他交换机路由消息
+	Arguments  amqp.Table `json:"arguments" mapstructure:"arguments"`
+}
+
+// BindingConfig 队列绑定
+type BindingConfig struct {
+	Exchange   string     `json:"exchange" mapstructure:"exchange"`
+	RoutingKey string     `json:"routing-key" mapstructure:"routing-key"` // topic 交换机支持 * 和 # 通配符
+	Arguments  amqp.Table `json:"arguments" mapstructure:"arguments"`
+}
+
+// QueueConfig 队列配置，对应 rabbitmq.<name>.queues.<queue>
+type QueueConfig struct {
+	Durable              bool            `json:"durable" mapstructure:"durable"`
+	AutoDelete           bool            `json:"auto-delete" mapstructure:"auto-delete"`
+	Exclusive            bool            `json:"exclusive" mapstructure:"exclusive"`
+	TTL                  time.Duration   `json:"ttl" mapstructure:"ttl"`                                         // 消息过期时间(x-message-ttl)
+	Expires              time.Duration   `json:"expires" mapstructure:"expires"`                                 // 队列闲置多久后删除(x-expires)
+	MaxLength            int             `json:"max-length" mapstructure:"max-length"`                           // 队列最大消息数(x-max-length)
+	DeadLetterExchange   string          `json:"dead-letter-exchange" mapstructure:"dead-letter-exchange"`       // 被拒绝、过期或超出长度的消息转发到的交换机
+	DeadLetterRoutingKey string          `json:"dead-letter-routing-key" mapstructure:"dead-letter-routing-key"` // 为空时沿用原路由键
+	Arguments            amqp.Table      `json:"arguments" mapstructure:"arguments"`
+	Bindings             []BindingConfig `json:"bindings" mapstructure:"bindings"`
+}
+
+// exchangeTypes 支持的交换机类型
+var exchangeTypes = map[string]bool{
+	amqp.ExchangeDirect:  true,
+	amqp.ExchangeTopic:   true,
+	amqp.ExchangeFanout:  true,
+	amqp.ExchangeHeaders: true,
+}
+
+// validateTopology 校验拓扑配置，绑定及死信交换机必须引用已配置或内置(amq.*)的交换机
+func validateTopology(cfg Config) error {
+	exists := func(name string) bool {
+		_, ok := cfg.Exchanges[name]
+		return ok || name == "" || strings.HasPrefix(name, "amq.")
+	}
+
+	for name, exchange := range cfg.Exchanges {
+		if exchange.Type != "" && !exchangeTypes[exchange.Type] {
+			return fmt.Errorf("rabbitmq exchange %s: unsupported type %q", name, exchange.Type)
+		}
+	}
+	for name, queue := range cfg.Queues {
+		if !exists(queue.DeadLetterExchange) {
+			return fmt.Errorf("rabbitmq queue %s: dead-letter-exchange %s not declared", name, queue.DeadLetterExchange)
+		}
+		for _, binding := range queue.Bindings {
+			if binding.Exchange == "" || !exists(binding.Exchange) {
+				return fmt.Errorf("rabbitmq queue %s: binding exchange %q not declared", name, binding.Exchange)
+			}
+		}
+	}
+	return nil
+}
+
+// queueArguments 合并队列参数，TTL 及死信配置转换为 x-* 参数
+func queueArguments(queue QueueConfig) amqp.Table {
+	args := amqp.Table{}
+	for k, v := range queue.Arguments {
+		args[k] = v
+	}
+	if queue.TTL > 0 {
+		args["x-message-ttl"] = queue.TTL.Milliseconds()
+	}
+	if queue.Expires > 0 {
+		args["x-expires"] = queue.Expires.Milliseconds()
+	}
+	if queue.MaxLength > 0 {
+		args["x-max-length"] = int64(queue.MaxLength)
+	}
+	if queue.DeadLetterExchange != "" {
+		args["x-dead-letter-exchange"] = queue.DeadLetterExchange
+	}
+	if queue.DeadLetterRoutingKey != "" {
+		args["x-dead-letter-routing-key"] = queue.DeadLetterRoutingKey
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	return args
+}
+
+// declareTopology 按配置声明交换机、队列及绑定
+//
+// 声明操作本身是幂等的，参数与 broker 上已有的不一致时 broker 会关闭通道并返回错误。
+// 交换机及队列按名称排序声明，保证每次声明顺序一致。
+func declareTopology(cfg Config) Topology {
+	return func(ch *amqp.Channel) error {
+		for _, name := range sortedKeys(cfg.Exchanges) {
+			exchange := cfg.Exchanges[name]
+			kind := exchange.Type
+			if kind == "" {
+				kind = amqp.ExchangeDirect
+			}
+			if err := ch.ExchangeDeclare(
+				name,                // 交换机名称
+				kind,                // 交换机类型
+				exchange.Durable,    // 是否持久化
+				exchange.AutoDelete, // 没有绑定时是否自动删除
+				exchange.Internal,   // 是否为内部交换机
+				false,               // 是否等待服务器确认
+				exchange.Arguments,  // 额外的属性
+			); err != nil {
+				return fmt.Errorf("declare exchange %s: %w", name, err)
+			}
+		}
+
+		for _, name := range sortedKeys(cfg.Queues) {
+			queue := cfg.Queues[name]
+			if _, err := ch.QueueDeclare(
+				name,                  // 队列名称
+				queue.Durable,         // 队列是否持久化
+				queue.AutoDelete,      // 未使用队列是否自动删除
+				queue.Exclusive,       // 是否为排他队列
+				false,                 // 队列是否阻塞
+				queueArguments(queue), // 额外的属性
+			); err != nil {
+				return fmt.Errorf("declare queue %s: %w", name, err)
+			}
+			for _, binding := range queue.Bindings {
+				if err := ch.QueueBind(name, binding.RoutingKey, binding.Exchange, false, binding.Arguments); err != nil {
+					return fmt.Errorf("bind queue %s to %s(%s): %w", name, binding.Exchange, binding.RoutingKey, err)
+				}
+			}
+		}
+		return nil
+	}
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -3,73 +3,119 @@ package redis
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/go-redis/redis/v8"
 )
 
-// NewClientDefault 实例化客户端
-func NewClientDefault(config Config) (*redis.Client, error) {
-
-	// redis客户端实例
-	var rdbDefault *redis.Client
-
-	// 判断客户端类型
-	switch config.Type {
-	// 单机
-	default:
-		rdbDefault = redis.NewClient(&redis.Options{
-			Addr:     config.Addr[0],
-			Password: config.Password,
-			DB:       config.DB,
-		})
-	}
-
-	// 测试redis客户端是否可用
-	if err := rdbDefault.Ping(context.Background()).Err(); err != nil {
-		return rdbDefault, err
+// typ 客户端类型，未配置时为单机
+func (c Config) typ() string {
+	if c.Type == "" {
+		return TypeSingleNode
 	}
-
-	return rdbDefault, nil
+	return c.Type
 }
 
-// ClientDefault
-// 获取指定的Redis客户端实例
-func ClientDefault(name string) *redis.Client {
-	return clientDefault[name]
-}
-
-// ClientAndErrDefault
-// 获取指定的Redis客户端实例不存在返回error
-func ClientAndErrDefault(name string) (*redis.Client, error) {
-	if client, ok := clientDefault[name]; ok {
-		return client, nil
+// validate 校验配置
+func (c Config) validate() error {
+	if len(c.Addr) == 0 {
+		return errors.New("redis addr is empty")
 	}
-	return nil, errors.New("redis client not exists")
+	switch c.typ() {
+	case TypeSingleNode:
+	case TypeCluster:
+		if c.DB != 0 {
+			return errors.New("redis cluster does not support db other than 0")
+		}
+	case TypeFailover:
+		if c.MasterName == "" {
+			return errors.New("redis failover requires master_name")
+		}
+	default:
+		return fmt.Errorf("unknown redis type: %s", c.Type)
+	}
+	return nil
 }
 
-// NewClient 实例化客户端
-func NewClient(config Config) (redis.UniversalClient, error) {
-
-	// redis客户端实例
-	var rdb redis.UniversalClient
-
-	// 判断客户端类型
-	switch config.Type {
+// newClient 按类型创建客户端，不检查连接
+func newClient(config Config) (redis.UniversalClient, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	tlsConfig, err := config.TLS.build()
+	if err != nil {
+		return nil, err
+	}
 
+	switch config.typ() {
 	// 集群
-	case "cluster":
-		rdb = redis.NewClusterClient(&redis.ClusterOptions{
-			Addrs:    config.Addr,
-			Password: config.Password,
-		})
+	case TypeCluster:
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:          config.Addr,
+			Username:       config.Username,
+			Password:       config.Password,
+			RouteByLatency: config.RouteByLatency,
+			RouteRandomly:  config.RouteRandomly,
+			MaxRetries:     config.MaxRetries,
+			DialTimeout:    config.DialTimeout,
+			ReadTimeout:    config.ReadTimeout,
+			WriteTimeout:   config.WriteTimeout,
+			PoolSize:       config.PoolSize,
+			MinIdleConns:   config.MinIdleConns,
+			MaxConnAge:     config.MaxConnAge,
+			PoolTimeout:    config.PoolTimeout,
+			IdleTimeout:    config.IdleTimeout,
+			TLSConfig:      tlsConfig,
+		}), nil
+
+	// 哨兵
+	case TypeFailover:
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       config.MasterName,
+			SentinelAddrs:    config.Addr,
+			SentinelUsername: config.SentinelUsername,
+			SentinelPassword: config.SentinelPassword,
+			Username:         config.Username,
+			Password:         config.Password,
+			DB:               config.DB,
+			MaxRetries:       config.MaxRetries,
+			DialTimeout:      config.DialTimeout,
+			ReadTimeout:      config.ReadTimeout,
+			WriteTimeout:     config.WriteTimeout,
+			PoolSize:         config.PoolSize,
+			MinIdleConns:     config.MinIdleConns,
+			MaxConnAge:       config.MaxConnAge,
+			PoolTimeout:      config.PoolTimeout,
+			IdleTimeout:      config.IdleTimeout,
+			TLSConfig:        tlsConfig,
+		}), nil
 
 	// 单机
 	default:
-		rdb = redis.NewClient(&redis.Options{
-			Addr:     config.Addr[0],
-			Password: config.Password,
-			DB:       config.DB,
-		})
+		return redis.NewClient(&redis.Options{
+			Addr:         config.Addr[0],
+			Username:     config.Username,
+			Password:     config.Password,
+			DB:           config.DB,
+			MaxRetries:   config.MaxRetries,
+			DialTimeout:  config.DialTimeout,
+			ReadTimeout:  config.ReadTimeout,
+			WriteTimeout: config.WriteTimeout,
+			PoolSize:     config.PoolSize,
+			MinIdleConns: config.MinIdleConns,
+			MaxConnAge:   config.MaxConnAge,
+			PoolTimeout:  config.PoolTimeout,
+			IdleTimeout:  config.IdleTimeout,
+			TLSConfig:    tlsConfig,
+		}), nil
+	}
+}
+
+// NewClient 实例化客户端并检查连接
+func NewClient(config Config) (redis.UniversalClient, error) {
+	rdb, err := newClient(config)
+	if err != nil {
+		return nil, err
 	}
 
 	// 测试redis客户端是否可用
@@ -80,17 +126,58 @@ func NewClient(config Config) (redis.UniversalClient, error) {
 	return rdb, nil
 }
 
+// NewClientDefault 实例化单机或 failover 客户端，cluster 类型返回错误
+func NewClientDefault(config Config) (*redis.Client, error) {
+	if config.typ() == TypeCluster {
+		return nil, errors.New("redis cluster client is not *redis.Client")
+	}
+	rdb, err := NewClient(config)
+	if rdb == nil {
+		return nil, err
+	}
+	return rdb.(*redis.Client), err
+}
+
 // Client
 // 获取指定的Redis客户端实例
 func Client(name string) redis.UniversalClient {
-	return clients[name]
+	client, _ := clientManager.Get(name)
+	return client
 }
 
 // ClientAndErr
 // 获取指定的Redis客户端实例不存在返回error
 func ClientAndErr(name string) (redis.UniversalClient, error) {
-	if client, ok := clients[name]; ok {
+	if client, ok := clientManager.Get(name); ok {
 		return client, nil
 	}
-	return nil, errors.New("redis client not exists")
+	return nil, ErrClientNotExists
+}
+
+// Default 获取 redis.default 配置的客户端
+func Default() (redis.UniversalClient, error) {
+	clientManager.mu.RLock()
+	name := clientManager.defaultName
+	clientManager.mu.RUnlock()
+	return ClientAndErr(name)
+}
+
+// ClientDefault
+// 获取指定的单机或 failover 客户端实例，cluster 客户端或不存在时返回 nil
+func ClientDefault(name string) *redis.Client {
+	client, _ := ClientAndErrDefault(name)
+	return client
+}
+
+// ClientAndErrDefault
+// 获取指定的单机或 failover 客户端实例不存在返回error
+func ClientAndErrDefault(name string) (*redis.Client, error) {
+	client, err := ClientAndErr(name)
+	if err != nil {
+		return nil, err
+	}
+	if c, ok := client.(*redis.Client); ok {
+		return c, nil
+	}
+	return nil, fmt.Errorf("redis client %s is not a single-node or failover client", name)
 }
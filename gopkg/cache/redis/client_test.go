@@ -0,0 +1,46 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClientTypes(t *testing.T) {
+	client, err := newClient(Config{Addr: []string{"127.0.0.1:6379"}, DB: 1, PoolSize: 5})
+	assert.NoError(t, err)
+	assert.IsType(t, &redis.Client{}, client)
+	assert.Equal(t, 5, client.(*redis.Client).Options().PoolSize)
+
+	client, err = newClient(Config{Type: TypeFailover, MasterName: "mymaster", Addr: []string{"127.0.0.1:26379"}})
+	assert.NoError(t, err)
+	assert.IsType(t, &redis.Client{}, client)
+
+	client, err = newClient(Config{Type: TypeCluster, Addr: []string{"127.0.0.1:7000", "127.0.0.1:7001"}})
+	assert.NoError(t, err)
+	assert.IsType(t, &redis.ClusterClient{}, client)
+
+	_, err = newClient(Config{Type: TypeCluster, Addr: []string{"127.0.0.1:7000"}, DB: 1})
+	assert.Error(t, err)
+	_, err = newClient(Config{Type: TypeFailover, Addr: []string{"127.0.0.1:26379"}})
+	assert.Error(t, err)
+	_, err = newClient(Config{})
+	assert.Error(t, err)
+	_, err = newClient(Config{Addr: []string{"127.0.0.1:6379"}, TLS: TLSConfig{Enable: true, CAFile: "missing.pem"}})
+	assert.Error(t, err)
+}
+
+func TestClientManager(t *testing.T) {
+	client, _ := newClient(Config{Addr: []string{"127.0.0.1:6379"}})
+	clientManager.Add("test", client)
+	defer clientManager.Close()
+
+	got, err := ClientAndErr("test")
+	assert.NoError(t, err)
+	assert.Equal(t, client, got)
+	assert.NotNil(t, ClientDefault("test"))
+
+	_, err = ClientAndErr("missing")
+	assert.ErrorIs(t, err, ErrClientNotExists)
+}
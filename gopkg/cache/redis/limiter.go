@@ -0,0 +1,211 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-agent/gopkg/utils"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// 限流算法
+const (
+	AlgorithmSlidingWindow = "sliding_window"
+	AlgorithmTokenBucket   = "token_bucket"
+)
+
+const defaultLimiterPrefix = "go-agent:ratelimit"
+
+// slidingWindowScript 滑动窗口日志，有序集合中保存窗口内每次请求的时间
+//
+// KEYS[1] 计数键；ARGV: limit, window(ms), n, member
+// 返回 {allowed, remaining, retry_after(ms), reset_after(ms)}
+var slidingWindowScript = redis.NewScript(`
+redis.replicate_commands()
+local key = KEYS[1]
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local n = tonumber(ARGV[3])
+local t = redis.call("TIME")
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+redis.call("ZREMRANGEBYSCORE", key, "-inf", now - window)
+local count = redis.call("ZCARD", key)
+local allowed = 0
+if count + n <= limit then
+	for i = 1, n do
+		redis.call("ZADD", key, now, ARGV[4] .. ":" .. i)
+	end
+	count = count + n
+	allowed = 1
+	redis.call("PEXPIRE", key, window)
+end
+
+local reset_after = 0
+local retry_after = 0
+local oldest = redis.call("ZRANGE", key, 0, 0, "WITHSCORES")
+if oldest[2] then
+	reset_after = tonumber(oldest[2]) + window - now
+end
+if allowed == 0 then
+	if n > limit then
+		retry_after = -1
+	else
+		-- 需要等待最早的 count + n - limit 个请求移出窗口
+		local idx = count + n - limit - 1
+		local entry = redis.call("ZRANGE", key, idx, idx, "WITHSCORES")
+		retry_after = tonumber(entry[2]) + window - now
+	end
+end
+return {allowed, math.max(limit - count, 0), retry_after, reset_after}
+`)
+
+// tokenBucketScript 令牌桶，哈希中保存剩余令牌数及上次更新时间
+//
+// KEYS[1] 令牌桶键；ARGV: rate(每 ms 补充的令牌数), burst, n
+// 返回 {allowed, remaining, retry_after(ms), reset_after(ms)}
+var tokenBucketScript = redis.NewScript(`
+redis.replicate_commands()
+local key = KEYS[1]
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local n = tonumber(ARGV[3])
+local t = redis.call("TIME")
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local state = redis.call("HMGET", key, "tokens", "ts")
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+tokens = math.min(burst, tokens + math.max(now - ts, 0) * rate)
+
+local allowed = 0
+local retry_after = 0
+if tokens >= n then
+	tokens = tokens - n
+	allowed = 1
+elseif n > burst then
+	retry_after = -1
+else
+	retry_after = math.ceil((n - tokens) / rate)
+end
+
+local reset_after = math.ceil((burst - tokens) / rate)
+redis.call("HSET", key, "tokens", tostring(tokens), "ts", now)
+redis.call("PEXPIRE", key, math.max(reset_after, 1))
+return {allowed, math.floor(tokens), retry_after, reset_after}
+`)
+
+// Limit 限流规则
+type Limit struct {
+	Algorithm string        // sliding_window, token_bucket，默认 sliding_window
+	Rate      int           // 窗口内允许的请求数；令牌桶为每个窗口补充的令牌数
+	Window    time.Duration // 窗口大小
+	Burst     int           // 令牌桶容量，默认等于 Rate，滑动窗口不使用
+}
+
+func (l Limit) validate() error {
+	if l.Rate <= 0 || l.Window <= 0 {
+		return fmt.Errorf("invalid rate limit: rate=%d, window=%s", l.Rate, l.Window)
+	}
+	switch l.Algorithm {
+	case "", AlgorithmSlidingWindow, AlgorithmTokenBucket:
+		return nil
+	default:
+		return fmt.Errorf("unknown rate limit algorithm: %s", l.Algorithm)
+	}
+}
+
+// burst 令牌桶容量，滑动窗口为 Rate
+func (l Limit) burst() int {
+	if l.Algorithm == AlgorithmTokenBucket && l.Burst > 0 {
+		return l.Burst
+	}
+	return l.Rate
+}
+
+// LimitResult 限流结果
+type LimitResult struct {
+	Allowed    bool
+	Limit      int           // 最多可连续通过的请求数
+	Remaining  int           // 剩余可通过的请求数
+	RetryAfter time.Duration // 被拒绝时需等待的时间，-1 表示请求数超过上限，永远无法通过
+	ResetAfter time.Duration // 恢复到 Limit 需等待的时间
+}
+
+// Limiter 基于 Redis Lua 脚本的限流器，计数及判断在脚本中原子完成，时间以 Redis 服务端为准
+type Limiter struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewLimiter 实例化Limiter，prefix 为空时使用 go-agent:ratelimit
+func NewLimiter(client redis.UniversalClient, prefix string) *Limiter {
+	if prefix == "" {
+		prefix = defaultLimiterPrefix
+	}
+	return &Limiter{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// Allow 判断 key 的一次请求是否允许通过
+func (l *Limiter) Allow(ctx context.Context, key string, limit Limit) (*LimitResult, error) {
+	return l.AllowN(ctx, key, limit, 1)
+}
+
+// AllowN 判断 key 的 n 次请求是否允许通过，不允许时不消耗配额
+func (l *Limiter) AllowN(ctx context.Context, key string, limit Limit, n int) (*LimitResult, error) {
+	if err := limit.validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		values []int64
+		err    error
+	)
+	switch limit.Algorithm {
+	case AlgorithmTokenBucket:
+		rate := float64(limit.Rate) / float64(limit.Window.Milliseconds())
+		values, err = tokenBucketScript.Run(ctx, l.client, []string{l.prefix + ":tb:" + key},
+			rate, limit.burst(), n).Int64Slice()
+	default:
+		values, err = slidingWindowScript.Run(ctx, l.client, []string{l.prefix + ":sw:" + key},
+			limit.Rate, limit.Window.Milliseconds(), n, utils.GenUUIDWithoutUnderline()).Int64Slice()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != 4 {
+		return nil, fmt.Errorf("unexpected rate limit result: %v", values)
+	}
+
+	result := &LimitResult{
+		Allowed:    values[0] == 1,
+		Limit:      limit.burst(),
+		Remaining:  int(values[1]),
+		RetryAfter: time.Duration(values[2]) * time.Millisecond,
+		ResetAfter: time.Duration(values[3]) * time.Millisecond,
+	}
+	if values[2] < 0 {
+		result.RetryAfter = -1
+	}
+	return result, nil
+}
+
+// Reset 清除 key 的计数
+func (l *Limiter) Reset(ctx context.Context, key string) error {
+	// cluster 模式下两个键可能不在同一个 slot，分别删除
+	for _, k := range []string{l.prefix + ":sw:" + key, l.prefix + ":tb:" + key} {
+		if err := l.client.Del(ctx, k).Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
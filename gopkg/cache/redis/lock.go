@@ -0,0 +1,300 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go-agent/gopkg/log"
+	"go-agent/gopkg/utils"
+
+	"github.com/go-redis/redis/v8"
+)
+
+var (
+	// ErrLockNotAcquired 锁已被其他持有者占用
+	ErrLockNotAcquired = errors.New("redis lock not acquired")
+	// ErrLockNotHeld 锁已过期或已被其他持有者获取
+	ErrLockNotHeld = errors.New("redis lock not held")
+)
+
+// 锁的默认选项
+const (
+	defaultLockTTL           = 30 * time.Second
+	defaultLockRetryInterval = 100 * time.Millisecond
+	defaultLockPrefix        = "go-agent:lock"
+)
+
+// releaseScript 只删除 token 匹配的锁，避免误删其他节点在锁过期后获取的锁
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// refreshScript 只续期 token 匹配的锁
+var refreshScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// lockOptions 加锁选项
+type lockOptions struct {
+	ttl           time.Duration
+	retryInterval time.Duration
+	renew         bool
+}
+
+// LockOption 加锁选项
+type LockOption func(*lockOptions)
+
+// WithLockTTL 锁的过期时间，默认 30s，自动续期时每 ttl/3 续期一次
+func WithLockTTL(ttl time.Duration) LockOption {
+	return func(o *lockOptions) {
+		o.ttl = ttl
+	}
+}
+
+// WithRetryInterval 阻塞加锁时的重试间隔，默认 100ms
+func WithRetryInterval(interval time.Duration) LockOption {
+	return func(o *lockOptions) {
+		o.retryInterval = interval
+	}
+}
+
+// WithoutRenewal 不自动续期，锁在 ttl 后过期
+func WithoutRenewal() LockOption {
+	return func(o *lockOptions) {
+		o.renew = false
+	}
+}
+
+func newLockOptions(opts []LockOption) lockOptions {
+	o := lockOptions{
+		ttl:           defaultLockTTL,
+		retryInterval: defaultLockRetryInterval,
+		renew:         true,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.ttl <= 0 {
+		o.ttl = defaultLockTTL
+	}
+	if o.retryInterval <= 0 {
+		o.retryInterval = defaultLockRetryInterval
+	}
+	return o
+}
+
+// Locker 基于 Redis SET NX 的分布式锁
+//
+// 每次加锁生成随机 token，释放及续期时通过 Lua 脚本比较 token，只操作自己持有的锁。
+type Locker struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewLocker 实例化Locker，prefix 为空时使用 go-agent:lock
+func NewLocker(client redis.UniversalClient, prefix string) *Locker {
+	if prefix == "" {
+		prefix = defaultLockPrefix
+	}
+	return &Locker{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+func (l *Locker) lockKey(key string) string {
+	return l.prefix + ":" + key
+}
+
+// TryLock 尝试加锁，锁已被占用时返回 ErrLockNotAcquired
+func (l *Locker) TryLock(ctx context.Context, key string, opts ...LockOption) (*Lock, error) {
+	o := newLockOptions(opts)
+	token := utils.GenUUIDWithoutUnderline()
+	ok, err := l.client.SetNX(ctx, l.lockKey(key), token, o.ttl).Result()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrLockNotAcquired
+	}
+	return l.newLock(key, token, o), nil
+}
+
+// Lock 阻塞加锁直到成功或 ctx 取消，等待超时通过 ctx 控制
+func (l *Locker) Lock(ctx context.Context, key string, opts ...LockOption) (*Lock, error) {
+	o := newLockOptions(opts)
+	ticker := time.NewTicker(o.retryInterval)
+	defer ticker.Stop()
+	for {
+		lock, err := l.TryLock(ctx, key, opts...)
+		if !errors.Is(err, ErrLockNotAcquired) {
+			return lock, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Unlock 释放 token 对应的锁，锁已不属于该 token 时返回 ErrLockNotHeld
+func (l *Locker) Unlock(ctx context.Context, key, token string) error {
+	n, err := releaseScript.Run(ctx, l.client, []string{l.lockKey(key)}, token).Int()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// Refresh 将 token 对应的锁续期为 ttl，锁已不属于该 token 时返回 ErrLockNotHeld
+func (l *Locker) Refresh(ctx context.Context, key, token string, ttl time.Duration) error {
+	n, err := refreshScript.Run(ctx, l.client, []string{l.lockKey(key)}, token, ttl.Milliseconds()).Int()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// WithLock 阻塞加锁后执行 fn 并释放锁
+//
+// 锁默认自动续期；续期失败（锁已丢失）时 fn 的 ctx 被取消，WithLock 返回 ErrLockNotHeld。
+func (l *Locker) WithLock(ctx context.Context, key string, fn func(ctx context.Context) error, opts ...LockOption) error {
+	lock, err := l.Lock(ctx, key, opts...)
+	if err != nil {
+		return err
+	}
+
+	fnCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-lock.Lost():
+			cancel()
+		case <-fnCtx.Done():
+		}
+	}()
+
+	fnErr := fn(fnCtx)
+	releaseErr := lock.Release(context.Background())
+	select {
+	case <-lock.Lost():
+		// fn 可能因 ctx 取消返回 context.Canceled，统一返回锁丢失
+		return ErrLockNotHeld
+	default:
+	}
+	if fnErr != nil {
+		return fnErr
+	}
+	return releaseErr
+}
+
+// Lock 已持有的锁
+type Lock struct {
+	locker *Locker
+	key    string
+	token  string
+	ttl    time.Duration
+	stop   chan struct{}
+	lost   chan struct{}
+	once   sync.Once
+	wg     sync.WaitGroup
+}
+
+func (l *Locker) newLock(key, token string, o lockOptions) *Lock {
+	lock := &Lock{
+		locker: l,
+		key:    key,
+		token:  token,
+		ttl:    o.ttl,
+		stop:   make(chan struct{}),
+		lost:   make(chan struct{}),
+	}
+	if o.renew {
+		lock.wg.Add(1)
+		go lock.renew()
+	}
+	return lock
+}
+
+// Key 锁的键，不含前缀
+func (lk *Lock) Key() string {
+	return lk.key
+}
+
+// Token 锁的 token
+func (lk *Lock) Token() string {
+	return lk.token
+}
+
+// Lost 锁丢失（续期时发现已不属于自己或续期失败直到过期）时关闭
+func (lk *Lock) Lost() <-chan struct{} {
+	return lk.lost
+}
+
+// Refresh 手动续期为 ttl
+func (lk *Lock) Refresh(ctx context.Context, ttl time.Duration) error {
+	return lk.locker.Refresh(ctx, lk.key, lk.token, ttl)
+}
+
+// Release 停止续期并释放锁，锁已丢失时返回 ErrLockNotHeld
+func (lk *Lock) Release(ctx context.Context) error {
+	lk.once.Do(func() {
+		close(lk.stop)
+	})
+	lk.wg.Wait()
+	return lk.locker.Unlock(ctx, lk.key, lk.token)
+}
+
+// renew 每 ttl/3 续期一次，锁已不属于自己或超过 ttl 未能续期时关闭 lost
+func (lk *Lock) renew() {
+	defer lk.wg.Done()
+	ticker := time.NewTicker(lk.ttl / 3)
+	defer ticker.Stop()
+
+	renewedAt := time.Now()
+	for {
+		select {
+		case <-lk.stop:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), lk.ttl/3)
+		err := lk.Refresh(ctx, lk.ttl)
+		cancel()
+		switch {
+		case err == nil:
+			renewedAt = time.Now()
+		case errors.Is(err, ErrLockNotHeld) || time.Since(renewedAt) >= lk.ttl:
+			log.Sugar().Errorf("redis 锁已丢失: %s, 错误: %v", lk.key, err)
+			close(lk.lost)
+			return
+		default:
+			log.Sugar().Warnf("redis 锁续期失败，稍后重试: %s, 错误: %v", lk.key, err)
+		}
+	}
+}
+
+// WithLock 使用 redis.default 客户端加锁后执行 fn，见 Locker.WithLock
+func WithLock(ctx context.Context, key string, fn func(ctx context.Context) error, opts ...LockOption) error {
+	client, err := Default()
+	if err != nil {
+		return err
+	}
+	return NewLocker(client, defaultLockPrefix).WithLock(ctx, key, fn, opts...)
+}
@@ -0,0 +1,99 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockOptions(t *testing.T) {
+	o := newLockOptions(nil)
+	assert.Equal(t, defaultLockTTL, o.ttl)
+	assert.True(t, o.renew)
+
+	o = newLockOptions([]LockOption{WithLockTTL(time.Second), WithRetryInterval(10 * time.Millisecond), WithoutRenewal()})
+	assert.Equal(t, time.Second, o.ttl)
+	assert.Equal(t, 10*time.Millisecond, o.retryInterval)
+	assert.False(t, o.renew)
+
+	assert.Equal(t, "go-agent:lock:job", NewLocker(nil, "").lockKey("job"))
+}
+
+func newTestLocker(t *testing.T) (*miniredis.Miniredis, *Locker) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	return mr, NewLocker(client, "")
+}
+
+func TestLocker_TryLock(t *testing.T) {
+	mr, locker := newTestLocker(t)
+	ctx := context.Background()
+
+	lock, err := locker.TryLock(ctx, "job", WithLockTTL(time.Second), WithoutRenewal())
+	assert.NoError(t, err)
+	_, err = locker.TryLock(ctx, "job")
+	assert.ErrorIs(t, err, ErrLockNotAcquired)
+
+	// 过期后被其他持有者获取，原持有者不能释放
+	mr.FastForward(2 * time.Second)
+	other, err := locker.TryLock(ctx, "job", WithoutRenewal())
+	assert.NoError(t, err)
+	assert.ErrorIs(t, lock.Release(ctx), ErrLockNotHeld)
+	assert.ErrorIs(t, locker.Unlock(ctx, "job", lock.Token()), ErrLockNotHeld)
+	assert.True(t, mr.Exists(locker.lockKey("job")))
+
+	assert.NoError(t, other.Release(ctx))
+	assert.False(t, mr.Exists(locker.lockKey("job")))
+
+	// 过期后无人持有，释放同样返回 ErrLockNotHeld
+	lock, err = locker.TryLock(ctx, "job", WithLockTTL(time.Second), WithoutRenewal())
+	assert.NoError(t, err)
+	mr.FastForward(2 * time.Second)
+	assert.ErrorIs(t, lock.Release(ctx), ErrLockNotHeld)
+}
+
+func TestLocker_Refresh(t *testing.T) {
+	mr, locker := newTestLocker(t)
+	ctx := context.Background()
+
+	lock, err := locker.TryLock(ctx, "job", WithLockTTL(time.Second), WithoutRenewal())
+	assert.NoError(t, err)
+	mr.FastForward(500 * time.Millisecond)
+	assert.NoError(t, lock.Refresh(ctx, 5*time.Second))
+	assert.Equal(t, 5*time.Second, mr.TTL(locker.lockKey("job")))
+	assert.ErrorIs(t, locker.Refresh(ctx, "job", "other", time.Second), ErrLockNotHeld)
+	assert.NoError(t, lock.Release(ctx))
+
+	// 自动续期每 ttl/3 将过期时间恢复为 ttl
+	lock, err = locker.TryLock(ctx, "renew", WithLockTTL(300*time.Millisecond))
+	assert.NoError(t, err)
+	defer lock.Release(ctx)
+	mr.FastForward(250 * time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return mr.TTL(locker.lockKey("renew")) > 250*time.Millisecond
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestLocker_WithLockLost(t *testing.T) {
+	mr, locker := newTestLocker(t)
+	ctx := context.Background()
+
+	err := locker.WithLock(ctx, "job", func(ctx context.Context) error {
+		// 锁被其他持有者抢占，续期时发现后取消 fn 的 ctx
+		mr.Set(locker.lockKey("job"), "other")
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+			return nil
+		}
+	}, WithLockTTL(60*time.Millisecond))
+	assert.ErrorIs(t, err, ErrLockNotHeld)
+	v, err := mr.Get(locker.lockKey("job"))
+	assert.NoError(t, err)
+	assert.Equal(t, "other", v)
+}
@@ -1,54 +1,139 @@
 package redis
 
 import (
-	"strings"
+	"errors"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/cloudwego/hertz/pkg/common/hlog"
 	"github.com/go-redis/redis/v8"
 	"github.com/spf13/viper"
 )
 
-var defaultName string
-var clients map[string]redis.UniversalClient
-var clientDefault map[string]*redis.Client
+// ErrClientNotExists 客户端不存在
+var ErrClientNotExists = errors.New("redis client not exists")
+
+// 客户端类型
+const (
+	TypeSingleNode = "single-node"
+	TypeCluster    = "cluster"
+	TypeFailover   = "failover"
+)
 
 type Config struct {
-	Type     string   `json:"type" mapstructure:"type"` // cluster, failover,single-node , default is single-node
-	Addr     []string `json:"addr" mapstructure:"addr"`
-	Password string   `json:"password" mapstructure:"password"`
-	DB       int      `json:"db" mapstructure:"db"`
+	Type             string        `json:"type" mapstructure:"type"` // cluster, failover,single-node , default is single-node
+	Addr             []string      `json:"addr" mapstructure:"addr"` // single-node 取第一个，cluster 为节点地址，failover 为 sentinel 地址
+	Username         string        `json:"username" mapstructure:"username"`
+	Password         string        `json:"password" mapstructure:"password"`
+	DB               int           `json:"db" mapstructure:"db"`                               // cluster 模式不支持
+	MasterName       string        `json:"master_name" mapstructure:"master_name"`             // failover 模式的主节点名称
+	SentinelUsername string        `json:"sentinel_username" mapstructure:"sentinel_username"` // failover 模式 sentinel 的用户名
+	SentinelPassword string        `json:"sentinel_password" mapstructure:"sentinel_password"` // failover 模式 sentinel 的密码
+	RouteByLatency   bool          `json:"route_by_latency" mapstructure:"route_by_latency"`   // cluster 模式只读命令路由到延迟最低的节点
+	RouteRandomly    bool          `json:"route_randomly" mapstructure:"route_randomly"`       // cluster 模式只读命令随机路由
+	PoolSize         int           `json:"pool_size" mapstructure:"pool_size"`                 // 连接池大小，默认每个 CPU 10 个
+	MinIdleConns     int           `json:"min_idle_conns" mapstructure:"min_idle_conns"`       // 最小空闲连接数
+	MaxConnAge       time.Duration `json:"max_conn_age" mapstructure:"max_conn_age"`           // 连接最长使用时间，0 表示不限制
+	PoolTimeout      time.Duration `json:"pool_timeout" mapstructure:"pool_timeout"`           // 等待空闲连接的超时
+	IdleTimeout      time.Duration `json:"idle_timeout" mapstructure:"idle_timeout"`           // 空闲连接关闭时间
+	DialTimeout      time.Duration `json:"dial_timeout" mapstructure:"dial_timeout"`           // 建立连接超时
+	ReadTimeout      time.Duration `json:"read_timeout" mapstructure:"read_timeout"`           // 读超时
+	WriteTimeout     time.Duration `json:"write_timeout" mapstructure:"write_timeout"`         // 写超时
+	MaxRetries       int           `json:"max_retries" mapstructure:"max_retries"`             // 命令失败重试次数，-1 表示不重试
+	TLS              TLSConfig     `json:"tls" mapstructure:"tls"`                             // TLS 配置
 }
 
-func InitFromViper() error {
-	var cfg map[string]Config
-	err := viper.UnmarshalKey("redis.client", &cfg)
-	if err != nil {
-		return err
+// ClientManager 管理Redis客户端
+type ClientManager struct {
+	mu          sync.RWMutex
+	clients     map[string]redis.UniversalClient
+	defaultName string
+}
+
+// NewClientManager 实例化ClientManager
+func NewClientManager() *ClientManager {
+	return &ClientManager{
+		clients: make(map[string]redis.UniversalClient),
 	}
-	clients = make(map[string]redis.UniversalClient)
-	for k := range cfg {
-		if clients[k], err = NewClient(cfg[k]); err != nil {
-			return err
-		}
+}
+
+// Add 添加客户端，同名客户端会被替换并关闭
+func (cm *ClientManager) Add(name string, client redis.UniversalClient) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	if old, ok := cm.clients[name]; ok && old != client {
+		_ = old.Close()
 	}
+	cm.clients[name] = client
+}
+
+// Get 获取客户端
+func (cm *ClientManager) Get(name string) (redis.UniversalClient, bool) {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	client, ok := cm.clients[name]
+	return client, ok
+}
 
-	hlog.Infof("redis client connect successful，环境地址: %s", strings.Join(cfg["account"].Addr, " "))
+// Names 客户端名称
+func (cm *ClientManager) Names() []string {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	names := make([]string, 0, len(cm.clients))
+	for name := range cm.clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
 
-	return nil
+// Close 关闭所有客户端
+func (cm *ClientManager) Close() error {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	var errs []error
+	for name, client := range cm.clients {
+		if err := client.Close(); err != nil {
+			errs = append(errs, err)
+		}
+		delete(cm.clients, name)
+	}
+	return errors.Join(errs...)
 }
 
-func InitFromViperDefault() error {
-	defaultName = viper.GetString("redis.default")
+var clientManager = NewClientManager()
+
+// InitFromViper 根据 redis.client 配置初始化客户端，redis.default 为默认客户端名称
+func InitFromViper() error {
 	var cfg map[string]Config
 	err := viper.UnmarshalKey("redis.client", &cfg)
 	if err != nil {
 		return err
 	}
-	clientDefault = make(map[string]*redis.Client)
-	for k := range cfg {
-		if clientDefault[k], err = NewClientDefault(cfg[k]); err != nil {
+	for name, c := range cfg {
+		client, err := NewClient(c)
+		if err != nil {
 			return err
 		}
+		clientManager.Add(name, client)
+		hlog.Infof("redis client connect successful，名称: %s, 类型: %s, 环境地址: %v", name, c.typ(), c.Addr)
 	}
+
+	clientManager.mu.Lock()
+	clientManager.defaultName = viper.GetString("redis.default")
+	clientManager.mu.Unlock()
 	return nil
 }
+
+// InitFromViperDefault 与 InitFromViper 相同，保留用于兼容
+//
+// Deprecated: 使用 InitFromViper，单机及 failover 客户端可通过 ClientDefault 获取 *redis.Client。
+func InitFromViperDefault() error {
+	return InitFromViper()
+}
+
+// Close 关闭所有客户端
+func Close() error {
+	return clientManager.Close()
+}
@@ -0,0 +1,50 @@
+package redis
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// TLSConfig TLS 配置
+type TLSConfig struct {
+	Enable             bool   `json:"enable" mapstructure:"enable"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify" mapstructure:"insecure_skip_verify"` // 跳过服务端证书校验，仅用于测试环境
+	ServerName         string `json:"server_name" mapstructure:"server_name"`                   // 校验证书使用的服务端名称，默认取连接地址
+	CAFile             string `json:"ca_file" mapstructure:"ca_file"`                           // 自签名 CA 证书
+	CertFile           string `json:"cert_file" mapstructure:"cert_file"`                       // 双向认证的客户端证书
+	KeyFile            string `json:"key_file" mapstructure:"key_file"`                         // 双向认证的客户端私钥
+}
+
+// build 构造 tls.Config，未启用时返回 nil
+func (c TLSConfig) build() (*tls.Config, error) {
+	if !c.Enable {
+		return nil, nil
+	}
+
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+	if c.CAFile != "" {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read redis ca file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("invalid redis ca file: %s", c.CAFile)
+		}
+		config.RootCAs = pool
+	}
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load redis client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
@@ -0,0 +1,248 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+var (
+	// ErrJobNotFound 任务未注册
+	ErrJobNotFound = errors.New("cron job not found")
+	// ErrJobDisabled 任务未在配置中启用
+	ErrJobDisabled = errors.New("cron job disabled")
+	// ErrInvalidSpec spec 格式错误
+	ErrInvalidSpec = errors.New("invalid cron spec")
+)
+
+// controlInterval 从 ControlStore 同步状态及手动触发请求的间隔
+const controlInterval = 2 * time.Second
+
+// JobInfo 定时任务信息
+type JobInfo struct {
+	Name    string     `json:"name"`
+	Spec    string     `json:"spec"`
+	Enabled bool       `json:"enabled"`
+	Paused  bool       `json:"paused"`
+	Running bool       `json:"running"`
+	Timeout string     `json:"timeout"`
+	Next    *time.Time `json:"next,omitempty"`
+	Prev    *time.Time `json:"prev,omitempty"`
+}
+
+// WithControl 设置任务状态共享存储，暂停、恢复、修改 spec 及手动触发会同步到所有节点
+func WithControl(control ControlStore) SchedulerOption {
+	return func(s *Scheduler) {
+		s.control = control
+	}
+}
+
+// Jobs 已注册任务的信息，下次及上次执行时间来自当前节点的调度器
+func (s *Scheduler) Jobs() []JobInfo {
+	entries := make(map[cron.EntryID]cron.Entry)
+	for _, entry := range s.cron.Entries() {
+		entries[entry.ID] = entry
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	jobs := make([]JobInfo, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		info := JobInfo{
+			Name:    j.name,
+			Spec:    j.spec,
+			Enabled: j.config.Enabled,
+			Paused:  j.paused,
+			Running: j.running.Load(),
+			Timeout: j.config.Timeout.String(),
+		}
+		if entry, ok := entries[j.entryID]; ok && j.entryID != 0 {
+			if !entry.Next.IsZero() {
+				next := entry.Next
+				info.Next = &next
+			}
+			if !entry.Prev.IsZero() {
+				prev := entry.Prev
+				info.Prev = &prev
+			}
+		}
+		jobs = append(jobs, info)
+	}
+	sort.Slice(jobs, func(i, k int) bool {
+		return jobs[i].Name < jobs[k].Name
+	})
+	return jobs
+}
+
+// Job 获取单个任务信息
+func (s *Scheduler) Job(name string) (JobInfo, error) {
+	for _, info := range s.Jobs() {
+		if info.Name == name {
+			return info, nil
+		}
+	}
+	return JobInfo{}, ErrJobNotFound
+}
+
+// Trigger 立即执行任务，不影响正常调度，任务仍在执行时跳过
+func (s *Scheduler) Trigger(ctx context.Context, name string) error {
+	j, err := s.job(name)
+	if err != nil {
+		return err
+	}
+	if s.control != nil {
+		return s.control.Trigger(ctx, name)
+	}
+	go s.run(j, true)
+	return nil
+}
+
+// Pause 暂停任务调度
+func (s *Scheduler) Pause(ctx context.Context, name string) error {
+	return s.update(ctx, name, func(state *JobState) {
+		state.Paused = true
+	})
+}
+
+// Resume 恢复任务调度
+func (s *Scheduler) Resume(ctx context.Context, name string) error {
+	return s.update(ctx, name, func(state *JobState) {
+		state.Paused = false
+	})
+}
+
+// UpdateSpec 修改任务 spec，立即生效，spec 为空时恢复为配置中的 spec
+func (s *Scheduler) UpdateSpec(ctx context.Context, name, spec string) error {
+	if spec != "" {
+		if _, err := s.parser().Parse(spec); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSpec, err)
+		}
+	}
+	return s.update(ctx, name, func(state *JobState) {
+		state.Spec = spec
+	})
+}
+
+func (s *Scheduler) job(name string) (*scheduledJob, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	j, ok := s.jobs[name]
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	return j, nil
+}
+
+// parser 与调度器一致的 spec 解析器
+func (s *Scheduler) parser() cron.Parser {
+	if s.seconds {
+		return cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+	}
+	return cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+}
+
+// update 修改任务状态，先写入 ControlStore 再应用到当前节点，其他节点在下次同步时生效
+func (s *Scheduler) update(ctx context.Context, name string, fn func(state *JobState)) error {
+	j, err := s.job(name)
+	if err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	state := j.state()
+	enabled := j.config.Enabled
+	s.mu.RUnlock()
+	if !enabled {
+		return ErrJobDisabled
+	}
+
+	fn(&state)
+	if s.control != nil {
+		if err := s.control.SetState(ctx, name, state); err != nil {
+			return err
+		}
+	}
+	return s.apply(j, state)
+}
+
+// state 当前状态，调用方需持有 s.mu
+func (j *scheduledJob) state() JobState {
+	state := JobState{Paused: j.paused}
+	if j.spec != j.config.Spec {
+		state.Spec = j.spec
+	}
+	return state
+}
+
+// apply 将状态应用到当前节点
+func (s *Scheduler) apply(j *scheduledJob, state JobState) error {
+	spec := state.Spec
+	if spec == "" {
+		spec = j.config.Spec
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if j.paused == state.Paused && j.spec == spec {
+		return nil
+	}
+
+	oldSpec, oldPaused := j.spec, j.paused
+	j.spec, j.paused = spec, state.Paused
+	if err := s.schedule(j); err != nil {
+		j.spec, j.paused = oldSpec, oldPaused
+		_ = s.schedule(j)
+		return err
+	}
+	s.logger.Infof("定时任务状态已更新: %s, spec: %s, 暂停: %v", j.name, j.spec, j.paused)
+	return nil
+}
+
+// syncControl 从 ControlStore 同步任务状态并执行手动触发请求
+func (s *Scheduler) syncControl() {
+	ctx := context.Background()
+	states, err := s.control.States(ctx)
+	if err != nil {
+		s.logger.Errorf("同步定时任务状态失败: %v", err)
+	}
+	for name, state := range states {
+		j, err := s.job(name)
+		if err != nil {
+			continue
+		}
+		if err := s.apply(j, state); err != nil {
+			s.logger.Errorf("应用定时任务状态失败: %s, 错误: %v", name, err)
+		}
+	}
+
+	names, err := s.control.Triggers(ctx)
+	if err != nil {
+		s.logger.Errorf("获取定时任务触发请求失败: %v", err)
+	}
+	for _, name := range names {
+		j, err := s.job(name)
+		if err != nil {
+			continue
+		}
+		s.logger.Infof("手动触发定时任务: %s", name)
+		go s.run(j, true)
+	}
+}
+
+// watchControl 定时同步，直到调度器停止
+func (s *Scheduler) watchControl() {
+	ticker := time.NewTicker(controlInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.syncControl()
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/robfig/cron/v3"
 )
 
+// Cron 旧的定时任务接口，无法取消及返回错误，新任务请实现 Job
 type Cron interface {
 	Spec() string
 	Run()
@@ -11,7 +12,7 @@ type Cron interface {
 
 // InitFromSecond 秒级
 func InitFromSecond(cronList []Cron) error {
-	if err := initFromViper(cron.New(cron.WithSeconds()), cronList); err != nil {
+	if err := initFromViper(newCron(true), cronList); err != nil {
 		return err
 	}
 	return nil
@@ -19,7 +20,7 @@ func InitFromSecond(cronList []Cron) error {
 
 // InitFromMinute 分级
 func InitFromMinute(cronList []Cron) error {
-	if err := initFromViper(cron.New(), cronList); err != nil {
+	if err := initFromViper(newCron(false), cronList); err != nil {
 		return err
 	}
 	return nil
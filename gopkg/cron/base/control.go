@@ -0,0 +1,100 @@
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// JobState 运行时修改的任务状态，在各节点间同步
+type JobState struct {
+	Paused bool   `json:"paused"`
+	Spec   string `json:"spec,omitempty"` // 为空时使用配置中的 spec
+}
+
+// ControlStore 任务状态及手动触发请求的共享存储，多副本部署时保证各节点状态一致
+type ControlStore interface {
+	// States 获取全部任务状态
+	States(ctx context.Context) (map[string]JobState, error)
+	// SetState 保存任务状态
+	SetState(ctx context.Context, name string, state JobState) error
+	// Trigger 提交手动触发请求
+	Trigger(ctx context.Context, name string) error
+	// Triggers 取出待执行的手动触发请求，每个请求只会被一个节点取到
+	Triggers(ctx context.Context) ([]string, error)
+}
+
+// maxTriggers 每次同步最多取出的手动触发请求数
+const maxTriggers = 100
+
+// RedisControlStore 基于 Redis 的 ControlStore
+type RedisControlStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisControlStore 实例化RedisControlStore
+func NewRedisControlStore(client redis.UniversalClient, prefix string) *RedisControlStore {
+	return &RedisControlStore{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+func (s *RedisControlStore) stateKey() string {
+	return s.prefix + ":state"
+}
+
+func (s *RedisControlStore) triggerKey() string {
+	return s.prefix + ":trigger"
+}
+
+// States 获取全部任务状态
+func (s *RedisControlStore) States(ctx context.Context) (map[string]JobState, error) {
+	values, err := s.client.HGetAll(ctx, s.stateKey()).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	states := make(map[string]JobState, len(values))
+	for name, value := range values {
+		var state JobState
+		if err := json.Unmarshal([]byte(value), &state); err != nil {
+			return nil, err
+		}
+		states[name] = state
+	}
+	return states, nil
+}
+
+// SetState 保存任务状态
+func (s *RedisControlStore) SetState(ctx context.Context, name string, state JobState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return s.client.HSet(ctx, s.stateKey(), name, data).Err()
+}
+
+// Trigger 提交手动触发请求
+func (s *RedisControlStore) Trigger(ctx context.Context, name string) error {
+	return s.client.LPush(ctx, s.triggerKey(), name).Err()
+}
+
+// Triggers 取出待执行的手动触发请求
+func (s *RedisControlStore) Triggers(ctx context.Context) ([]string, error) {
+	var names []string
+	for i := 0; i < maxTriggers; i++ {
+		name, err := s.client.RPop(ctx, s.triggerKey()).Result()
+		if errors.Is(err, redis.Nil) {
+			break
+		}
+		if err != nil {
+			return names, err
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
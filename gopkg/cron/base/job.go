@@ -0,0 +1,43 @@
+package base
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// Job 支持取消及返回错误的定时任务
+//
+// ctx 在任务超时或进程优雅退出时取消，返回的错误会记录到执行记录并上报 Sentry。
+type Job interface {
+	Spec() string
+	Run(ctx context.Context) error
+}
+
+// cronJob 将旧的 Cron 适配为 Job
+type cronJob struct {
+	Cron
+}
+
+// FromCron 将旧的 Cron 适配为 Job，旧任务无法响应取消，执行超时只在结束后记录
+func FromCron(c Cron) Job {
+	return &cronJob{Cron: c}
+}
+
+func (c *cronJob) Run(context.Context) error {
+	c.Cron.Run()
+	return nil
+}
+
+// zapLogger 将 zap 适配为 robfig/cron 的 Logger，调度日志使用 debug 级别
+type zapLogger struct {
+	logger *zap.SugaredLogger
+}
+
+func (l zapLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.logger.Debugw("cron: "+msg, keysAndValues...)
+}
+
+func (l zapLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	l.logger.Errorw("cron: "+msg, append(keysAndValues, "error", err)...)
+}
@@ -0,0 +1,46 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	rxRedis "go-agent/gopkg/cache/redis"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// RedisLocker 基于 rxRedis.Locker 的分布式锁
+//
+// 调度器自行管理锁的生命周期，这里不自动续期，锁在任务超时时间后过期。
+type RedisLocker struct {
+	locker *rxRedis.Locker
+}
+
+// NewRedisLocker 实例化RedisLocker
+func NewRedisLocker(client redis.UniversalClient, prefix string) *RedisLocker {
+	return &RedisLocker{
+		locker: rxRedis.NewLocker(client, prefix),
+	}
+}
+
+// Acquire 尝试加锁
+func (l *RedisLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (string, bool, error) {
+	lock, err := l.locker.TryLock(ctx, key, rxRedis.WithLockTTL(ttl), rxRedis.WithoutRenewal())
+	if errors.Is(err, rxRedis.ErrLockNotAcquired) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return lock.Token(), true, nil
+}
+
+// Release 释放锁，锁已过期时忽略
+func (l *RedisLocker) Release(ctx context.Context, key, token string) error {
+	err := l.locker.Unlock(ctx, key, token)
+	if errors.Is(err, rxRedis.ErrLockNotHeld) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,372 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go-agent/gopkg/log"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// JobConfig 定时任务配置，对应 cron.jobs.<name>
+type JobConfig struct {
+	Spec    string        `json:"spec" mapstructure:"spec"`       // 为空时使用任务的 Spec()
+	Enabled bool          `json:"enabled" mapstructure:"enabled"` // 未启用的任务不会被调度
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"` // 单次执行超时，同时作为分布式锁的过期时间
+}
+
+// 执行记录状态
+const (
+	RunStatusSuccess   = "success"
+	RunStatusFailed    = "failed"
+	RunStatusTimeout   = "timeout"
+	RunStatusCancelled = "cancelled"
+)
+
+// RunRecord 定时任务执行记录
+type RunRecord struct {
+	Name        string
+	Node        string
+	Status      string
+	Error       string
+	ScheduledAt time.Time
+	StartedAt   time.Time
+	FinishedAt  time.Time
+	Duration    time.Duration
+}
+
+// HistoryStore 定时任务执行记录存储
+type HistoryStore interface {
+	Save(ctx context.Context, record *RunRecord) error
+}
+
+// Locker 分布式锁，保证多副本部署时同一任务只在一个节点执行
+type Locker interface {
+	// Acquire 尝试加锁，已被占用时返回 false
+	Acquire(ctx context.Context, key string, ttl time.Duration) (token string, ok bool, err error)
+	// Release 释放 token 对应的锁
+	Release(ctx context.Context, key, token string) error
+}
+
+// defaultJobTimeout 未配置超时时的默认值
+const defaultJobTimeout = 10 * time.Minute
+
+// scheduledJob 已注册的定时任务
+type scheduledJob struct {
+	name    string
+	job     Job
+	config  JobConfig
+	spec    string // 当前生效的 spec，可在运行时修改
+	paused  bool
+	entryID cron.EntryID
+	running atomic.Bool
+}
+
+// Scheduler 支持分布式锁、执行记录及配置化的定时任务调度器
+type Scheduler struct {
+	cron    *cron.Cron
+	mu      sync.RWMutex
+	jobs    map[string]*scheduledJob
+	ctx     context.Context
+	locker  Locker
+	history HistoryStore
+	control ControlStore
+	seconds bool
+	node    string
+	logger  *zap.SugaredLogger
+	stop    chan struct{}
+	once    sync.Once
+}
+
+// SchedulerOption 调度器选项
+type SchedulerOption func(*Scheduler)
+
+// WithSeconds 使用秒级 spec
+func WithSeconds() SchedulerOption {
+	return func(s *Scheduler) {
+		s.seconds = true
+	}
+}
+
+// WithContext 设置任务的父 ctx，ctx 取消时执行中的任务随之取消，通常传入 graceful.Context()
+func WithContext(ctx context.Context) SchedulerOption {
+	return func(s *Scheduler) {
+		s.ctx = ctx
+	}
+}
+
+// WithLocker 设置分布式锁，未设置时只在当前进程内防止重复执行
+func WithLocker(locker Locker) SchedulerOption {
+	return func(s *Scheduler) {
+		s.locker = locker
+	}
+}
+
+// WithHistory 设置执行记录存储
+func WithHistory(history HistoryStore) SchedulerOption {
+	return func(s *Scheduler) {
+		s.history = history
+	}
+}
+
+// NewScheduler 实例化Scheduler，默认分钟级 spec
+func NewScheduler(opts ...SchedulerOption) *Scheduler {
+	hostname, _ := os.Hostname()
+	s := &Scheduler{
+		jobs:   make(map[string]*scheduledJob),
+		ctx:    context.Background(),
+		node:   fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		logger: log.Sugar(),
+		stop:   make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.cron = newCron(s.seconds)
+	return s
+}
+
+// newCron 创建 robfig 调度器，使用 zap 记录日志并通过 cron.Recover 兜底 panic
+func newCron(seconds bool) *cron.Cron {
+	logger := zapLogger{logger: log.Sugar()}
+	opts := []cron.Option{
+		cron.WithLogger(logger),
+		cron.WithChain(cron.Recover(logger)),
+	}
+	if seconds {
+		opts = append(opts, cron.WithSeconds())
+	}
+	return cron.New(opts...)
+}
+
+// Register 注册定时任务，未启用的任务只登记不调度，旧的 Cron 通过 FromCron 适配
+func (s *Scheduler) Register(name string, j Job, cfg JobConfig) error {
+	if cfg.Spec == "" {
+		cfg.Spec = j.Spec()
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultJobTimeout
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.jobs[name]; ok {
+		return fmt.Errorf("cron job %s already registered", name)
+	}
+
+	sj := &scheduledJob{name: name, job: j, config: cfg, spec: cfg.Spec}
+	if err := s.schedule(sj); err != nil {
+		return err
+	}
+	s.jobs[name] = sj
+	return nil
+}
+
+// schedule 按当前 spec 及暂停状态重新调度任务，调用方需持有 s.mu
+func (s *Scheduler) schedule(j *scheduledJob) error {
+	if j.entryID != 0 {
+		s.cron.Remove(j.entryID)
+		j.entryID = 0
+	}
+	if !j.config.Enabled || j.paused {
+		return nil
+	}
+
+	id, err := s.cron.AddFunc(j.spec, func() { s.run(j, false) })
+	if err != nil {
+		return fmt.Errorf("cron job %s: %w", j.name, err)
+	}
+	j.entryID = id
+	return nil
+}
+
+// Names 已注册的任务名称
+func (s *Scheduler) Names() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	names := make([]string, 0, len(s.jobs))
+	for name := range s.jobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Start 启动调度，设置了 ControlStore 时同时启动状态同步
+func (s *Scheduler) Start() {
+	if s.control != nil {
+		s.syncControl()
+		go s.watchControl()
+	}
+	s.cron.Start()
+}
+
+// Stop 停止调度，返回的 ctx 在执行中的任务结束后完成
+func (s *Scheduler) Stop() context.Context {
+	s.once.Do(func() {
+		close(s.stop)
+	})
+	return s.cron.Stop()
+}
+
+// GracefulStart 实现 graceful.Graceful，ctx 取消后停止调度并等待执行中的任务结束
+func (s *Scheduler) GracefulStart(ctx context.Context) {
+	<-ctx.Done()
+	<-s.Stop().Done()
+	s.logger.Info("定时任务已停止")
+}
+
+// run 执行任务：本地或其他节点仍在执行时跳过，按计划触发时同一计划时间只在一个节点执行
+func (s *Scheduler) run(j *scheduledJob, manual bool) {
+	scheduledAt := time.Now()
+	if !manual {
+		s.mu.RLock()
+		entryID := j.entryID
+		s.mu.RUnlock()
+		if prev := s.cron.Entry(entryID).Prev; !prev.IsZero() {
+			scheduledAt = prev
+		}
+	}
+
+	if !j.running.CompareAndSwap(false, true) {
+		s.logger.Warnf("定时任务仍在执行，跳过本次: %s", j.name)
+		return
+	}
+	defer j.running.Store(false)
+
+	release, ok := s.lock(j, scheduledAt, manual)
+	if !ok {
+		return
+	}
+	defer release()
+
+	s.execute(j, scheduledAt)
+}
+
+// lock 获取计划时间锁及执行锁
+//
+// 计划时间锁不主动释放，避免各节点时钟存在偏差时同一计划被执行多次；
+// 执行锁在任务结束后释放，用于跨节点的 skip-if-still-running。
+func (s *Scheduler) lock(j *scheduledJob, scheduledAt time.Time, manual bool) (func(), bool) {
+	if s.locker == nil {
+		return func() {}, true
+	}
+
+	ctx := context.Background()
+	if !manual {
+		tickKey := fmt.Sprintf("tick:%s:%d", j.name, scheduledAt.Unix())
+		if _, ok, err := s.locker.Acquire(ctx, tickKey, j.config.Timeout); err != nil || !ok {
+			if err != nil {
+				s.logger.Errorf("定时任务加锁失败: %s, 错误: %v", j.name, err)
+			}
+			return nil, false
+		}
+	}
+
+	runningKey := "running:" + j.name
+	token, ok, err := s.locker.Acquire(ctx, runningKey, j.config.Timeout)
+	if err != nil {
+		s.logger.Errorf("定时任务加锁失败: %s, 错误: %v", j.name, err)
+		return nil, false
+	}
+	if !ok {
+		s.logger.Warnf("定时任务仍在其他节点执行，跳过本次: %s", j.name)
+		return nil, false
+	}
+
+	return func() {
+		if err := s.locker.Release(ctx, runningKey, token); err != nil {
+			s.logger.Errorf("定时任务释放锁失败: %s, 错误: %v", j.name, err)
+		}
+	}, true
+}
+
+// errJobTimeout 任务执行超时
+var errJobTimeout = errors.New("cron job timeout")
+
+// execute 执行任务并保存执行记录，失败时上报 Sentry
+func (s *Scheduler) execute(j *scheduledJob, scheduledAt time.Time) {
+	record := &RunRecord{
+		Name:        j.name,
+		Node:        s.node,
+		Status:      RunStatusSuccess,
+		ScheduledAt: scheduledAt,
+		StartedAt:   time.Now(),
+	}
+
+	ctx, cancel := context.WithTimeout(s.ctx, j.config.Timeout)
+	defer cancel()
+	err := s.invoke(ctx, j)
+
+	record.FinishedAt = time.Now()
+	record.Duration = record.FinishedAt.Sub(record.StartedAt)
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		// 不响应取消的任务在结束后才能判断超时
+		record.Status = RunStatusTimeout
+		if err == nil {
+			err = fmt.Errorf("%w after %v", errJobTimeout, j.config.Timeout)
+		} else {
+			err = fmt.Errorf("%w after %v: %v", errJobTimeout, j.config.Timeout, err)
+		}
+	case err == nil:
+		s.logger.Infof("定时任务执行成功: %s, 耗时: %v", j.name, record.Duration)
+	case s.ctx.Err() != nil:
+		record.Status = RunStatusCancelled
+	default:
+		record.Status = RunStatusFailed
+	}
+	if err != nil {
+		record.Error = err.Error()
+		if record.Status == RunStatusCancelled {
+			s.logger.Warnf("定时任务已取消: %s, 耗时: %v, 错误: %v", j.name, record.Duration, err)
+		} else {
+			s.logger.Errorf("定时任务执行失败: %s, 耗时: %v, 错误: %v", j.name, record.Duration, err)
+			s.report(record, err)
+		}
+	}
+
+	if s.history != nil {
+		if err := s.history.Save(context.Background(), record); err != nil {
+			s.logger.Errorf("保存定时任务执行记录失败: %s, 错误: %v", j.name, err)
+		}
+	}
+}
+
+// invoke 执行任务，panic 转换为错误以便记录及上报
+func (s *Scheduler) invoke(ctx context.Context, j *scheduledJob) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+		}
+	}()
+	return j.job.Run(ctx)
+}
+
+// report 上报任务失败到 Sentry，未初始化 Sentry 时不做任何事
+func (s *Scheduler) report(record *RunRecord, err error) {
+	hub := sentry.CurrentHub().Clone()
+	if hub.Client() == nil {
+		return
+	}
+	hub.WithScope(func(scope *sentry.Scope) {
+		scope.SetTag("cron.job", record.Name)
+		scope.SetTag("cron.status", record.Status)
+		scope.SetTag("cron.node", record.Node)
+		scope.SetContext("cron", sentry.Context{
+			"scheduled_at": record.ScheduledAt,
+			"duration":     record.Duration.String(),
+		})
+		hub.CaptureException(err)
+	})
+}
@@ -0,0 +1,149 @@
+package base
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countCron struct {
+	runs  atomic.Int32
+	sleep time.Duration
+}
+
+func (c *countCron) Spec() string { return "* * * * *" }
+
+func (c *countCron) Run() {
+	c.runs.Add(1)
+	time.Sleep(c.sleep)
+}
+
+type memoryLocker struct {
+	mu    sync.Mutex
+	locks map[string]string
+}
+
+func (l *memoryLocker) Acquire(_ context.Context, key string, _ time.Duration) (string, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.locks[key]; ok {
+		return "", false, nil
+	}
+	l.locks[key] = key
+	return key, true, nil
+}
+
+func (l *memoryLocker) Release(_ context.Context, key, token string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.locks[key] == token {
+		delete(l.locks, key)
+	}
+	return nil
+}
+
+type memoryHistory struct {
+	mu      sync.Mutex
+	records []*RunRecord
+}
+
+func (h *memoryHistory) Save(_ context.Context, record *RunRecord) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, record)
+	return nil
+}
+
+func TestSchedulerRunOncePerTick(t *testing.T) {
+	locker := &memoryLocker{locks: make(map[string]string)}
+	history := &memoryHistory{}
+	job := &countCron{sleep: 50 * time.Millisecond}
+
+	// 模拟两个副本同时触发同一计划
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		s := NewScheduler(WithLocker(locker), WithHistory(history))
+		assert.NoError(t, s.Register("count", FromCron(job), JobConfig{Enabled: true, Timeout: time.Second}))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.run(s.jobs["count"], false)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), job.runs.Load())
+	if assert.Len(t, history.records, 1) {
+		assert.Equal(t, RunStatusSuccess, history.records[0].Status)
+	}
+}
+
+type funcJob func(ctx context.Context) error
+
+func (f funcJob) Spec() string { return "* * * * *" }
+
+func (f funcJob) Run(ctx context.Context) error { return f(ctx) }
+
+func TestSchedulerTimeoutAndPanic(t *testing.T) {
+	history := &memoryHistory{}
+	ctx, cancel := context.WithCancel(context.Background())
+	s := NewScheduler(WithHistory(history), WithContext(ctx))
+
+	assert.NoError(t, s.Register("slow", funcJob(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}), JobConfig{Enabled: true, Timeout: 10 * time.Millisecond}))
+	assert.NoError(t, s.Register("legacy", FromCron(&countCron{sleep: 50 * time.Millisecond}), JobConfig{Enabled: true, Timeout: 10 * time.Millisecond}))
+	assert.NoError(t, s.Register("panic", funcJob(func(ctx context.Context) error {
+		panic("boom")
+	}), JobConfig{Enabled: true}))
+	assert.NoError(t, s.Register("shutdown", funcJob(func(ctx context.Context) error {
+		cancel()
+		<-ctx.Done()
+		return ctx.Err()
+	}), JobConfig{Enabled: true}))
+
+	for _, name := range []string{"slow", "legacy", "panic", "shutdown"} {
+		s.run(s.jobs[name], true)
+	}
+	if assert.Len(t, history.records, 4) {
+		assert.Equal(t, RunStatusTimeout, history.records[0].Status)
+		assert.Equal(t, RunStatusTimeout, history.records[1].Status)
+		assert.Equal(t, RunStatusFailed, history.records[2].Status)
+		assert.Contains(t, history.records[2].Error, "panic: boom")
+		assert.Equal(t, RunStatusCancelled, history.records[3].Status)
+	}
+}
+
+func TestSchedulerPauseResumeAndSpec(t *testing.T) {
+	s := NewScheduler()
+	ctx := context.Background()
+	assert.NoError(t, s.Register("count", FromCron(&countCron{}), JobConfig{Enabled: true}))
+	assert.NoError(t, s.Register("off", FromCron(&countCron{}), JobConfig{}))
+	s.Start()
+	defer s.Stop()
+
+	job, err := s.Job("count")
+	assert.NoError(t, err)
+	assert.NotNil(t, job.Next)
+
+	assert.NoError(t, s.Pause(ctx, "count"))
+	job, _ = s.Job("count")
+	assert.True(t, job.Paused)
+	assert.Nil(t, job.Next)
+
+	assert.NoError(t, s.Resume(ctx, "count"))
+	assert.NoError(t, s.UpdateSpec(ctx, "count", "@hourly"))
+	job, _ = s.Job("count")
+	assert.False(t, job.Paused)
+	assert.Equal(t, "@hourly", job.Spec)
+	assert.NotNil(t, job.Next)
+
+	assert.ErrorIs(t, s.UpdateSpec(ctx, "count", "bad spec"), ErrInvalidSpec)
+	assert.ErrorIs(t, s.Pause(ctx, "off"), ErrJobDisabled)
+	assert.ErrorIs(t, s.Pause(ctx, "missing"), ErrJobNotFound)
+}
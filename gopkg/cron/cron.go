@@ -1,20 +1,98 @@
 package cron
 
 import (
+	"fmt"
+
+	rxRedis "go-agent/gopkg/cache/redis"
 	rxCron "go-agent/gopkg/cron/base"
+	"go-agent/gopkg/graceful"
 
 	"github.com/spf13/viper"
 )
 
-func DoCron() error {
+// Config 定时任务配置
+type Config struct {
+	Switch  bool                        `mapstructure:"switch"`
+	Seconds bool                        `mapstructure:"seconds"` // 是否使用秒级 spec
+	Lock    LockConfig                  `mapstructure:"lock"`
+	Jobs    map[string]rxCron.JobConfig `mapstructure:"jobs"`
+}
+
+// LockConfig 分布式锁配置，client 为空时不加锁，只适用于单副本部署
+//
+// 同一 Redis 同时用于在各节点间同步暂停、恢复、修改 spec 及手动触发。
+type LockConfig struct {
+	Client string `mapstructure:"client"`
+	Prefix string `mapstructure:"prefix"`
+}
+
+const defaultLockPrefix = "go-agent:cron"
+
+// jobs 已实现的定时任务，需要在 cron.jobs 中配置并启用
+func jobs() map[string]rxCron.Job {
+	return map[string]rxCron.Job{
+		"table_status": NewTableStatus(),
+	}
+}
+
+var scheduler *rxCron.Scheduler
+
+// DoCron 根据 cron 配置注册并启动定时任务，history 为 nil 时不保存执行记录
+//
+// 调度器启动后会抢占执行锁并消费手动触发的队列，只能在 server、worker 等常驻进程中调用，
+// 不能放在所有命令共用的初始化中，否则短时运行的命令退出时会中断任务并丢失手动触发。
+func DoCron(history rxCron.HistoryStore) error {
 	if !viper.GetBool("cron.switch") {
 		return nil
 	}
 
-	tableStatusList := make([]rxCron.Cron, 0)
-	tableStatusList = append(tableStatusList, NewTableStatus())
-	if err := rxCron.InitFromMinute(tableStatusList); err != nil {
+	var cfg Config
+	if err := viper.UnmarshalKey("cron", &cfg); err != nil {
 		return err
 	}
+
+	// 任务 ctx 跟随进程优雅退出取消
+	opts := []rxCron.SchedulerOption{rxCron.WithContext(graceful.Context())}
+	if cfg.Seconds {
+		opts = append(opts, rxCron.WithSeconds())
+	}
+	if history != nil {
+		opts = append(opts, rxCron.WithHistory(history))
+	}
+	if cfg.Lock.Client != "" {
+		client, err := rxRedis.ClientAndErr(cfg.Lock.Client)
+		if err != nil {
+			return err
+		}
+		if cfg.Lock.Prefix == "" {
+			cfg.Lock.Prefix = defaultLockPrefix
+		}
+		opts = append(opts,
+			rxCron.WithLocker(rxCron.NewRedisLocker(client, cfg.Lock.Prefix)),
+			rxCron.WithControl(rxCron.NewRedisControlStore(client, cfg.Lock.Prefix)),
+		)
+	}
+
+	registered := jobs()
+	for name := range cfg.Jobs {
+		if _, ok := registered[name]; !ok {
+			return fmt.Errorf("unknown cron job: %s", name)
+		}
+	}
+
+	s := rxCron.NewScheduler(opts...)
+	for name, job := range registered {
+		if err := s.Register(name, job, cfg.Jobs[name]); err != nil {
+			return err
+		}
+	}
+	s.Start()
+	graceful.Start(s)
+	scheduler = s
 	return nil
 }
+
+// Scheduler 当前运行的调度器，未启用定时任务时为 nil
+func Scheduler() *rxCron.Scheduler {
+	return scheduler
+}
@@ -1,6 +1,8 @@
 package cron
 
 import (
+	"context"
+
 	rxCron "go-agent/gopkg/cron/base"
 	"go-agent/gopkg/log"
 )
@@ -8,7 +10,7 @@ import (
 type TableStatus struct {
 }
 
-func NewTableStatus() rxCron.Cron {
+func NewTableStatus() rxCron.Job {
 	return &TableStatus{}
 }
 
@@ -16,7 +18,8 @@ func (ts *TableStatus) Spec() string {
 	return "* * * * *"
 }
 
-func (ts *TableStatus) Run() {
+func (ts *TableStatus) Run(ctx context.Context) error {
 	log.Sugar().Info("每分钟执行任务")
 	// 执行处理业务逻辑
+	return nil
 }
@@ -40,3 +40,8 @@ func Wait() {
 	cancel()
 	wg.Wait()
 }
+
+// Context 收到退出信号时取消的 ctx，用于让后台任务跟随进程优雅退出
+func Context() context.Context {
+	return ctx
+}
@@ -7,6 +7,9 @@ import (
 const (
 	RequestIDKey = "x-request-id"
 	ClientIPKey  = "client-ip"
+	UserIDKey    = "user-id"    // 认证后的用户ID，API Key 认证时为 key 的所属用户
+	TeamIDKey    = "team-id"    // 用户或 API Key 所属的团队ID
+	APIKeyIDKey  = "api-key-id" // API Key 认证后的 key ID
 )
 
 func GetRequestID(ctx context.Context) string {
@@ -25,6 +28,30 @@ func GetClientIP(ctx context.Context) string {
 	return GetString(ctx, ClientIPKey)
 }
 
+func SetUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, UserIDKey, userID)
+}
+
+func GetUserID(ctx context.Context) string {
+	return GetString(ctx, UserIDKey)
+}
+
+func SetTeamID(ctx context.Context, teamID string) context.Context {
+	return context.WithValue(ctx, TeamIDKey, teamID)
+}
+
+func GetTeamID(ctx context.Context) string {
+	return GetString(ctx, TeamIDKey)
+}
+
+func SetAPIKeyID(ctx context.Context, keyID string) context.Context {
+	return context.WithValue(ctx, APIKeyIDKey, keyID)
+}
+
+func GetAPIKeyID(ctx context.Context) string {
+	return GetString(ctx, APIKeyIDKey)
+}
+
 func GetString(ctx context.Context, key string) string {
 	valueAny := ctx.Value(key)
 	if valueAny == nil {
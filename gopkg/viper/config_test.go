@@ -0,0 +1,19 @@
+package viper
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_ConfigFile 仓库中的 config/config.yml 需能被 viper 解析，否则服务无法启动
+func Test_ConfigFile(t *testing.T) {
+	v := viper.New()
+	v.SetConfigFile("../../config/config.yml")
+	assert.NoError(t, v.ReadInConfig())
+
+	assert.False(t, v.GetBool("minio.use_ssl"))
+	assert.NotEmpty(t, v.GetString("auth.jwt.issuer"))
+	assert.NotEmpty(t, v.GetStringMap("cache"))
+}
@@ -0,0 +1,40 @@
+package admin
+
+import (
+	"go-agent/gopkg/gins"
+	"go-agent/handler/middleware"
+	"go-agent/internal/service"
+	"go-agent/internal/service/quota"
+	"go-agent/internal/service/rbac"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	g            *gin.RouterGroup
+	rbacService  service.RBAC
+	quotaService service.Quota
+}
+
+func NewHandler(g *gin.RouterGroup) gins.Handler {
+	return &Handler{
+		g:            g,
+		rbacService:  rbac.NewService(),
+		quotaService: quota.NewService(),
+	}
+}
+
+func (h *Handler) RegisterRoutes() {
+	g := h.g.Group("/admin")
+	roles := middleware.RequirePermission(rbac.PermissionAdminRoles)
+	g.GET("/roles", roles, h.ListRoles)
+	g.GET("/users/:user_id/roles", roles, h.UserRoles)
+	g.POST("/users/:user_id/roles", roles, h.AssignRole)
+	g.DELETE("/users/:user_id/roles/:role", roles, h.UnassignRole)
+
+	// token 额度，修改后立即生效
+	quotas := middleware.RequirePermission(rbac.PermissionAdminQuotas)
+	g.GET("/quotas/:subject_type/:subject_id", quotas, h.ListQuotas)
+	g.PUT("/quotas/:subject_type/:subject_id", quotas, h.SaveQuota)
+	g.DELETE("/quotas/:subject_type/:subject_id/:period", quotas, h.DeleteQuota)
+}
@@ -0,0 +1,64 @@
+package admin
+
+import (
+	"go-agent/gopkg/gins"
+	"go-agent/handler/api/admin/request"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) ListQuotas(ctx *gin.Context) {
+	var req request.QuotaSubjectRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		gins.BadRequest(ctx, err)
+		return
+	}
+
+	res, err := h.quotaService.List(ctx, req.SubjectType, req.SubjectID)
+	if err != nil {
+		gins.ServerError(ctx, err)
+		return
+	}
+
+	gins.StatusOK(ctx, res)
+}
+
+func (h *Handler) SaveQuota(ctx *gin.Context) {
+	var uri request.QuotaSubjectRequest
+	var req request.SaveQuotaRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		gins.BadRequest(ctx, err)
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		gins.BadRequest(ctx, err)
+		return
+	}
+
+	res, err := h.quotaService.Save(ctx, uri.SubjectType, uri.SubjectID, req.Period, *req.TokenLimit)
+	if err != nil {
+		gins.ServerError(ctx, err)
+		return
+	}
+
+	gins.StatusOK(ctx, res)
+}
+
+func (h *Handler) DeleteQuota(ctx *gin.Context) {
+	var req request.DeleteQuotaRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		gins.BadRequest(ctx, err)
+		return
+	}
+
+	res, err := h.quotaService.Delete(ctx, req.SubjectType, req.SubjectID, req.Period)
+	if err != nil {
+		gins.ServerError(ctx, err)
+		return
+	}
+
+	gins.StatusOK(ctx, res)
+}
@@ -0,0 +1,74 @@
+package admin
+
+import (
+	"go-agent/gopkg/gins"
+	"go-agent/handler/api/admin/request"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) ListRoles(ctx *gin.Context) {
+	res, err := h.rbacService.ListRoles(ctx)
+	if err != nil {
+		gins.ServerError(ctx, err)
+		return
+	}
+
+	gins.StatusOK(ctx, res)
+}
+
+func (h *Handler) UserRoles(ctx *gin.Context) {
+	var req request.UserIDRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		gins.BadRequest(ctx, err)
+		return
+	}
+
+	res, err := h.rbacService.UserRoles(ctx, req.UserID)
+	if err != nil {
+		gins.ServerError(ctx, err)
+		return
+	}
+
+	gins.StatusOK(ctx, res)
+}
+
+func (h *Handler) AssignRole(ctx *gin.Context) {
+	var uri request.UserIDRequest
+	var req request.AssignRoleRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		gins.BadRequest(ctx, err)
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		gins.BadRequest(ctx, err)
+		return
+	}
+
+	res, err := h.rbacService.AssignRole(ctx, uri.UserID, req.Role)
+	if err != nil {
+		gins.ServerError(ctx, err)
+		return
+	}
+
+	gins.StatusOK(ctx, res)
+}
+
+func (h *Handler) UnassignRole(ctx *gin.Context) {
+	var req request.UnassignRoleRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		gins.BadRequest(ctx, err)
+		return
+	}
+
+	res, err := h.rbacService.UnassignRole(ctx, req.UserID, req.Role)
+	if err != nil {
+		gins.ServerError(ctx, err)
+		return
+	}
+
+	gins.StatusOK(ctx, res)
+}
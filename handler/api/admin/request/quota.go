@@ -0,0 +1,17 @@
+package request
+
+type QuotaSubjectRequest struct {
+	SubjectType string `uri:"subject_type" binding:"required"`
+	SubjectID   string `uri:"subject_id" binding:"required"`
+}
+
+type SaveQuotaRequest struct {
+	Period     string `json:"period" binding:"required"`
+	TokenLimit *int64 `json:"token_limit" binding:"required"`
+}
+
+type DeleteQuotaRequest struct {
+	SubjectType string `uri:"subject_type" binding:"required"`
+	SubjectID   string `uri:"subject_id" binding:"required"`
+	Period      string `uri:"period" binding:"required"`
+}
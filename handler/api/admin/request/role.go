@@ -0,0 +1,14 @@
+package request
+
+type UserIDRequest struct {
+	UserID string `uri:"user_id" binding:"required"`
+}
+
+type AssignRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type UnassignRoleRequest struct {
+	UserID string `uri:"user_id" binding:"required"`
+	Role   string `uri:"role" binding:"required"`
+}
@@ -5,6 +5,8 @@ import (
 	"go-agent/gopkg/gins"
 	"go-agent/handler/middleware"
 	"go-agent/internal/agent"
+	"go-agent/internal/agent/quota"
+	"go-agent/internal/service/rbac"
 	"io"
 	"os"
 
@@ -23,8 +25,8 @@ func NewHandler(g *gin.RouterGroup) gins.Handler {
 
 func (h *Handler) RegisterRoutes() {
 	g := h.g.Group("/agent")
-	// 支持 POST 请求，使用 EventStreamHeadersMiddleware 中间件设置 SSE 头
-	g.POST("/chat", middleware.EventStreamHeadersMiddleware(), h.Chat)
+	// 支持 POST 请求，需 agent:chat 权限，使用 EventStreamHeadersMiddleware 中间件设置 SSE 头
+	g.POST("/chat", middleware.RequirePermission(rbac.PermissionAgentChat), middleware.EventStreamHeadersMiddleware(), h.Chat)
 }
 
 // ChatRequest 请求结构
@@ -62,9 +64,12 @@ func (h *Handler) Chat(c *gin.Context) {
 		return
 	}
 
-	// 调用流式接口
-	stream, err := ag.StreamHandle(c.Request.Context(), req.Prompt)
+	// 调用流式接口，开启 quota 时按用户、团队及 API Key 检查 token 额度
+	stream, err := agent.WithQuota(ag, quota.Default()).StreamHandle(quota.WithSubjects(c), req.Prompt)
 	if err != nil {
+		if quota.Abort(c, err) {
+			return
+		}
 		gins.ServerError(c, fmt.Errorf("failed to start stream: %v", err))
 		return
 	}
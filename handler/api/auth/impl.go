@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"go-agent/gopkg/gins"
+	"go-agent/handler/middleware"
+	"go-agent/internal/service"
+	"go-agent/internal/service/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	g           *gin.RouterGroup
+	authService service.Auth
+}
+
+func NewHandler(g *gin.RouterGroup) gins.Handler {
+	return &Handler{
+		g:           g,
+		authService: auth.NewService(),
+	}
+}
+
+func (h *Handler) RegisterRoutes() {
+	g := h.g.Group("/auth")
+	g.POST("/login", h.Login)
+	g.POST("/refresh", h.Refresh)
+	g.POST("/logout", middleware.RequireAuth(), h.Logout)
+}
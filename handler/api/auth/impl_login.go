@@ -0,0 +1,25 @@
+package auth
+
+import (
+	"go-agent/gopkg/gins"
+	"go-agent/handler/api/auth/request"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) Login(ctx *gin.Context) {
+	var req request.LoginRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		gins.BadRequest(ctx, err)
+		return
+	}
+
+	res, err := h.authService.Login(ctx, req.Username, req.Password)
+	if err != nil {
+		gins.ServerError(ctx, err)
+		return
+	}
+
+	gins.StatusOK(ctx, res)
+}
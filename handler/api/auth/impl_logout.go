@@ -0,0 +1,18 @@
+package auth
+
+import (
+	"go-agent/gopkg/gins"
+	"go-agent/handler/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) Logout(ctx *gin.Context) {
+	res, err := h.authService.Logout(ctx, middleware.Claims(ctx))
+	if err != nil {
+		gins.ServerError(ctx, err)
+		return
+	}
+
+	gins.StatusOK(ctx, res)
+}
@@ -0,0 +1,25 @@
+package auth
+
+import (
+	"go-agent/gopkg/gins"
+	"go-agent/handler/api/auth/request"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) Refresh(ctx *gin.Context) {
+	var req request.RefreshRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		gins.BadRequest(ctx, err)
+		return
+	}
+
+	res, err := h.authService.Refresh(ctx, req.RefreshToken)
+	if err != nil {
+		gins.ServerError(ctx, err)
+		return
+	}
+
+	gins.StatusOK(ctx, res)
+}
@@ -0,0 +1,34 @@
+package cron
+
+import (
+	"go-agent/gopkg/gins"
+	"go-agent/handler/middleware"
+	"go-agent/internal/service"
+	"go-agent/internal/service/cron"
+	"go-agent/internal/service/rbac"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	g           *gin.RouterGroup
+	cronService service.Cron
+}
+
+func NewHandler(g *gin.RouterGroup) gins.Handler {
+	return &Handler{
+		g:           g,
+		cronService: cron.NewService(),
+	}
+}
+
+func (h *Handler) RegisterRoutes() {
+	g := h.g.Group("/crons")
+	read := middleware.RequirePermission(rbac.PermissionCronRead)
+	write := middleware.RequirePermission(rbac.PermissionCronWrite)
+	g.GET("", read, h.List)
+	g.POST("/:name/run", write, h.Run)
+	g.POST("/:name/pause", write, h.Pause)
+	g.POST("/:name/resume", write, h.Resume)
+	g.PUT("/:name/spec", write, h.UpdateSpec)
+}
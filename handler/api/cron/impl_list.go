@@ -0,0 +1,17 @@
+package cron
+
+import (
+	"go-agent/gopkg/gins"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) List(ctx *gin.Context) {
+	res, err := h.cronService.List(ctx)
+	if err != nil {
+		gins.ServerError(ctx, err)
+		return
+	}
+
+	gins.StatusOK(ctx, res)
+}
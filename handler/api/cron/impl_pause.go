@@ -0,0 +1,42 @@
+package cron
+
+import (
+	"go-agent/gopkg/gins"
+	"go-agent/handler/api/cron/request"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) Pause(ctx *gin.Context) {
+	var req request.JobNameRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		gins.BadRequest(ctx, err)
+		return
+	}
+
+	res, err := h.cronService.Pause(ctx, req.Name)
+	if err != nil {
+		gins.ServerError(ctx, err)
+		return
+	}
+
+	gins.StatusOK(ctx, res)
+}
+
+func (h *Handler) Resume(ctx *gin.Context) {
+	var req request.JobNameRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		gins.BadRequest(ctx, err)
+		return
+	}
+
+	res, err := h.cronService.Resume(ctx, req.Name)
+	if err != nil {
+		gins.ServerError(ctx, err)
+		return
+	}
+
+	gins.StatusOK(ctx, res)
+}
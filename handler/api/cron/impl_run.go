@@ -0,0 +1,25 @@
+package cron
+
+import (
+	"go-agent/gopkg/gins"
+	"go-agent/handler/api/cron/request"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) Run(ctx *gin.Context) {
+	var req request.JobNameRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		gins.BadRequest(ctx, err)
+		return
+	}
+
+	res, err := h.cronService.Run(ctx, req.Name)
+	if err != nil {
+		gins.ServerError(ctx, err)
+		return
+	}
+
+	gins.StatusOK(ctx, res)
+}
@@ -0,0 +1,29 @@
+package cron
+
+import (
+	"go-agent/gopkg/gins"
+	"go-agent/handler/api/cron/request"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) UpdateSpec(ctx *gin.Context) {
+	var req request.UpdateSpecRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		gins.BadRequest(ctx, err)
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		gins.BadRequest(ctx, err)
+		return
+	}
+
+	res, err := h.cronService.UpdateSpec(ctx, req.Name, req.Spec)
+	if err != nil {
+		gins.ServerError(ctx, err)
+		return
+	}
+
+	gins.StatusOK(ctx, res)
+}
@@ -0,0 +1,10 @@
+package request
+
+type JobNameRequest struct {
+	Name string `uri:"name" binding:"required"`
+}
+
+type UpdateSpecRequest struct {
+	Name string `uri:"name" binding:"required"`
+	Spec string `json:"spec"` // 为空时恢复为配置中的 spec
+}
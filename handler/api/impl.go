@@ -2,8 +2,12 @@ package api
 
 import (
 	"go-agent/gopkg/gins"
+	"go-agent/handler/api/admin"
 	"go-agent/handler/api/agent"
+	"go-agent/handler/api/auth"
 	"go-agent/handler/api/chinese"
+	"go-agent/handler/api/cron"
+	"go-agent/handler/api/task"
 	"go-agent/handler/middleware"
 
 	"github.com/gin-contrib/cors"
@@ -22,14 +26,22 @@ func NewHandler(engine *gin.Engine) gins.Handler {
 
 func (h *Handler) RegisterRoutes() {
 	config := cors.DefaultConfig()
-	config.AllowHeaders = append([]string{}, config.AllowHeaders...)
+	// 认证头及限流响应头，浏览器跨域请求的预检及读取响应头需要显式声明
+	config.AllowHeaders = append(config.AllowHeaders, "Authorization", "X-API-Key")
+	config.ExposeHeaders = append(config.ExposeHeaders,
+		"RateLimit-Policy", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After")
 	config.AllowAllOrigins = true
 	h.engine.Use(cors.New(config))
 
-	g := h.engine.Group("/api", middleware.RequestCapture())
+	// 按 IP 限流在认证之前，认证失败的请求也会计数，防止暴力尝试 token 及 API Key
+	g := h.engine.Group("/api", middleware.RequestCapture(), middleware.RateLimitByIP(), middleware.Authenticate(), middleware.RateLimit())
 	handlers := []gins.Handler{
+		auth.NewHandler(g),
 		chinese.NewHandler(g),
 		agent.NewHandler(g),
+		task.NewHandler(g),
+		cron.NewHandler(g),
+		admin.NewHandler(g),
 	}
 
 	for _, handler := range handlers {
@@ -0,0 +1,38 @@
+package task
+
+import (
+	"go-agent/gopkg/gins"
+	"go-agent/handler/middleware"
+	"go-agent/internal/service"
+	"go-agent/internal/service/rbac"
+	"go-agent/internal/service/task"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	g           *gin.RouterGroup
+	taskService service.Task
+	rbacService service.RBAC
+}
+
+func NewHandler(g *gin.RouterGroup) gins.Handler {
+	return &Handler{
+		g:           g,
+		taskService: task.NewService(),
+		rbacService: rbac.NewService(),
+	}
+}
+
+func (h *Handler) RegisterRoutes() {
+	g := h.g.Group("/tasks")
+	read := middleware.RequirePermission(rbac.PermissionTaskRead)
+	write := middleware.RequirePermission(rbac.PermissionTaskWrite)
+	g.GET("/:id", read, h.Detail)
+	g.DELETE("/:id", write, h.Cancel)
+	g.GET("/dead-letters", read, h.DeadLetterList)
+	g.POST("/dead-letters/:id/requeue", write, h.DeadLetterRequeue)
+	g.DELETE("/dead-letters/:id", write, h.DeadLetterDelete)
+	g.DELETE("/dead-letters", write, h.DeadLetterPurge)
+	g.GET("/workflows/:id", read, h.WorkflowDetail)
+}
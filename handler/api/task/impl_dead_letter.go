@@ -0,0 +1,69 @@
+package task
+
+import (
+	"go-agent/gopkg/gins"
+	"go-agent/handler/api/task/request"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) DeadLetterList(ctx *gin.Context) {
+	var req request.DeadLetterListRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		gins.BadRequest(ctx, err)
+		return
+	}
+
+	res, err := h.taskService.DeadLetterList(ctx, req.Page)
+	if err != nil {
+		gins.ServerError(ctx, err)
+		return
+	}
+
+	gins.StatusOK(ctx, res)
+}
+
+func (h *Handler) DeadLetterRequeue(ctx *gin.Context) {
+	var req request.DeadLetterIDRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		gins.BadRequest(ctx, err)
+		return
+	}
+
+	res, err := h.taskService.DeadLetterRequeue(ctx, req.ID)
+	if err != nil {
+		gins.ServerError(ctx, err)
+		return
+	}
+
+	gins.StatusOK(ctx, res)
+}
+
+func (h *Handler) DeadLetterDelete(ctx *gin.Context) {
+	var req request.DeadLetterIDRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		gins.BadRequest(ctx, err)
+		return
+	}
+
+	res, err := h.taskService.DeadLetterDelete(ctx, req.ID)
+	if err != nil {
+		gins.ServerError(ctx, err)
+		return
+	}
+
+	gins.StatusOK(ctx, res)
+}
+
+func (h *Handler) DeadLetterPurge(ctx *gin.Context) {
+	res, err := h.taskService.DeadLetterPurge(ctx)
+	if err != nil {
+		gins.ServerError(ctx, err)
+		return
+	}
+
+	gins.StatusOK(ctx, res)
+}
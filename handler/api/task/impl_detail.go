@@ -0,0 +1,64 @@
+package task
+
+import (
+	"go-agent/gopkg/gins"
+	"go-agent/gopkg/utils"
+	"go-agent/handler/api/task/request"
+	"go-agent/handler/middleware"
+	"go-agent/internal/service/rbac"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) Detail(ctx *gin.Context) {
+	var req request.TaskIDRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		gins.BadRequest(ctx, err)
+		return
+	}
+	owner, err := h.owner(ctx)
+	if err != nil {
+		gins.ServerError(ctx, err)
+		return
+	}
+
+	res, err := h.taskService.Detail(ctx, req.ID, owner)
+	if err != nil {
+		gins.ServerError(ctx, err)
+		return
+	}
+
+	gins.StatusOK(ctx, res)
+}
+
+func (h *Handler) Cancel(ctx *gin.Context) {
+	var req request.TaskIDRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		gins.BadRequest(ctx, err)
+		return
+	}
+	owner, err := h.owner(ctx)
+	if err != nil {
+		gins.ServerError(ctx, err)
+		return
+	}
+
+	res, err := h.taskService.Cancel(ctx, req.ID, owner)
+	if err != nil {
+		gins.ServerError(ctx, err)
+		return
+	}
+
+	gins.StatusOK(ctx, res)
+}
+
+// owner 拥有 task:admin 权限时返回空，可操作全部任务，否则只能操作自己提交的任务
+func (h *Handler) owner(ctx *gin.Context) (string, error) {
+	all, err := middleware.HasPermission(ctx, h.rbacService, rbac.PermissionTaskAdmin)
+	if err != nil || all {
+		return "", err
+	}
+	return ctx.GetString(utils.UserIDKey), nil
+}
@@ -0,0 +1,25 @@
+package task
+
+import (
+	"go-agent/gopkg/gins"
+	"go-agent/handler/api/task/request"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) WorkflowDetail(ctx *gin.Context) {
+	var req request.WorkflowIDRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		gins.BadRequest(ctx, err)
+		return
+	}
+
+	res, err := h.taskService.WorkflowDetail(ctx, req.ID)
+	if err != nil {
+		gins.ServerError(ctx, err)
+		return
+	}
+
+	gins.StatusOK(ctx, res)
+}
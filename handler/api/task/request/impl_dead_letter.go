@@ -0,0 +1,11 @@
+package request
+
+import "go-agent/gopkg/paging"
+
+type DeadLetterListRequest struct {
+	paging.Page
+}
+
+type DeadLetterIDRequest struct {
+	ID string `uri:"id" binding:"required"`
+}
@@ -0,0 +1,5 @@
+package request
+
+type TaskIDRequest struct {
+	ID string `uri:"id" binding:"required"`
+}
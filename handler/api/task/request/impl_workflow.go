@@ -0,0 +1,5 @@
+package request
+
+type WorkflowIDRequest struct {
+	ID string `uri:"id" binding:"required"`
+}
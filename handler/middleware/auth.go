@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"go-agent/gopkg/auth"
+	"go-agent/gopkg/gins"
+	"go-agent/gopkg/services"
+	"go-agent/gopkg/utils"
+	"go-agent/internal/model"
+	"go-agent/internal/service/apikey"
+	authService "go-agent/internal/service/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	ClaimsKey = "auth-claims"  // 认证后的 *auth.Claims 在 gin.Context 中的键
+	APIKeyKey = "auth-api-key" // API Key 认证后的 *model.SApiKey 在 gin.Context 中的键
+)
+
+// Authenticate 解析 Authorization: Bearer 中的 access token 或 API Key，将用户ID及团队ID写入上下文
+//
+// API Key 通过 X-API-Key 或以 sk- 开头的 Bearer 传递，认证后同时写入 key ID，用户ID为 key 的所属用户。
+// 未携带凭证时放行，由 RequireAuth 决定是否需要登录；携带的凭证无效、过期或已吊销时返回 401。
+// 需在 RateLimit 之前使用，以便按用户限流。
+func Authenticate() gin.HandlerFunc {
+	keys := apikey.NewService()
+	return func(c *gin.Context) {
+		token := bearerToken(c)
+		if key := c.GetHeader("X-API-Key"); key != "" || auth.IsAPIKey(token) {
+			if key == "" {
+				key = token
+			}
+			authenticateAPIKey(c, keys, key)
+			return
+		}
+
+		manager := auth.Default()
+		if token == "" || manager == nil {
+			c.Next()
+			return
+		}
+
+		claims, err := manager.ParseAccessToken(c, token)
+		if err != nil {
+			abortUnauthorized(c, authService.TokenError(err))
+			return
+		}
+		c.Set(ClaimsKey, claims)
+		c.Set(utils.UserIDKey, claims.UserID)
+		if claims.TeamID != "" {
+			c.Set(utils.TeamIDKey, claims.TeamID)
+		}
+		c.Next()
+	}
+}
+
+func authenticateAPIKey(c *gin.Context, keys *apikey.Service, key string) {
+	k, err := keys.Authenticate(c, key)
+	if err != nil {
+		abortUnauthorized(c, err)
+		return
+	}
+	c.Set(APIKeyKey, k)
+	c.Set(utils.APIKeyIDKey, k.KeyId)
+	c.Set(utils.UserIDKey, k.OwnerId)
+	if k.TeamId != "" {
+		c.Set(utils.TeamIDKey, k.TeamId)
+	}
+	c.Next()
+}
+
+// RequireAuth 要求请求已通过 Authenticate 认证，否则返回 401
+func RequireAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString(utils.UserIDKey) == "" {
+			abortUnauthorized(c, authService.ErrUnauthorized)
+			return
+		}
+		c.Next()
+	}
+}
+
+// Claims 获取 Authenticate 写入的 token 声明，未认证时返回 nil
+func Claims(c *gin.Context) *auth.Claims {
+	if v, ok := c.Get(ClaimsKey); ok {
+		if claims, ok := v.(*auth.Claims); ok {
+			return claims
+		}
+	}
+	return nil
+}
+
+// APIKey 获取 Authenticate 写入的 API Key，未使用 API Key 认证时返回 nil
+func APIKey(c *gin.Context) *model.SApiKey {
+	if v, ok := c.Get(APIKeyKey); ok {
+		if key, ok := v.(*model.SApiKey); ok {
+			return key
+		}
+	}
+	return nil
+}
+
+func bearerToken(c *gin.Context) string {
+	header := c.GetHeader("Authorization")
+	if len(header) > 7 && strings.EqualFold(header[:7], "Bearer ") {
+		return strings.TrimSpace(header[7:])
+	}
+	return ""
+}
+
+// abortUnauthorized 返回 401，业务错误码见 internal/service/auth 及 internal/service/apikey；其他错误（如吊销列表不可用）返回 500
+func abortUnauthorized(c *gin.Context, err error) {
+	codeErr, ok := err.(services.Error)
+	if !ok {
+		gins.ServerError(c, err)
+		return
+	}
+	c.Header("WWW-Authenticate", `Bearer realm="go-agent"`)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, services.NewResult(c, codeErr.GetCode(), codeErr.Error(), nil))
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+
+	"go-agent/gopkg/auth"
+	"go-agent/gopkg/gins"
+	"go-agent/gopkg/services"
+	"go-agent/gopkg/utils"
+	"go-agent/internal/service"
+	"go-agent/internal/service/apikey"
+	authService "go-agent/internal/service/auth"
+	"go-agent/internal/service/rbac"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission 要求已认证的用户拥有全部 permissions，未认证时返回 401，权限不足时返回 403
+//
+// 用户权限来自角色，未分配角色时使用 rbac.default_permissions，见 internal/service/rbac；使用 API Key 认证时 key 的授权范围也需包含 permissions。
+func RequirePermission(permissions ...string) gin.HandlerFunc {
+	return RequirePermissionWith(rbac.NewService(), permissions...)
+}
+
+// RequirePermissionWith 使用指定的 rbac 服务校验权限，用于测试
+func RequirePermissionWith(roles service.RBAC, permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetString(utils.UserIDKey)
+		if userID == "" {
+			abortUnauthorized(c, authService.ErrUnauthorized)
+			return
+		}
+
+		granted, err := roles.Permissions(c, userID)
+		if err != nil {
+			gins.ServerError(c, err)
+			return
+		}
+		for _, permission := range permissions {
+			if err := checkPermission(c, granted, permission); err != nil {
+				abortForbidden(c, err)
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// HasPermission 已认证的用户是否拥有 permission，用于按权限决定数据范围的接口，如查看全部用户的任务
+func HasPermission(c *gin.Context, roles service.RBAC, permission string) (bool, error) {
+	userID := c.GetString(utils.UserIDKey)
+	if userID == "" {
+		return false, nil
+	}
+	granted, err := roles.Permissions(c, userID)
+	if err != nil {
+		return false, err
+	}
+	return checkPermission(c, granted, permission) == nil, nil
+}
+
+// checkPermission 校验用户权限，使用 API Key 认证时 key 的授权范围也需包含 permission
+func checkPermission(c *gin.Context, granted []string, permission string) *services.BaseError {
+	if key := APIKey(c); key != nil && !apikey.HasScope(key, permission) {
+		return apikey.ErrScopeForbidden
+	}
+	if !auth.HasPermission(granted, permission) {
+		return rbac.ErrPermissionDenied
+	}
+	return nil
+}
+
+func abortForbidden(c *gin.Context, err *services.BaseError) {
+	c.AbortWithStatusJSON(http.StatusForbidden, services.NewResult(c, err.GetCode(), err.Error(), nil))
+}
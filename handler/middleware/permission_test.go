@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-agent/gopkg/utils"
+	"go-agent/internal/dao"
+	"go-agent/internal/model"
+	"go-agent/internal/service"
+	"go-agent/internal/service/rbac"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubRBAC struct {
+	service.RBAC
+	permissions map[string][]string
+}
+
+func (s *stubRBAC) Permissions(_ context.Context, userID string) ([]string, error) {
+	return s.permissions[userID], nil
+}
+
+type memoryRoles struct {
+	dao.Role
+	userRoles   map[string][]string
+	permissions map[string][]string
+}
+
+func (m *memoryRoles) UserRoles(_ context.Context, userID string) ([]string, error) {
+	return m.userRoles[userID], nil
+}
+
+func (m *memoryRoles) Permissions(_ context.Context, roleNames ...string) ([]*model.SRolePermission, error) {
+	var rows []*model.SRolePermission
+	for _, name := range roleNames {
+		for _, p := range m.permissions[name] {
+			rows = append(rows, &model.SRolePermission{RoleName: name, Permission: p})
+		}
+	}
+	return rows, nil
+}
+
+func serveChat(roles service.RBAC, userID string, key *model.SApiKey) int {
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Set(utils.UserIDKey, userID)
+		if key != nil {
+			c.Set(APIKeyKey, key)
+		}
+	})
+	engine.POST("/api/agent/chat", RequirePermissionWith(roles, rbac.PermissionAgentChat), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/agent/chat", nil))
+	return w.Code
+}
+
+func TestRequirePermission_UserWithoutRoles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	roles := &memoryRoles{
+		userRoles:   map[string][]string{"operator": {"operator"}},
+		permissions: map[string][]string{"operator": {"cron:read"}},
+	}
+	s := rbac.NewServiceWith(roles, nil, []string{rbac.PermissionAgentChat})
+
+	// 引入角色前的已有用户没有角色，使用默认权限
+	assert.Equal(t, http.StatusOK, serveChat(s, "legacy", nil))
+	// 仍然校验 API Key 的授权范围
+	assert.Equal(t, http.StatusOK, serveChat(s, "legacy", &model.SApiKey{Scopes: "agent:chat"}))
+	assert.Equal(t, http.StatusForbidden, serveChat(s, "legacy", &model.SApiKey{Scopes: "cron:read"}))
+	// 已分配角色的用户只拥有角色的权限
+	assert.Equal(t, http.StatusForbidden, serveChat(s, "operator", nil))
+	// 默认权限为空时必须分配角色
+	assert.Equal(t, http.StatusForbidden, serveChat(rbac.NewServiceWith(roles, nil, nil), "legacy", nil))
+}
+
+func TestRequirePermissionWith(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	roles := &stubRBAC{permissions: map[string][]string{
+		"admin":    {"admin:*", "agent:chat"},
+		"operator": {"cron:read"},
+	}}
+
+	serve := func(userID string, key *model.SApiKey) int {
+		engine := gin.New()
+		engine.Use(func(c *gin.Context) {
+			if userID != "" {
+				c.Set(utils.UserIDKey, userID)
+			}
+			if key != nil {
+				c.Set(APIKeyKey, key)
+			}
+		})
+		engine.GET("/admin/roles", RequirePermissionWith(roles, "admin:roles"), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/roles", nil))
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, serve("", nil))
+	assert.Equal(t, http.StatusOK, serve("admin", nil))
+	assert.Equal(t, http.StatusForbidden, serve("operator", nil))
+	// API Key 的授权范围同样需要包含权限
+	assert.Equal(t, http.StatusForbidden, serve("admin", &model.SApiKey{Scopes: "agent:chat"}))
+	assert.Equal(t, http.StatusOK, serve("admin", &model.SApiKey{Scopes: "agent:chat,admin:*"}))
+}
@@ -0,0 +1,206 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	rxRedis "go-agent/gopkg/cache/redis"
+	"go-agent/gopkg/log"
+	"go-agent/gopkg/services"
+	"go-agent/gopkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// ErrRateLimited 请求超过限流规则
+var ErrRateLimited = services.NewError(10201, "请求过于频繁，请稍后重试")
+
+// RateLimitConfig 限流配置，对应 rate_limit
+type RateLimitConfig struct {
+	Switch  bool            `json:"switch" mapstructure:"switch"`
+	Client  string          `json:"client" mapstructure:"client"`   // redis 客户端名称
+	Prefix  string          `json:"prefix" mapstructure:"prefix"`   // redis 键前缀，默认 go-agent:ratelimit
+	Default RateLimitRule   `json:"default" mapstructure:"default"` // 未匹配路由规则时使用，rate 为 0 表示不限流
+	IP      RateLimitRule   `json:"ip" mapstructure:"ip"`           // 认证前按客户端 IP 限流，防止暴力尝试 token 及 API Key，rate 为 0 表示不限流
+	Routes  []RateLimitRule `json:"routes" mapstructure:"routes"`   // 按路由配置的规则，按顺序匹配第一条
+}
+
+// RateLimitRule 限流规则
+type RateLimitRule struct {
+	Name      string        `json:"name" mapstructure:"name"`           // 规则名称，用于区分计数，默认为 method+path
+	Method    string        `json:"method" mapstructure:"method"`       // 为空匹配所有方法
+	Path      string        `json:"path" mapstructure:"path"`           // gin 路由路径，如 /api/agent/chat、/api/task/:id
+	Algorithm string        `json:"algorithm" mapstructure:"algorithm"` // sliding_window, token_bucket
+	Rate      int           `json:"rate" mapstructure:"rate"`           // 窗口内允许的请求数，令牌桶为每个窗口补充的令牌数
+	Window    time.Duration `json:"window" mapstructure:"window"`
+	Burst     int           `json:"burst" mapstructure:"burst"` // 令牌桶容量，默认等于 rate
+}
+
+func (r RateLimitRule) match(method, path string) bool {
+	return (r.Method == "" || strings.EqualFold(r.Method, method)) && (r.Path == "" || r.Path == path)
+}
+
+func (r RateLimitRule) limit() rxRedis.Limit {
+	return rxRedis.Limit{
+		Algorithm: r.Algorithm,
+		Rate:      r.Rate,
+		Window:    r.Window,
+		Burst:     r.Burst,
+	}
+}
+
+// policy RateLimit-Policy 头，如 100;w=60
+func (r RateLimitRule) policy() string {
+	return fmt.Sprintf("%d;w=%d", r.Rate, ceilSeconds(r.Window))
+}
+
+// RateLimiter 限流器，由 rxRedis.Limiter 实现
+type RateLimiter interface {
+	Allow(ctx context.Context, key string, limit rxRedis.Limit) (*rxRedis.LimitResult, error)
+}
+
+var (
+	rateLimiter     RateLimiter
+	rateLimitConfig RateLimitConfig
+)
+
+// InitRateLimitFromViper 根据 rate_limit 配置初始化限流器，依赖 Redis 客户端
+func InitRateLimitFromViper() error {
+	var cfg RateLimitConfig
+	if err := viper.UnmarshalKey("rate_limit", &cfg); err != nil {
+		return err
+	}
+	if !cfg.Switch {
+		rateLimiter = nil
+		return nil
+	}
+	client, err := rxRedis.ClientAndErr(cfg.Client)
+	if err != nil {
+		return fmt.Errorf("rate_limit client %s: %w", cfg.Client, err)
+	}
+	rateLimiter, rateLimitConfig = rxRedis.NewLimiter(client, cfg.Prefix), cfg
+	return nil
+}
+
+// RateLimit 根据 rate_limit 配置限流，未开启时不做任何事
+//
+// 需在 RequestCapture 及认证中间件之后使用。Redis 不可用时放行请求并记录日志。
+func RateLimit() gin.HandlerFunc {
+	if rateLimiter == nil {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+	return RateLimitWithLimiter(rateLimiter, rateLimitConfig)
+}
+
+// RateLimitWithLimiter 使用指定的限流器及配置限流
+func RateLimitWithLimiter(limiter RateLimiter, cfg RateLimitConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rule, ok := cfg.rule(c.Request.Method, c.FullPath())
+		if !ok {
+			c.Next()
+			return
+		}
+		allow(c, limiter, rule, RateLimitSubject(c))
+	}
+}
+
+// RateLimitByIP 根据 rate_limit.ip 按客户端 IP 限流，未开启时不做任何事
+//
+// 需在 RequestCapture 之后、Authenticate 之前使用，认证失败的请求同样计数。
+func RateLimitByIP() gin.HandlerFunc {
+	if rateLimiter == nil || rateLimitConfig.IP.Rate <= 0 {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+	return RateLimitByIPWithLimiter(rateLimiter, rateLimitConfig.IP)
+}
+
+// RateLimitByIPWithLimiter 使用指定的限流器及规则按客户端 IP 限流
+func RateLimitByIPWithLimiter(limiter RateLimiter, rule RateLimitRule) gin.HandlerFunc {
+	if rule.Name == "" {
+		rule.Name = "pre-auth"
+	}
+	return func(c *gin.Context) {
+		ip := c.GetString(utils.ClientIPKey)
+		if ip == "" {
+			ip = c.ClientIP()
+		}
+		allow(c, limiter, rule, "ip:"+ip)
+	}
+}
+
+// allow 检查 subject 是否超过 rule，设置 RateLimit 响应头，超过时返回 429
+func allow(c *gin.Context, limiter RateLimiter, rule RateLimitRule, subject string) {
+	result, err := limiter.Allow(c, rule.Name+":"+subject, rule.limit())
+	if err != nil {
+		log.Sugar().Warnf("限流检查失败，放行请求: %s, 错误: %v", rule.Name, err)
+		c.Next()
+		return
+	}
+
+	header := c.Writer.Header()
+	header.Set("RateLimit-Policy", rule.policy())
+	header.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+	header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	header.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+	if !result.Allowed {
+		if result.RetryAfter > 0 {
+			header.Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+		}
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, services.NewResult(c, ErrRateLimited.GetCode(), ErrRateLimited.Error(), nil))
+		return
+	}
+	c.Next()
+}
+
+// rule 按顺序匹配路由规则，未匹配时使用默认规则
+func (cfg RateLimitConfig) rule(method, path string) (RateLimitRule, bool) {
+	rule := cfg.Default
+	for _, r := range cfg.Routes {
+		if r.match(method, path) {
+			rule = r
+			break
+		}
+	}
+	if rule.Rate <= 0 {
+		return rule, false
+	}
+	if rule.Name == "" {
+		if rule.Path == "" {
+			rule.Name = "default"
+		} else {
+			rule.Name = strings.ToUpper(rule.Method) + rule.Path
+		}
+	}
+	return rule, true
+}
+
+// RateLimitSubject 限流主体，依次使用 API Key、用户ID及客户端 IP；API Key 认证时也会写入所属用户ID，因此优先按 key 限流
+func RateLimitSubject(c *gin.Context) string {
+	if keyID := c.GetString(utils.APIKeyIDKey); keyID != "" {
+		return "key:" + keyID
+	}
+	if userID := c.GetString(utils.UserIDKey); userID != "" {
+		return "user:" + userID
+	}
+	if ip := c.GetString(utils.ClientIPKey); ip != "" {
+		return "ip:" + ip
+	}
+	return "ip:" + c.ClientIP()
+}
+
+func ceilSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int(math.Ceil(d.Seconds()))
+}
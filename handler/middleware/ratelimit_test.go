@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	rxRedis "go-agent/gopkg/cache/redis"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubLimiter struct {
+	keys   []string
+	result rxRedis.LimitResult
+}
+
+func (s *stubLimiter) Allow(_ context.Context, key string, _ rxRedis.Limit) (*rxRedis.LimitResult, error) {
+	s.keys = append(s.keys, key)
+	result := s.result
+	return &result, nil
+}
+
+func TestRateLimitWithLimiter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limiter := &stubLimiter{result: rxRedis.LimitResult{
+		Limit:      3,
+		Remaining:  0,
+		RetryAfter: 1500 * time.Millisecond,
+		ResetAfter: 20 * time.Second,
+	}}
+	cfg := RateLimitConfig{
+		Routes: []RateLimitRule{
+			{Method: http.MethodPost, Path: "/api/agent/chat", Algorithm: rxRedis.AlgorithmTokenBucket, Rate: 10, Window: time.Minute, Burst: 3},
+		},
+	}
+
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Set("user-id", "u1")
+	}, RateLimitWithLimiter(limiter, cfg))
+	engine.POST("/api/agent/chat", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	engine.GET("/api/task/:id", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/agent/chat", nil))
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "3", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "20", w.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, "10;w=60", w.Header().Get("RateLimit-Policy"))
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+	assert.Equal(t, []string{"POST/api/agent/chat:user:u1"}, limiter.keys)
+
+	// 未匹配规则且没有默认规则时不限流
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/task/1", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, limiter.keys, 1)
+}
+
+func TestRateLimitByIPWithLimiter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limiter := &stubLimiter{result: rxRedis.LimitResult{Limit: 10, RetryAfter: time.Second}}
+
+	// 按 IP 限流在认证之前，认证失败的请求同样被限流
+	engine := gin.New()
+	engine.Use(RateLimitByIPWithLimiter(limiter, RateLimitRule{Rate: 10, Window: time.Minute}), func(c *gin.Context) {
+		c.AbortWithStatus(http.StatusUnauthorized)
+	})
+	engine.GET("/api/task/:id", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/task/1", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set("Authorization", "Bearer invalid")
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, []string{"pre-auth:ip:10.0.0.1"}, limiter.keys)
+
+	limiter.result.Allowed = true
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
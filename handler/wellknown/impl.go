@@ -0,0 +1,36 @@
+package wellknown
+
+import (
+	"net/http"
+
+	"go-agent/gopkg/auth"
+	"go-agent/gopkg/gins"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	engine *gin.Engine
+}
+
+func NewHandler(engine *gin.Engine) gins.Handler {
+	return &Handler{
+		engine: engine,
+	}
+}
+
+func (h *Handler) RegisterRoutes() {
+	g := h.engine.Group("/.well-known")
+	g.GET("/jwks.json", h.JWKS)
+}
+
+// JWKS 签名公钥，按 RFC 7517 格式返回，不使用 services.Result 包装
+func (h *Handler) JWKS(ctx *gin.Context) {
+	jwks := auth.JWKS{Keys: []auth.JWK{}}
+	if manager := auth.Default(); manager != nil {
+		jwks = manager.Keys().JWKS()
+	}
+
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, jwks)
+}
@@ -17,6 +17,11 @@ type StreamAgent interface {
 	StreamHandle(ctx context.Context, prompt string) (*schema.StreamReader[*schema.Message], error)
 }
 
+// MessageAgent 返回模型生成的完整消息，ResponseMeta 中包含模型返回的 token 用量
+type MessageAgent interface {
+	Generate(ctx context.Context, prompt string) (*schema.Message, error)
+}
+
 // NewLocalAgent 返回一个不会调用外部 LLM 的本地实现（用于开发与测试）
 func NewLocalAgent() Agent {
 	return &localAgent{}
@@ -55,6 +55,16 @@ func NewEinoAgent() (*EinoAgent, error) {
 
 // Handle 实现 Agent 接口
 func (a *EinoAgent) Handle(ctx context.Context, prompt string) (string, error) {
+	resp, err := a.Generate(ctx, prompt)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Content, nil
+}
+
+// Generate 实现 MessageAgent 接口，返回的消息包含 token 用量
+func (a *EinoAgent) Generate(ctx context.Context, prompt string) (*schema.Message, error) {
 	// 将 prompt 转换为 Eino 消息
 	msgs := []*schema.Message{
 		schema.UserMessage(prompt),
@@ -65,10 +75,10 @@ func (a *EinoAgent) Handle(ctx context.Context, prompt string) (string, error) {
 
 	if err != nil {
 		log.Sugar().Errorf("eino agent invoke error: %v", err)
-		return "", err
+		return nil, err
 	}
 
-	return resp.Content, nil
+	return resp, nil
 }
 
 // StreamHandle 实现流式处理
@@ -0,0 +1,61 @@
+package quota
+
+import (
+	"fmt"
+	"time"
+
+	rxRedis "go-agent/gopkg/cache/redis"
+	quotaDao "go-agent/internal/dao/quota"
+
+	"github.com/spf13/viper"
+)
+
+// Config 额度配置，对应 quota
+type Config struct {
+	Switch           bool          `json:"switch" mapstructure:"switch"`
+	Client           string        `json:"client" mapstructure:"client"`                       // redis 客户端名称
+	Prefix           string        `json:"prefix" mapstructure:"prefix"`                       // redis 键前缀，默认 go-agent:quota
+	CharsPerToken    float64       `json:"chars_per_token" mapstructure:"chars_per_token"`     // 估算 prompt token 数时每个 token 的字符数，默认 1.5
+	CompletionTokens int64         `json:"completion_tokens" mapstructure:"completion_tokens"` // 调用前为回复预留的 token 数，默认 1024
+	CacheTTL         time.Duration `json:"cache_ttl" mapstructure:"cache_ttl"`                 // 额度配置的缓存时间，默认 1m
+}
+
+func (c *Config) setDefaults() {
+	if c.Prefix == "" {
+		c.Prefix = "go-agent:quota"
+	}
+	if c.CharsPerToken <= 0 {
+		c.CharsPerToken = 1.5
+	}
+	if c.CompletionTokens <= 0 {
+		c.CompletionTokens = 1024
+	}
+	if c.CacheTTL <= 0 {
+		c.CacheTTL = time.Minute
+	}
+}
+
+var defaultEnforcer *Enforcer
+
+// InitFromViper 根据 quota 配置初始化默认 Enforcer，依赖 Redis 客户端
+func InitFromViper() error {
+	var cfg Config
+	if err := viper.UnmarshalKey("quota", &cfg); err != nil {
+		return err
+	}
+	if !cfg.Switch {
+		defaultEnforcer = nil
+		return nil
+	}
+	client, err := rxRedis.ClientAndErr(cfg.Client)
+	if err != nil {
+		return fmt.Errorf("quota client %s: %w", cfg.Client, err)
+	}
+	defaultEnforcer = NewEnforcer(NewRedisCounter(client), quotaDao.NewDao(), cfg)
+	return nil
+}
+
+// Default 默认 Enforcer，未开启时为 nil
+func Default() *Enforcer {
+	return defaultEnforcer
+}
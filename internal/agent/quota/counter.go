@@ -0,0 +1,47 @@
+package quota
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// reserveScript used+n 不超过 limit 时增加计数
+//
+// KEYS[1] 计数键；ARGV: n, limit, expire_at(unix 秒)
+// 返回 {ok, used}
+var reserveScript = redis.NewScript(`
+local used = tonumber(redis.call("GET", KEYS[1]) or "0")
+local n = tonumber(ARGV[1])
+if used + n > tonumber(ARGV[2]) then
+	return {0, used}
+end
+used = redis.call("INCRBY", KEYS[1], n)
+redis.call("EXPIREAT", KEYS[1], ARGV[3])
+return {1, used}
+`)
+
+// RedisCounter 基于 Redis 的额度计数，检查与增加在 Lua 脚本中原子完成
+type RedisCounter struct {
+	client redis.UniversalClient
+}
+
+// NewRedisCounter 实例化RedisCounter
+func NewRedisCounter(client redis.UniversalClient) *RedisCounter {
+	return &RedisCounter{
+		client: client,
+	}
+}
+
+func (c *RedisCounter) Reserve(ctx context.Context, key string, n, limit int64, expireAt time.Time) (int64, bool, error) {
+	values, err := reserveScript.Run(ctx, c.client, []string{key}, n, limit, expireAt.Unix()).Int64Slice()
+	if err != nil {
+		return 0, false, err
+	}
+	return values[1], values[0] == 1, nil
+}
+
+func (c *RedisCounter) Add(ctx context.Context, key string, n int64) error {
+	return c.client.IncrBy(ctx, key, n).Err()
+}
@@ -0,0 +1,275 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"go-agent/gopkg/cache"
+	"go-agent/gopkg/log"
+	"go-agent/gopkg/services"
+	"go-agent/gopkg/utils"
+	"go-agent/internal/dao"
+	"go-agent/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrQuotaExceeded token 额度已用完
+var ErrQuotaExceeded = services.NewError(10301, "token 额度已用完")
+
+// 主体类型
+const (
+	SubjectUser   = "user"
+	SubjectTeam   = "team"
+	SubjectAPIKey = "api_key"
+)
+
+// 额度周期
+const (
+	PeriodDaily   = "daily"
+	PeriodMonthly = "monthly"
+)
+
+// CacheTag 额度配置的缓存标签，通过 internal/service/quota 修改 s_quota 后失效
+const CacheTag = "quota"
+
+// Subject 额度主体
+type Subject struct {
+	Type string
+	ID   string
+}
+
+func (s Subject) String() string {
+	return s.Type + ":" + s.ID
+}
+
+// SubjectsFromContext 从 ctx 中读取认证后的用户、团队及 API Key，gin.Context 及 request ctx 均可
+func SubjectsFromContext(ctx context.Context) []Subject {
+	var subjects []Subject
+	if id := utils.GetUserID(ctx); id != "" {
+		subjects = append(subjects, Subject{Type: SubjectUser, ID: id})
+	}
+	if id := utils.GetTeamID(ctx); id != "" {
+		subjects = append(subjects, Subject{Type: SubjectTeam, ID: id})
+	}
+	if id := utils.GetAPIKeyID(ctx); id != "" {
+		subjects = append(subjects, Subject{Type: SubjectAPIKey, ID: id})
+	}
+	return subjects
+}
+
+// WithSubjects 将 gin.Context 中的认证信息写入 request ctx，供 agent 调用时使用
+func WithSubjects(c *gin.Context) context.Context {
+	ctx := c.Request.Context()
+	if id := c.GetString(utils.UserIDKey); id != "" {
+		ctx = utils.SetUserID(ctx, id)
+	}
+	if id := c.GetString(utils.TeamIDKey); id != "" {
+		ctx = utils.SetTeamID(ctx, id)
+	}
+	if id := c.GetString(utils.APIKeyIDKey); id != "" {
+		ctx = utils.SetAPIKeyID(ctx, id)
+	}
+	return ctx
+}
+
+// ExceededError 额度不足，错误码为 ErrQuotaExceeded
+type ExceededError struct {
+	Subject Subject
+	Period  string
+	Limit   int64
+	Used    int64
+	ResetAt time.Time
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("%s: %s %s 额度 %d, 已使用 %d", ErrQuotaExceeded.Error(), e.Subject, e.Period, e.Limit, e.Used)
+}
+
+func (e *ExceededError) GetCode() int {
+	return ErrQuotaExceeded.GetCode()
+}
+
+func (e *ExceededError) Unwrap() error {
+	return ErrQuotaExceeded
+}
+
+// Abort 额度不足时返回 429 及 Retry-After，ok 为 false 表示 err 不是额度错误
+func Abort(c *gin.Context, err error) (ok bool) {
+	var exceeded *ExceededError
+	if !errors.As(err, &exceeded) {
+		return false
+	}
+	retryAfter := int(math.Ceil(time.Until(exceeded.ResetAt).Seconds()))
+	c.Header("Content-Type", "application/json; charset=utf-8")
+	c.Header("Retry-After", strconv.Itoa(max(retryAfter, 0)))
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, services.NewResult(c, exceeded.GetCode(), ErrQuotaExceeded.Error(), gin.H{
+		"subject_type": exceeded.Subject.Type,
+		"subject_id":   exceeded.Subject.ID,
+		"period":       exceeded.Period,
+		"limit":        exceeded.Limit,
+		"used":         exceeded.Used,
+		"reset_at":     exceeded.ResetAt,
+	}))
+	return true
+}
+
+// Counter 额度计数
+type Counter interface {
+	// Reserve used+n 不超过 limit 时增加计数并返回 ok，否则不增加；返回当前用量
+	Reserve(ctx context.Context, key string, n, limit int64, expireAt time.Time) (used int64, ok bool, err error)
+	// Add 调整计数，n 可以为负数
+	Add(ctx context.Context, key string, n int64) error
+}
+
+// Enforcer 额度检查
+//
+// 调用模型前按估算的 token 数预占所有主体的每日/每月额度，任一额度不足时返回 ExceededError；
+// 调用结束后按实际用量修正计数。额度配置保存在 s_quota，计数保存在 Redis。
+type Enforcer struct {
+	counter Counter
+	dao     dao.Quota
+	cfg     Config
+	now     func() time.Time
+}
+
+// NewEnforcer 实例化Enforcer
+func NewEnforcer(counter Counter, d dao.Quota, cfg Config) *Enforcer {
+	cfg.setDefaults()
+	return &Enforcer{
+		counter: counter,
+		dao:     d,
+		cfg:     cfg,
+		now:     time.Now,
+	}
+}
+
+// Estimate 估算 prompt 及回复的 token 数
+func (e *Enforcer) Estimate(prompt string) int64 {
+	return e.PromptTokens(prompt) + e.cfg.CompletionTokens
+}
+
+// PromptTokens 按字符数估算文本的 token 数，用于模型未返回用量时
+func (e *Enforcer) PromptTokens(text string) int64 {
+	return int64(math.Ceil(float64(utf8.RuneCountInString(text)) / e.cfg.CharsPerToken))
+}
+
+// budget 一个周期的额度及计数键
+type budget struct {
+	subject  Subject
+	period   string
+	limit    int64
+	key      string
+	resetAt  time.Time
+	reserved bool
+}
+
+// Reserve 为 ctx 中的主体预占 tokens，没有主体或未配置额度时返回不做任何事的 Reservation
+func (e *Enforcer) Reserve(ctx context.Context, tokens int64) (*Reservation, error) {
+	now := e.now()
+	var budgets []*budget
+	for _, subject := range SubjectsFromContext(ctx) {
+		rows, err := e.limits(ctx, subject)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			start, resetAt, ok := periodRange(row.Period, now)
+			if !ok {
+				log.Sugar().Warnf("未知的额度周期: %s, 主体: %s", row.Period, subject)
+				continue
+			}
+			budgets = append(budgets, &budget{
+				subject: subject,
+				period:  row.Period,
+				limit:   row.TokenLimit,
+				key:     e.counterKey(subject, row.Period, start),
+				resetAt: resetAt,
+			})
+		}
+	}
+
+	r := &Reservation{enforcer: e, tokens: tokens, budgets: budgets}
+	for _, b := range budgets {
+		// 计数在周期结束一天后过期，便于对账
+		used, ok, err := e.counter.Reserve(ctx, b.key, tokens, b.limit, b.resetAt.Add(24*time.Hour))
+		if err == nil && !ok {
+			err = &ExceededError{Subject: b.subject, Period: b.period, Limit: b.limit, Used: used, ResetAt: b.resetAt}
+		}
+		if err != nil {
+			r.Cancel(ctx)
+			return nil, err
+		}
+		b.reserved = true
+	}
+	return r, nil
+}
+
+// limits 查询主体的额度配置，结果通过 cache 缓存
+func (e *Enforcer) limits(ctx context.Context, subject Subject) ([]*model.SQuota, error) {
+	return cache.GetOrLoad(ctx, "quota:"+subject.String(), e.cfg.CacheTTL, func(ctx context.Context) ([]*model.SQuota, error) {
+		return e.dao.FindBySubject(ctx, subject.Type, subject.ID)
+	}, cache.WithTags(CacheTag))
+}
+
+func (e *Enforcer) counterKey(subject Subject, period string, start time.Time) string {
+	layout := "20060102"
+	if period == PeriodMonthly {
+		layout = "200601"
+	}
+	return fmt.Sprintf("%s:%s:%s:%s", e.cfg.Prefix, subject, period, start.Format(layout))
+}
+
+// periodRange 周期的开始时间及下一个周期的开始时间，按本地时区计算
+func periodRange(period string, now time.Time) (start, end time.Time, ok bool) {
+	y, m, d := now.Date()
+	switch period {
+	case PeriodDaily:
+		start = time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+		return start, start.AddDate(0, 0, 1), true
+	case PeriodMonthly:
+		start = time.Date(y, m, 1, 0, 0, 0, 0, now.Location())
+		return start, start.AddDate(0, 1, 0), true
+	default:
+		return time.Time{}, time.Time{}, false
+	}
+}
+
+// Reservation 预占的额度
+type Reservation struct {
+	enforcer *Enforcer
+	tokens   int64
+	budgets  []*budget
+	done     bool
+}
+
+// Commit 按实际用量修正计数，实际用量超过额度时只记录，下次调用时拒绝；重复调用无效
+func (r *Reservation) Commit(ctx context.Context, actual int64) {
+	if r.done {
+		return
+	}
+	r.done = true
+	delta := actual - r.tokens
+	if delta == 0 {
+		return
+	}
+	for _, b := range r.budgets {
+		if !b.reserved {
+			continue
+		}
+		if err := r.enforcer.counter.Add(ctx, b.key, delta); err != nil {
+			log.Sugar().Errorf("修正 token 用量失败: %s, 差额: %d, 错误: %v", b.key, delta, err)
+		}
+	}
+}
+
+// Cancel 调用失败时归还预占的额度
+func (r *Reservation) Cancel(ctx context.Context) {
+	r.Commit(ctx, 0)
+}
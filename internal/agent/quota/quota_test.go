@@ -0,0 +1,87 @@
+package quota
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-agent/gopkg/utils"
+	"go-agent/internal/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryCounter map[string]int64
+
+func (m memoryCounter) Reserve(_ context.Context, key string, n, limit int64, _ time.Time) (int64, bool, error) {
+	if m[key]+n > limit {
+		return m[key], false, nil
+	}
+	m[key] += n
+	return m[key], true, nil
+}
+
+func (m memoryCounter) Add(_ context.Context, key string, n int64) error {
+	m[key] += n
+	return nil
+}
+
+type stubDao map[string][]*model.SQuota
+
+func (s stubDao) FindBySubject(_ context.Context, subjectType, subjectID string) ([]*model.SQuota, error) {
+	return s[subjectType+":"+subjectID], nil
+}
+
+func (s stubDao) Save(_ context.Context, _ *model.SQuota) error {
+	return nil
+}
+
+func (s stubDao) Delete(_ context.Context, _, _, _ string) (bool, error) {
+	return false, nil
+}
+
+func TestEnforcer_Reserve(t *testing.T) {
+	counter := memoryCounter{}
+	enforcer := NewEnforcer(counter, stubDao{
+		"user:u1": {
+			{Period: PeriodDaily, TokenLimit: 100},
+			{Period: PeriodMonthly, TokenLimit: 1000},
+		},
+		"team:t1": {
+			{Period: PeriodDaily, TokenLimit: 150},
+		},
+	}, Config{})
+	now := time.Date(2026, 10, 18, 15, 0, 0, 0, time.Local)
+	enforcer.now = func() time.Time { return now }
+	ctx := utils.SetTeamID(utils.SetUserID(context.Background(), "u1"), "t1")
+
+	r, err := enforcer.Reserve(ctx, 80)
+	assert.NoError(t, err)
+	r.Commit(ctx, 60)
+	assert.Equal(t, int64(60), counter["go-agent:quota:user:u1:daily:20261018"])
+	assert.Equal(t, int64(60), counter["go-agent:quota:user:u1:monthly:202610"])
+	assert.Equal(t, int64(60), counter["go-agent:quota:team:t1:daily:20261018"])
+
+	// 用户每日额度不足，已预占的额度被归还
+	_, err = enforcer.Reserve(ctx, 50)
+	var exceeded *ExceededError
+	assert.ErrorAs(t, err, &exceeded)
+	assert.ErrorIs(t, err, ErrQuotaExceeded)
+	assert.Equal(t, SubjectUser, exceeded.Subject.Type)
+	assert.Equal(t, PeriodDaily, exceeded.Period)
+	assert.Equal(t, time.Date(2026, 10, 19, 0, 0, 0, 0, time.Local), exceeded.ResetAt)
+	assert.Equal(t, int64(60), counter["go-agent:quota:user:u1:monthly:202610"])
+	assert.Equal(t, int64(60), counter["go-agent:quota:team:t1:daily:20261018"])
+
+	// 次日重新计数
+	now = now.AddDate(0, 0, 1)
+	r, err = enforcer.Reserve(ctx, 50)
+	assert.NoError(t, err)
+	r.Cancel(ctx)
+	assert.Equal(t, int64(0), counter["go-agent:quota:user:u1:daily:20261019"])
+
+	// 没有主体时不检查
+	r, err = enforcer.Reserve(context.Background(), 1<<20)
+	assert.NoError(t, err)
+	r.Commit(ctx, 1)
+}
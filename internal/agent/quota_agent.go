@@ -0,0 +1,120 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	"go-agent/internal/agent/quota"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// quotaAgent 调用前预占 token 额度，调用结束后按实际用量修正
+type quotaAgent struct {
+	agent    StreamAgent
+	enforcer *quota.Enforcer
+}
+
+// WithQuota 为 agent 增加 token 额度检查，enforcer 为空时返回 agent 本身
+//
+// 额度不足时 Handle 及 StreamHandle 返回 *quota.ExceededError。
+func WithQuota(agent StreamAgent, enforcer *quota.Enforcer) StreamAgent {
+	if enforcer == nil {
+		return agent
+	}
+	return &quotaAgent{
+		agent:    agent,
+		enforcer: enforcer,
+	}
+}
+
+func (a *quotaAgent) Handle(ctx context.Context, prompt string) (string, error) {
+	resp, err := a.Generate(ctx, prompt)
+	if err != nil {
+		return "", err
+	}
+	return resp.Content, nil
+}
+
+// Generate 被包装的 agent 实现 MessageAgent 时按模型返回的用量计费，否则按内容估算
+func (a *quotaAgent) Generate(ctx context.Context, prompt string) (*schema.Message, error) {
+	reservation, err := a.enforcer.Reserve(ctx, a.enforcer.Estimate(prompt))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *schema.Message
+	if generator, ok := a.agent.(MessageAgent); ok {
+		resp, err = generator.Generate(ctx, prompt)
+	} else {
+		var content string
+		content, err = a.agent.Handle(ctx, prompt)
+		resp = schema.AssistantMessage(content, nil)
+	}
+	if err != nil {
+		reservation.Cancel(context.WithoutCancel(ctx))
+		return nil, err
+	}
+
+	var usage *schema.TokenUsage
+	if resp.ResponseMeta != nil {
+		usage = resp.ResponseMeta.Usage
+	}
+	reservation.Commit(context.WithoutCancel(ctx), a.actualTokens(prompt, resp.Content, usage))
+	return resp, nil
+}
+
+// actualTokens 实际用量，模型未返回用量时按 prompt 及回复内容估算
+func (a *quotaAgent) actualTokens(prompt, content string, usage *schema.TokenUsage) int64 {
+	if usage != nil && usage.TotalTokens > 0 {
+		return int64(usage.TotalTokens)
+	}
+	return a.enforcer.PromptTokens(prompt) + a.enforcer.PromptTokens(content)
+}
+
+func (a *quotaAgent) StreamHandle(ctx context.Context, prompt string) (*schema.StreamReader[*schema.Message], error) {
+	reservation, err := a.enforcer.Reserve(ctx, a.enforcer.Estimate(prompt))
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := a.agent.StreamHandle(ctx, prompt)
+	if err != nil {
+		reservation.Cancel(context.WithoutCancel(ctx))
+		return nil, err
+	}
+
+	reader, writer := schema.Pipe[*schema.Message](1)
+	go func() {
+		defer writer.Close()
+		defer stream.Close()
+
+		var (
+			usage   *schema.TokenUsage
+			content strings.Builder
+		)
+		defer func() {
+			reservation.Commit(context.WithoutCancel(ctx), a.actualTokens(prompt, content.String(), usage))
+		}()
+
+		for {
+			chunk, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if chunk != nil {
+				content.WriteString(chunk.Content)
+				if chunk.ResponseMeta != nil && chunk.ResponseMeta.Usage != nil {
+					usage = chunk.ResponseMeta.Usage
+				}
+			}
+			// 调用方关闭流或模型返回错误时结束，已生成的部分仍计入用量
+			if closed := writer.Send(chunk, err); closed || err != nil {
+				return
+			}
+		}
+	}()
+	return reader, nil
+}
@@ -0,0 +1,107 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"go-agent/gopkg/utils"
+	"go-agent/internal/agent/quota"
+	"go-agent/internal/model"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryCounter map[string]int64
+
+func (m memoryCounter) Reserve(_ context.Context, key string, n, limit int64, _ time.Time) (int64, bool, error) {
+	if m[key]+n > limit {
+		return m[key], false, nil
+	}
+	m[key] += n
+	return m[key], true, nil
+}
+
+func (m memoryCounter) Add(_ context.Context, key string, n int64) error {
+	m[key] += n
+	return nil
+}
+
+type stubQuotaDao []*model.SQuota
+
+func (s stubQuotaDao) FindBySubject(_ context.Context, _, _ string) ([]*model.SQuota, error) {
+	return s, nil
+}
+
+func (s stubQuotaDao) Save(_ context.Context, _ *model.SQuota) error {
+	return nil
+}
+
+func (s stubQuotaDao) Delete(_ context.Context, _, _, _ string) (bool, error) {
+	return false, nil
+}
+
+// usageAgent 返回固定内容及模型用量
+type usageAgent struct {
+	content string
+	usage   *schema.TokenUsage
+}
+
+func (a *usageAgent) message() *schema.Message {
+	msg := schema.AssistantMessage(a.content, nil)
+	if a.usage != nil {
+		msg.ResponseMeta = &schema.ResponseMeta{Usage: a.usage}
+	}
+	return msg
+}
+
+func (a *usageAgent) Handle(_ context.Context, _ string) (string, error) {
+	return a.content, nil
+}
+
+func (a *usageAgent) Generate(_ context.Context, _ string) (*schema.Message, error) {
+	return a.message(), nil
+}
+
+func (a *usageAgent) StreamHandle(_ context.Context, _ string) (*schema.StreamReader[*schema.Message], error) {
+	return schema.StreamReaderFromArray([]*schema.Message{a.message()}), nil
+}
+
+func TestQuotaAgent_Usage(t *testing.T) {
+	ctx := utils.SetUserID(context.Background(), "u1")
+	counter := memoryCounter{}
+	enforcer := quota.NewEnforcer(counter, stubQuotaDao{{Period: quota.PeriodDaily, TokenLimit: 1 << 20}}, quota.Config{})
+	used := func() int64 {
+		var total int64
+		for _, n := range counter {
+			total += n
+		}
+		return total
+	}
+
+	// 按模型返回的用量计费
+	a := WithQuota(&usageAgent{content: "hello", usage: &schema.TokenUsage{TotalTokens: 42}}, enforcer)
+	resp, err := a.Handle(ctx, "hi")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", resp)
+	assert.Equal(t, int64(42), used())
+
+	stream, err := a.StreamHandle(ctx, "hi")
+	assert.NoError(t, err)
+	for {
+		if _, err := stream.Recv(); errors.Is(err, io.EOF) {
+			break
+		}
+	}
+	stream.Close()
+	assert.Eventually(t, func() bool { return used() == 84 }, time.Second, 10*time.Millisecond)
+
+	// 模型未返回用量时按内容估算
+	a = WithQuota(&usageAgent{content: "hello"}, enforcer)
+	_, err = a.Handle(ctx, "hi")
+	assert.NoError(t, err)
+	assert.Equal(t, 84+enforcer.PromptTokens("hi")+enforcer.PromptTokens("hello"), used())
+}
@@ -0,0 +1,27 @@
+package dao
+
+import (
+	"context"
+	"go-agent/internal/model"
+	"time"
+)
+
+// API Key 状态
+const (
+	APIKeyStatusOn      = "on"
+	APIKeyStatusRevoked = "revoked"
+)
+
+type APIKey interface {
+	Create(ctx context.Context, key *model.SApiKey) error
+	// FindByHash 按 key 哈希查询，不存在时返回 nil
+	FindByHash(ctx context.Context, keyHash string) (*model.SApiKey, error)
+	// FindByKeyID 按 key ID 查询，不存在时返回 nil
+	FindByKeyID(ctx context.Context, keyID string) (*model.SApiKey, error)
+	// List 查询用户的 API Key，ownerID 为空时查询全部
+	List(ctx context.Context, ownerID string) ([]*model.SApiKey, error)
+	// Revoke 吊销 API Key，返回是否有更新
+	Revoke(ctx context.Context, keyID string) (bool, error)
+	// TouchLastUsed 记录最后使用时间
+	TouchLastUsed(ctx context.Context, keyID string, at time.Time) error
+}
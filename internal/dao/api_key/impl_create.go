@@ -0,0 +1,15 @@
+package api_key
+
+import (
+	"context"
+	"go-agent/internal/dao"
+	"go-agent/internal/model"
+)
+
+func (d *Dao) Create(ctx context.Context, key *model.SApiKey) error {
+	if err := dao.SApiKey.WithContext(ctx).Create(key); err != nil {
+		return d.ConvertError(err)
+	}
+
+	return nil
+}
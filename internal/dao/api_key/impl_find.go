@@ -0,0 +1,41 @@
+package api_key
+
+import (
+	"context"
+	"go-agent/internal/dao"
+	"go-agent/internal/model"
+)
+
+func (d *Dao) FindByHash(ctx context.Context, keyHash string) (*model.SApiKey, error) {
+	k := dao.SApiKey
+	key, err := k.WithContext(ctx).Where(k.KeyHash.Eq(keyHash)).First()
+	if err != nil {
+		return nil, d.ConvertError(err)
+	}
+
+	return key, nil
+}
+
+func (d *Dao) FindByKeyID(ctx context.Context, keyID string) (*model.SApiKey, error) {
+	k := dao.SApiKey
+	key, err := k.WithContext(ctx).Where(k.KeyId.Eq(keyID)).First()
+	if err != nil {
+		return nil, d.ConvertError(err)
+	}
+
+	return key, nil
+}
+
+func (d *Dao) List(ctx context.Context, ownerID string) ([]*model.SApiKey, error) {
+	k := dao.SApiKey
+	q := k.WithContext(ctx)
+	if ownerID != "" {
+		q = q.Where(k.OwnerId.Eq(ownerID))
+	}
+	keys, err := q.Order(k.Id.Desc()).Find()
+	if err != nil {
+		return nil, d.ConvertError(err)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,25 @@
+package api_key
+
+import (
+	"context"
+	"go-agent/internal/dao"
+	"time"
+)
+
+func (d *Dao) Revoke(ctx context.Context, keyID string) (bool, error) {
+	k := dao.SApiKey
+	info, err := k.WithContext(ctx).
+		Where(k.KeyId.Eq(keyID), k.Status.Eq(dao.APIKeyStatusOn)).
+		UpdateSimple(k.Status.Value(dao.APIKeyStatusRevoked), k.UpdatedAt.Value(time.Now()))
+	if err != nil {
+		return false, d.ConvertError(err)
+	}
+
+	return info.RowsAffected > 0, nil
+}
+
+func (d *Dao) TouchLastUsed(ctx context.Context, keyID string, at time.Time) error {
+	k := dao.SApiKey
+	_, err := k.WithContext(ctx).Where(k.KeyId.Eq(keyID)).UpdateSimple(k.LastUsedAt.Value(at))
+	return d.ConvertError(err)
+}
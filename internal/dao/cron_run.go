@@ -0,0 +1,10 @@
+package dao
+
+import (
+	"context"
+	"go-agent/internal/model"
+)
+
+type CronRun interface {
+	Create(ctx context.Context, run *model.SCronRun) error
+}
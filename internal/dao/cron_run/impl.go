@@ -0,0 +1,15 @@
+package cron_run
+
+import (
+	"go-agent/gopkg/gorms"
+)
+
+type Dao struct {
+	*gorms.BaseDao
+}
+
+func NewDao() *Dao {
+	return &Dao{
+		BaseDao: gorms.NewBaseDao(),
+	}
+}
@@ -0,0 +1,14 @@
+package cron_run
+
+import (
+	"context"
+	"go-agent/internal/dao"
+	"go-agent/internal/model"
+)
+
+func (d *Dao) Create(ctx context.Context, run *model.SCronRun) error {
+	if err := dao.SCronRun.WithContext(ctx).Create(run); err != nil {
+		return d.ConvertError(err)
+	}
+	return nil
+}
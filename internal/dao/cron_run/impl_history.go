@@ -0,0 +1,22 @@
+package cron_run
+
+import (
+	"context"
+	rxCron "go-agent/gopkg/cron/base"
+	"go-agent/internal/model"
+)
+
+// Save 实现 rxCron.HistoryStore，保存定时任务执行记录
+func (d *Dao) Save(ctx context.Context, record *rxCron.RunRecord) error {
+	return d.Create(ctx, &model.SCronRun{
+		Name:        record.Name,
+		Node:        record.Node,
+		Status:      record.Status,
+		Error:       record.Error,
+		ScheduledAt: record.ScheduledAt,
+		StartedAt:   record.StartedAt,
+		FinishedAt:  record.FinishedAt,
+		DurationMs:  record.Duration.Milliseconds(),
+		CreatedAt:   record.FinishedAt,
+	})
+}
@@ -16,34 +16,74 @@ import (
 )
 
 var (
-	Q            = new(Query)
-	SPictureBook *sPictureBook
+	Q               = new(Query)
+	SApiKey         *sApiKey
+	SCronRun        *sCronRun
+	SOutbox         *sOutbox
+	SPictureBook    *sPictureBook
+	SQuota          *sQuota
+	SRole           *sRole
+	SRolePermission *sRolePermission
+	SUser           *sUser
+	SUserRole       *sUserRole
 )
 
 func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	*Q = *Use(db, opts...)
+	SApiKey = &Q.SApiKey
+	SCronRun = &Q.SCronRun
+	SOutbox = &Q.SOutbox
 	SPictureBook = &Q.SPictureBook
+	SQuota = &Q.SQuota
+	SRole = &Q.SRole
+	SRolePermission = &Q.SRolePermission
+	SUser = &Q.SUser
+	SUserRole = &Q.SUserRole
 }
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
-		db:           db,
-		SPictureBook: newSPictureBook(db, opts...),
+		db:              db,
+		SApiKey:         newSApiKey(db, opts...),
+		SCronRun:        newSCronRun(db, opts...),
+		SOutbox:         newSOutbox(db, opts...),
+		SPictureBook:    newSPictureBook(db, opts...),
+		SQuota:          newSQuota(db, opts...),
+		SRole:           newSRole(db, opts...),
+		SRolePermission: newSRolePermission(db, opts...),
+		SUser:           newSUser(db, opts...),
+		SUserRole:       newSUserRole(db, opts...),
 	}
 }
 
 type Query struct {
 	db *gorm.DB
 
-	SPictureBook sPictureBook
+	SApiKey         sApiKey
+	SCronRun        sCronRun
+	SOutbox         sOutbox
+	SPictureBook    sPictureBook
+	SQuota          sQuota
+	SRole           sRole
+	SRolePermission sRolePermission
+	SUser           sUser
+	SUserRole       sUserRole
 }
 
 func (q *Query) Available() bool { return q.db != nil }
 
 func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
-		db:           db,
-		SPictureBook: q.SPictureBook.clone(db),
+		db:              db,
+		SApiKey:         q.SApiKey.clone(db),
+		SCronRun:        q.SCronRun.clone(db),
+		SOutbox:         q.SOutbox.clone(db),
+		SPictureBook:    q.SPictureBook.clone(db),
+		SQuota:          q.SQuota.clone(db),
+		SRole:           q.SRole.clone(db),
+		SRolePermission: q.SRolePermission.clone(db),
+		SUser:           q.SUser.clone(db),
+		SUserRole:       q.SUserRole.clone(db),
 	}
 }
 
@@ -57,18 +97,42 @@ func (q *Query) WriteDB() *Query {
 
 func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
-		db:           db,
-		SPictureBook: q.SPictureBook.replaceDB(db),
+		db:              db,
+		SApiKey:         q.SApiKey.replaceDB(db),
+		SCronRun:        q.SCronRun.replaceDB(db),
+		SOutbox:         q.SOutbox.replaceDB(db),
+		SPictureBook:    q.SPictureBook.replaceDB(db),
+		SQuota:          q.SQuota.replaceDB(db),
+		SRole:           q.SRole.replaceDB(db),
+		SRolePermission: q.SRolePermission.replaceDB(db),
+		SUser:           q.SUser.replaceDB(db),
+		SUserRole:       q.SUserRole.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
-	SPictureBook ISPictureBookDo
+	SApiKey         ISApiKeyDo
+	SCronRun        ISCronRunDo
+	SOutbox         ISOutboxDo
+	SPictureBook    ISPictureBookDo
+	SQuota          ISQuotaDo
+	SRole           ISRoleDo
+	SRolePermission ISRolePermissionDo
+	SUser           ISUserDo
+	SUserRole       ISUserRoleDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
-		SPictureBook: q.SPictureBook.WithContext(ctx),
+		SApiKey:         q.SApiKey.WithContext(ctx),
+		SCronRun:        q.SCronRun.WithContext(ctx),
+		SOutbox:         q.SOutbox.WithContext(ctx),
+		SPictureBook:    q.SPictureBook.WithContext(ctx),
+		SQuota:          q.SQuota.WithContext(ctx),
+		SRole:           q.SRole.WithContext(ctx),
+		SRolePermission: q.SRolePermission.WithContext(ctx),
+		SUser:           q.SUser.WithContext(ctx),
+		SUserRole:       q.SUserRole.WithContext(ctx),
 	}
 }
 
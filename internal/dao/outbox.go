@@ -0,0 +1,27 @@
+package dao
+
+import (
+	"context"
+	"go-agent/internal/model"
+	"time"
+)
+
+// 发件箱消息状态
+const (
+	OutboxStatusPending = "pending"
+	OutboxStatusSent    = "sent"
+	OutboxStatusFailed  = "failed"
+)
+
+type Outbox interface {
+	// Create 写入待发送消息，tx 为业务写入所在的事务，为空时直接写入
+	Create(ctx context.Context, tx *Query, row *model.SOutbox) error
+	// Pending 锁定到期的待发送消息，需在事务中调用，已被其他节点锁定的行会被跳过
+	Pending(ctx context.Context, tx *Query, limit int) ([]*model.SOutbox, error)
+	// MarkSent 标记为已发送
+	MarkSent(ctx context.Context, tx *Query, ids []uint64) error
+	// MarkRetry 记录发送失败，status 为 failed 时不再重试
+	MarkRetry(ctx context.Context, tx *Query, row *model.SOutbox) error
+	// DeleteSent 删除 before 之前发送的消息，返回删除的数量
+	DeleteSent(ctx context.Context, before time.Time, limit int) (int64, error)
+}
@@ -0,0 +1,17 @@
+package outbox
+
+import (
+	"context"
+	"go-agent/internal/dao"
+	"go-agent/internal/model"
+)
+
+func (d *Dao) Create(ctx context.Context, tx *dao.Query, row *model.SOutbox) error {
+	if tx == nil {
+		tx = dao.Q
+	}
+	if err := tx.SOutbox.WithContext(ctx).Create(row); err != nil {
+		return d.ConvertError(err)
+	}
+	return nil
+}
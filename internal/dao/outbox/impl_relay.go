@@ -0,0 +1,63 @@
+package outbox
+
+import (
+	"context"
+	"go-agent/internal/dao"
+	"go-agent/internal/model"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+func (d *Dao) Pending(ctx context.Context, tx *dao.Query, limit int) ([]*model.SOutbox, error) {
+	o := tx.SOutbox
+	rows, err := o.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where(o.Status.Eq(dao.OutboxStatusPending), o.NextRetryAt.Lte(time.Now())).
+		Order(o.Id).
+		Limit(limit).
+		Find()
+	if err != nil {
+		return nil, d.ConvertError(err)
+	}
+	return rows, nil
+}
+
+func (d *Dao) MarkSent(ctx context.Context, tx *dao.Query, ids []uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	o := tx.SOutbox
+	now := time.Now()
+	_, err := o.WithContext(ctx).Where(o.Id.In(ids...)).UpdateSimple(
+		o.Status.Value(dao.OutboxStatusSent),
+		o.Attempts.Add(1),
+		o.SentAt.Value(now),
+		o.UpdatedAt.Value(now),
+	)
+	return d.ConvertError(err)
+}
+
+func (d *Dao) MarkRetry(ctx context.Context, tx *dao.Query, row *model.SOutbox) error {
+	o := tx.SOutbox
+	_, err := o.WithContext(ctx).Where(o.Id.Eq(row.Id)).UpdateSimple(
+		o.Status.Value(row.Status),
+		o.Attempts.Value(row.Attempts),
+		o.LastError.Value(row.LastError),
+		o.NextRetryAt.Value(row.NextRetryAt),
+		o.UpdatedAt.Value(time.Now()),
+	)
+	return d.ConvertError(err)
+}
+
+func (d *Dao) DeleteSent(ctx context.Context, before time.Time, limit int) (int64, error) {
+	o := dao.SOutbox
+	info, err := o.WithContext(ctx).
+		Where(o.Status.Eq(dao.OutboxStatusSent), o.SentAt.Lt(before)).
+		Limit(limit).
+		Delete()
+	if err != nil {
+		return 0, d.ConvertError(err)
+	}
+	return info.RowsAffected, nil
+}
@@ -6,6 +6,12 @@ import (
 	"context"
 )
 
+// 绘本的写操作成功后失效分页缓存
 type PictureBook interface {
 	Pagination(ctx context.Context, page gorms.Page) (*gorms.Paging[*model.SPictureBook], error)
+	Create(ctx context.Context, book *model.SPictureBook) error
+	// Update 按 book_id 更新绘本，返回是否有更新
+	Update(ctx context.Context, book *model.SPictureBook) (bool, error)
+	// Delete 按 book_id 删除绘本，返回是否有删除
+	Delete(ctx context.Context, bookID string) (bool, error)
 }
@@ -1,7 +1,10 @@
 package picture_book
 
 import (
+	"context"
+	"go-agent/gopkg/cache"
 	"go-agent/gopkg/gorms"
+	"go-agent/gopkg/log"
 )
 
 type Dao struct {
@@ -13,3 +16,10 @@ func NewDao() *Dao {
 		BaseDao: gorms.NewBaseDao(),
 	}
 }
+
+// invalidate 失效绘本缓存，失败时只记录日志，缓存在 paginationCacheTTL 后过期
+func (d *Dao) invalidate(ctx context.Context) {
+	if err := cache.InvalidateTags(ctx, CacheTag); err != nil {
+		log.Sugar().Warnf("picture book invalidate cache failed: %v", err)
+	}
+}
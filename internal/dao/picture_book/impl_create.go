@@ -0,0 +1,16 @@
+package picture_book
+
+import (
+	"context"
+	"go-agent/internal/dao"
+	"go-agent/internal/model"
+)
+
+func (d *Dao) Create(ctx context.Context, book *model.SPictureBook) error {
+	if err := dao.SPictureBook.WithContext(ctx).Create(book); err != nil {
+		return d.ConvertError(err)
+	}
+	d.invalidate(ctx)
+
+	return nil
+}
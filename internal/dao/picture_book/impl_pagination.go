@@ -1,23 +1,35 @@
 package picture_book
 
 import (
+	"context"
+	"fmt"
+	"go-agent/gopkg/cache"
 	"go-agent/gopkg/gorms"
 	"go-agent/internal/dao"
 	"go-agent/internal/model"
-	"context"
+	"time"
 )
 
+// CacheTag 绘本缓存标签，绘本变更后通过 cache.InvalidateTags 失效
+const CacheTag = "picture_book"
+
+const paginationCacheTTL = 5 * time.Minute
+
 func (d *Dao) Pagination(ctx context.Context, page gorms.Page) (*gorms.Paging[*model.SPictureBook], error) {
-	paging, err := gorms.PaginationQuery(
-		dao.SPictureBook.Order(
-			dao.SPictureBook.Position.Desc(),
-		).FindByPage, gorms.Page{
-			PageIndex: page.PageIndex,
-			PageSize:  page.PageSize,
-		})
-	if err != nil {
-		return nil, d.ConvertError(err)
-	}
+	// gorms.Paging 的字段为 json:"-"，使用 msgpack 编码
+	key := fmt.Sprintf("picture_book:page:%d:%d", page.PageIndex, page.PageSize)
+	return cache.GetOrLoad(ctx, key, paginationCacheTTL, func(ctx context.Context) (*gorms.Paging[*model.SPictureBook], error) {
+		paging, err := gorms.PaginationQuery(
+			dao.SPictureBook.WithContext(ctx).Order(
+				dao.SPictureBook.Position.Desc(),
+			).FindByPage, gorms.Page{
+				PageIndex: page.PageIndex,
+				PageSize:  page.PageSize,
+			})
+		if err != nil {
+			return nil, d.ConvertError(err)
+		}
 
-	return paging, nil
+		return paging, nil
+	}, cache.WithTags(CacheTag), cache.WithLoadCodec(cache.Msgpack))
 }
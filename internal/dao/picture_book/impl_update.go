@@ -0,0 +1,42 @@
+package picture_book
+
+import (
+	"context"
+	"go-agent/internal/dao"
+	"go-agent/internal/model"
+	"time"
+)
+
+func (d *Dao) Update(ctx context.Context, book *model.SPictureBook) (bool, error) {
+	b := dao.SPictureBook
+	info, err := b.WithContext(ctx).Where(b.BookId.Eq(book.BookId)).UpdateSimple(
+		b.Title.Value(book.Title),
+		b.Icon.Value(book.Icon),
+		b.CategoryId.Value(book.CategoryId),
+		b.Type.Value(book.Type),
+		b.Status.Value(book.Status),
+		b.Position.Value(book.Position),
+		b.UpdatedAt.Value(time.Now()),
+	)
+	if err != nil {
+		return false, d.ConvertError(err)
+	}
+	if info.RowsAffected > 0 {
+		d.invalidate(ctx)
+	}
+
+	return info.RowsAffected > 0, nil
+}
+
+func (d *Dao) Delete(ctx context.Context, bookID string) (bool, error) {
+	b := dao.SPictureBook
+	info, err := b.WithContext(ctx).Where(b.BookId.Eq(bookID)).Delete()
+	if err != nil {
+		return false, d.ConvertError(err)
+	}
+	if info.RowsAffected > 0 {
+		d.invalidate(ctx)
+	}
+
+	return info.RowsAffected > 0, nil
+}
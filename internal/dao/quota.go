@@ -0,0 +1,15 @@
+package dao
+
+import (
+	"context"
+	"go-agent/internal/model"
+)
+
+type Quota interface {
+	// FindBySubject 查询主体配置的全部额度
+	FindBySubject(ctx context.Context, subjectType, subjectID string) ([]*model.SQuota, error)
+	// Save 创建或更新主体一个周期的额度
+	Save(ctx context.Context, quota *model.SQuota) error
+	// Delete 删除主体一个周期的额度，返回是否有删除
+	Delete(ctx context.Context, subjectType, subjectID, period string) (bool, error)
+}
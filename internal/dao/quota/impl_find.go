@@ -0,0 +1,19 @@
+package quota
+
+import (
+	"context"
+	"go-agent/internal/dao"
+	"go-agent/internal/model"
+)
+
+func (d *Dao) FindBySubject(ctx context.Context, subjectType, subjectID string) ([]*model.SQuota, error) {
+	q := dao.SQuota
+	rows, err := q.WithContext(ctx).
+		Where(q.SubjectType.Eq(subjectType), q.SubjectId.Eq(subjectID)).
+		Find()
+	if err != nil {
+		return nil, d.ConvertError(err)
+	}
+
+	return rows, nil
+}
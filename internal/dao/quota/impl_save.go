@@ -0,0 +1,34 @@
+package quota
+
+import (
+	"context"
+	"go-agent/internal/dao"
+	"go-agent/internal/model"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+func (d *Dao) Save(ctx context.Context, quota *model.SQuota) error {
+	q := dao.SQuota
+	quota.UpdatedAt = time.Now()
+	err := q.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: q.SubjectType.ColumnName().String()}, {Name: q.SubjectId.ColumnName().String()}, {Name: q.Period.ColumnName().String()}},
+			DoUpdates: clause.AssignmentColumns([]string{q.TokenLimit.ColumnName().String(), q.UpdatedAt.ColumnName().String()}),
+		}).
+		Create(quota)
+	return d.ConvertError(err)
+}
+
+func (d *Dao) Delete(ctx context.Context, subjectType, subjectID, period string) (bool, error) {
+	q := dao.SQuota
+	info, err := q.WithContext(ctx).
+		Where(q.SubjectType.Eq(subjectType), q.SubjectId.Eq(subjectID), q.Period.Eq(period)).
+		Delete()
+	if err != nil {
+		return false, d.ConvertError(err)
+	}
+
+	return info.RowsAffected > 0, nil
+}
@@ -0,0 +1,22 @@
+package dao
+
+import (
+	"context"
+	"go-agent/internal/model"
+)
+
+type Role interface {
+	// FindByName 按角色名查询，不存在时返回 nil
+	FindByName(ctx context.Context, name string) (*model.SRole, error)
+	List(ctx context.Context) ([]*model.SRole, error)
+	// Save 创建或更新角色，并将权限替换为 permissions
+	Save(ctx context.Context, role *model.SRole, permissions []string) error
+	// Permissions 查询角色的权限
+	Permissions(ctx context.Context, roleNames ...string) ([]*model.SRolePermission, error)
+	// UserRoles 查询用户的角色名
+	UserRoles(ctx context.Context, userID string) ([]string, error)
+	// AssignUser 为用户分配角色，已分配时不做任何事
+	AssignUser(ctx context.Context, userID, roleName string) error
+	// UnassignUser 移除用户的角色，返回是否有删除
+	UnassignUser(ctx context.Context, userID, roleName string) (bool, error)
+}
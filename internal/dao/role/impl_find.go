@@ -0,0 +1,40 @@
+package role
+
+import (
+	"context"
+	"go-agent/internal/dao"
+	"go-agent/internal/model"
+)
+
+func (d *Dao) FindByName(ctx context.Context, name string) (*model.SRole, error) {
+	r := dao.SRole
+	role, err := r.WithContext(ctx).Where(r.Name.Eq(name)).First()
+	if err != nil {
+		return nil, d.ConvertError(err)
+	}
+
+	return role, nil
+}
+
+func (d *Dao) List(ctx context.Context) ([]*model.SRole, error) {
+	r := dao.SRole
+	roles, err := r.WithContext(ctx).Order(r.Name).Find()
+	if err != nil {
+		return nil, d.ConvertError(err)
+	}
+
+	return roles, nil
+}
+
+func (d *Dao) Permissions(ctx context.Context, roleNames ...string) ([]*model.SRolePermission, error) {
+	if len(roleNames) == 0 {
+		return nil, nil
+	}
+	p := dao.SRolePermission
+	rows, err := p.WithContext(ctx).Where(p.RoleName.In(roleNames...)).Order(p.RoleName, p.Permission).Find()
+	if err != nil {
+		return nil, d.ConvertError(err)
+	}
+
+	return rows, nil
+}
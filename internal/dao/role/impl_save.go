@@ -0,0 +1,45 @@
+package role
+
+import (
+	"context"
+	"go-agent/internal/dao"
+	"go-agent/internal/model"
+	"time"
+)
+
+func (d *Dao) Save(ctx context.Context, role *model.SRole, permissions []string) error {
+	err := dao.Q.Transaction(func(tx *dao.Query) error {
+		r := tx.SRole
+		exists, err := r.WithContext(ctx).Where(r.Name.Eq(role.Name)).First()
+		if err = d.ConvertError(err); err != nil {
+			return err
+		}
+		if exists == nil {
+			if err := r.WithContext(ctx).Create(role); err != nil {
+				return err
+			}
+		} else {
+			role.Id = exists.Id
+			if _, err := r.WithContext(ctx).Where(r.Id.Eq(exists.Id)).UpdateSimple(
+				r.Description.Value(role.Description),
+				r.UpdatedAt.Value(time.Now()),
+			); err != nil {
+				return err
+			}
+		}
+
+		p := tx.SRolePermission
+		if _, err := p.WithContext(ctx).Where(p.RoleName.Eq(role.Name)).Delete(); err != nil {
+			return err
+		}
+		if len(permissions) == 0 {
+			return nil
+		}
+		rows := make([]*model.SRolePermission, 0, len(permissions))
+		for _, permission := range permissions {
+			rows = append(rows, &model.SRolePermission{RoleName: role.Name, Permission: permission})
+		}
+		return p.WithContext(ctx).Create(rows...)
+	})
+	return d.ConvertError(err)
+}
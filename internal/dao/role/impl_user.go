@@ -0,0 +1,37 @@
+package role
+
+import (
+	"context"
+	"go-agent/internal/dao"
+	"go-agent/internal/model"
+
+	"gorm.io/gorm/clause"
+)
+
+func (d *Dao) UserRoles(ctx context.Context, userID string) ([]string, error) {
+	u := dao.SUserRole
+	var names []string
+	if err := u.WithContext(ctx).Where(u.UserId.Eq(userID)).Order(u.RoleName).Pluck(u.RoleName, &names); err != nil {
+		return nil, d.ConvertError(err)
+	}
+
+	return names, nil
+}
+
+func (d *Dao) AssignUser(ctx context.Context, userID, roleName string) error {
+	u := dao.SUserRole
+	err := u.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.SUserRole{UserId: userID, RoleName: roleName})
+	return d.ConvertError(err)
+}
+
+func (d *Dao) UnassignUser(ctx context.Context, userID, roleName string) (bool, error) {
+	u := dao.SUserRole
+	info, err := u.WithContext(ctx).Where(u.UserId.Eq(userID), u.RoleName.Eq(roleName)).Delete()
+	if err != nil {
+		return false, d.ConvertError(err)
+	}
+
+	return info.RowsAffected > 0, nil
+}
@@ -0,0 +1,431 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package dao
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"go-agent/internal/model"
+)
+
+func newSApiKey(db *gorm.DB, opts ...gen.DOOption) sApiKey {
+	_sApiKey := sApiKey{}
+
+	_sApiKey.sApiKeyDo.UseDB(db, opts...)
+	_sApiKey.sApiKeyDo.UseModel(&model.SApiKey{})
+
+	tableName := _sApiKey.sApiKeyDo.TableName()
+	_sApiKey.ALL = field.NewAsterisk(tableName)
+	_sApiKey.Id = field.NewUint64(tableName, "id")
+	_sApiKey.KeyId = field.NewString(tableName, "key_id")
+	_sApiKey.KeyHash = field.NewString(tableName, "key_hash")
+	_sApiKey.Prefix = field.NewString(tableName, "prefix")
+	_sApiKey.Name = field.NewString(tableName, "name")
+	_sApiKey.OwnerId = field.NewString(tableName, "owner_id")
+	_sApiKey.TeamId = field.NewString(tableName, "team_id")
+	_sApiKey.Scopes_ = field.NewString(tableName, "scopes")
+	_sApiKey.Status = field.NewString(tableName, "status")
+	_sApiKey.ExpiresAt = field.NewTime(tableName, "expires_at")
+	_sApiKey.LastUsedAt = field.NewTime(tableName, "last_used_at")
+	_sApiKey.CreatedAt = field.NewTime(tableName, "created_at")
+	_sApiKey.UpdatedAt = field.NewTime(tableName, "updated_at")
+
+	_sApiKey.fillFieldMap()
+
+	return _sApiKey
+}
+
+type sApiKey struct {
+	sApiKeyDo
+
+	ALL        field.Asterisk
+	Id         field.Uint64 // 主键id
+	KeyId      field.String // key ID
+	KeyHash    field.String // key 的 SHA-256 哈希
+	Prefix     field.String // key 前缀,用于识别
+	Name       field.String // 名称
+	OwnerId    field.String // 所属用户ID
+	TeamId     field.String // 所属团队ID
+	Scopes_    field.String // 授权范围,逗号分隔,*表示全部
+	Status     field.String // 状态,on启用,revoked已吊销
+	ExpiresAt  field.Time   // 过期时间,为空表示不过期
+	LastUsedAt field.Time   // 最后使用时间
+	CreatedAt  field.Time   // 添加时间
+	UpdatedAt  field.Time   // 更新时间
+
+	fieldMap map[string]field.Expr
+}
+
+func (s sApiKey) Table(newTableName string) *sApiKey {
+	s.sApiKeyDo.UseTable(newTableName)
+	return s.updateTableName(newTableName)
+}
+
+func (s sApiKey) As(alias string) *sApiKey {
+	s.sApiKeyDo.DO = *(s.sApiKeyDo.As(alias).(*gen.DO))
+	return s.updateTableName(alias)
+}
+
+func (s *sApiKey) updateTableName(table string) *sApiKey {
+	s.ALL = field.NewAsterisk(table)
+	s.Id = field.NewUint64(table, "id")
+	s.KeyId = field.NewString(table, "key_id")
+	s.KeyHash = field.NewString(table, "key_hash")
+	s.Prefix = field.NewString(table, "prefix")
+	s.Name = field.NewString(table, "name")
+	s.OwnerId = field.NewString(table, "owner_id")
+	s.TeamId = field.NewString(table, "team_id")
+	s.Scopes_ = field.NewString(table, "scopes")
+	s.Status = field.NewString(table, "status")
+	s.ExpiresAt = field.NewTime(table, "expires_at")
+	s.LastUsedAt = field.NewTime(table, "last_used_at")
+	s.CreatedAt = field.NewTime(table, "created_at")
+	s.UpdatedAt = field.NewTime(table, "updated_at")
+
+	s.fillFieldMap()
+
+	return s
+}
+
+func (s *sApiKey) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := s.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (s *sApiKey) fillFieldMap() {
+	s.fieldMap = make(map[string]field.Expr, 13)
+	s.fieldMap["id"] = s.Id
+	s.fieldMap["key_id"] = s.KeyId
+	s.fieldMap["key_hash"] = s.KeyHash
+	s.fieldMap["prefix"] = s.Prefix
+	s.fieldMap["name"] = s.Name
+	s.fieldMap["owner_id"] = s.OwnerId
+	s.fieldMap["team_id"] = s.TeamId
+	s.fieldMap["scopes"] = s.Scopes_
+	s.fieldMap["status"] = s.Status
+	s.fieldMap["expires_at"] = s.ExpiresAt
+	s.fieldMap["last_used_at"] = s.LastUsedAt
+	s.fieldMap["created_at"] = s.CreatedAt
+	s.fieldMap["updated_at"] = s.UpdatedAt
+}
+
+func (s sApiKey) clone(db *gorm.DB) sApiKey {
+	s.sApiKeyDo.ReplaceConnPool(db.Statement.ConnPool)
+	return s
+}
+
+func (s sApiKey) replaceDB(db *gorm.DB) sApiKey {
+	s.sApiKeyDo.ReplaceDB(db)
+	return s
+}
+
+type sApiKeyDo struct{ gen.DO }
+
+type ISApiKeyDo interface {
+	gen.SubQuery
+	Debug() ISApiKeyDo
+	WithContext(ctx context.Context) ISApiKeyDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() ISApiKeyDo
+	WriteDB() ISApiKeyDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) ISApiKeyDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) ISApiKeyDo
+	Not(conds ...gen.Condition) ISApiKeyDo
+	Or(conds ...gen.Condition) ISApiKeyDo
+	Select(conds ...field.Expr) ISApiKeyDo
+	Where(conds ...gen.Condition) ISApiKeyDo
+	Order(conds ...field.Expr) ISApiKeyDo
+	Distinct(cols ...field.Expr) ISApiKeyDo
+	Omit(cols ...field.Expr) ISApiKeyDo
+	Join(table schema.Tabler, on ...field.Expr) ISApiKeyDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) ISApiKeyDo
+	RightJoin(table schema.Tabler, on ...field.Expr) ISApiKeyDo
+	Group(cols ...field.Expr) ISApiKeyDo
+	Having(conds ...gen.Condition) ISApiKeyDo
+	Limit(limit int) ISApiKeyDo
+	Offset(offset int) ISApiKeyDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) ISApiKeyDo
+	Unscoped() ISApiKeyDo
+	Create(values ...*model.SApiKey) error
+	CreateInBatches(values []*model.SApiKey, batchSize int) error
+	Save(values ...*model.SApiKey) error
+	First() (*model.SApiKey, error)
+	Take() (*model.SApiKey, error)
+	Last() (*model.SApiKey, error)
+	Find() ([]*model.SApiKey, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.SApiKey, err error)
+	FindInBatches(result *[]*model.SApiKey, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*model.SApiKey) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) ISApiKeyDo
+	Assign(attrs ...field.AssignExpr) ISApiKeyDo
+	Joins(fields ...field.RelationField) ISApiKeyDo
+	Preload(fields ...field.RelationField) ISApiKeyDo
+	FirstOrInit() (*model.SApiKey, error)
+	FirstOrCreate() (*model.SApiKey, error)
+	FindByPage(offset int, limit int) (result []*model.SApiKey, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) ISApiKeyDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (s sApiKeyDo) Debug() ISApiKeyDo {
+	return s.withDO(s.DO.Debug())
+}
+
+func (s sApiKeyDo) WithContext(ctx context.Context) ISApiKeyDo {
+	return s.withDO(s.DO.WithContext(ctx))
+}
+
+func (s sApiKeyDo) ReadDB() ISApiKeyDo {
+	return s.Clauses(dbresolver.Read)
+}
+
+func (s sApiKeyDo) WriteDB() ISApiKeyDo {
+	return s.Clauses(dbresolver.Write)
+}
+
+func (s sApiKeyDo) Session(config *gorm.Session) ISApiKeyDo {
+	return s.withDO(s.DO.Session(config))
+}
+
+func (s sApiKeyDo) Clauses(conds ...clause.Expression) ISApiKeyDo {
+	return s.withDO(s.DO.Clauses(conds...))
+}
+
+func (s sApiKeyDo) Returning(value interface{}, columns ...string) ISApiKeyDo {
+	return s.withDO(s.DO.Returning(value, columns...))
+}
+
+func (s sApiKeyDo) Not(conds ...gen.Condition) ISApiKeyDo {
+	return s.withDO(s.DO.Not(conds...))
+}
+
+func (s sApiKeyDo) Or(conds ...gen.Condition) ISApiKeyDo {
+	return s.withDO(s.DO.Or(conds...))
+}
+
+func (s sApiKeyDo) Select(conds ...field.Expr) ISApiKeyDo {
+	return s.withDO(s.DO.Select(conds...))
+}
+
+func (s sApiKeyDo) Where(conds ...gen.Condition) ISApiKeyDo {
+	return s.withDO(s.DO.Where(conds...))
+}
+
+func (s sApiKeyDo) Order(conds ...field.Expr) ISApiKeyDo {
+	return s.withDO(s.DO.Order(conds...))
+}
+
+func (s sApiKeyDo) Distinct(cols ...field.Expr) ISApiKeyDo {
+	return s.withDO(s.DO.Distinct(cols...))
+}
+
+func (s sApiKeyDo) Omit(cols ...field.Expr) ISApiKeyDo {
+	return s.withDO(s.DO.Omit(cols...))
+}
+
+func (s sApiKeyDo) Join(table schema.Tabler, on ...field.Expr) ISApiKeyDo {
+	return s.withDO(s.DO.Join(table, on...))
+}
+
+func (s sApiKeyDo) LeftJoin(table schema.Tabler, on ...field.Expr) ISApiKeyDo {
+	return s.withDO(s.DO.LeftJoin(table, on...))
+}
+
+func (s sApiKeyDo) RightJoin(table schema.Tabler, on ...field.Expr) ISApiKeyDo {
+	return s.withDO(s.DO.RightJoin(table, on...))
+}
+
+func (s sApiKeyDo) Group(cols ...field.Expr) ISApiKeyDo {
+	return s.withDO(s.DO.Group(cols...))
+}
+
+func (s sApiKeyDo) Having(conds ...gen.Condition) ISApiKeyDo {
+	return s.withDO(s.DO.Having(conds...))
+}
+
+func (s sApiKeyDo) Limit(limit int) ISApiKeyDo {
+	return s.withDO(s.DO.Limit(limit))
+}
+
+func (s sApiKeyDo) Offset(offset int) ISApiKeyDo {
+	return s.withDO(s.DO.Offset(offset))
+}
+
+func (s sApiKeyDo) Scopes(funcs ...func(gen.Dao) gen.Dao) ISApiKeyDo {
+	return s.withDO(s.DO.Scopes(funcs...))
+}
+
+func (s sApiKeyDo) Unscoped() ISApiKeyDo {
+	return s.withDO(s.DO.Unscoped())
+}
+
+func (s sApiKeyDo) Create(values ...*model.SApiKey) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return s.DO.Create(values)
+}
+
+func (s sApiKeyDo) CreateInBatches(values []*model.SApiKey, batchSize int) error {
+	return s.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (s sApiKeyDo) Save(values ...*model.SApiKey) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return s.DO.Save(values)
+}
+
+func (s sApiKeyDo) First() (*model.SApiKey, error) {
+	if result, err := s.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SApiKey), nil
+	}
+}
+
+func (s sApiKeyDo) Take() (*model.SApiKey, error) {
+	if result, err := s.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SApiKey), nil
+	}
+}
+
+func (s sApiKeyDo) Last() (*model.SApiKey, error) {
+	if result, err := s.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SApiKey), nil
+	}
+}
+
+func (s sApiKeyDo) Find() ([]*model.SApiKey, error) {
+	result, err := s.DO.Find()
+	return result.([]*model.SApiKey), err
+}
+
+func (s sApiKeyDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.SApiKey, err error) {
+	buf := make([]*model.SApiKey, 0, batchSize)
+	err = s.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (s sApiKeyDo) FindInBatches(result *[]*model.SApiKey, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return s.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (s sApiKeyDo) Attrs(attrs ...field.AssignExpr) ISApiKeyDo {
+	return s.withDO(s.DO.Attrs(attrs...))
+}
+
+func (s sApiKeyDo) Assign(attrs ...field.AssignExpr) ISApiKeyDo {
+	return s.withDO(s.DO.Assign(attrs...))
+}
+
+func (s sApiKeyDo) Joins(fields ...field.RelationField) ISApiKeyDo {
+	for _, _f := range fields {
+		s = *s.withDO(s.DO.Joins(_f))
+	}
+	return &s
+}
+
+func (s sApiKeyDo) Preload(fields ...field.RelationField) ISApiKeyDo {
+	for _, _f := range fields {
+		s = *s.withDO(s.DO.Preload(_f))
+	}
+	return &s
+}
+
+func (s sApiKeyDo) FirstOrInit() (*model.SApiKey, error) {
+	if result, err := s.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SApiKey), nil
+	}
+}
+
+func (s sApiKeyDo) FirstOrCreate() (*model.SApiKey, error) {
+	if result, err := s.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SApiKey), nil
+	}
+}
+
+func (s sApiKeyDo) FindByPage(offset int, limit int) (result []*model.SApiKey, count int64, err error) {
+	result, err = s.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = s.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (s sApiKeyDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = s.Count()
+	if err != nil {
+		return
+	}
+
+	err = s.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (s sApiKeyDo) Scan(result interface{}) (err error) {
+	return s.DO.Scan(result)
+}
+
+func (s sApiKeyDo) Delete(models ...*model.SApiKey) (result gen.ResultInfo, err error) {
+	return s.DO.Delete(models)
+}
+
+func (s *sApiKeyDo) withDO(do gen.Dao) *sApiKeyDo {
+	s.DO = *do.(*gen.DO)
+	return s
+}
@@ -0,0 +1,419 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package dao
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"go-agent/internal/model"
+)
+
+func newSCronRun(db *gorm.DB, opts ...gen.DOOption) sCronRun {
+	_sCronRun := sCronRun{}
+
+	_sCronRun.sCronRunDo.UseDB(db, opts...)
+	_sCronRun.sCronRunDo.UseModel(&model.SCronRun{})
+
+	tableName := _sCronRun.sCronRunDo.TableName()
+	_sCronRun.ALL = field.NewAsterisk(tableName)
+	_sCronRun.Id = field.NewUint64(tableName, "id")
+	_sCronRun.Name = field.NewString(tableName, "name")
+	_sCronRun.Node = field.NewString(tableName, "node")
+	_sCronRun.Status = field.NewString(tableName, "status")
+	_sCronRun.Error = field.NewString(tableName, "error")
+	_sCronRun.ScheduledAt = field.NewTime(tableName, "scheduled_at")
+	_sCronRun.StartedAt = field.NewTime(tableName, "started_at")
+	_sCronRun.FinishedAt = field.NewTime(tableName, "finished_at")
+	_sCronRun.DurationMs = field.NewInt64(tableName, "duration_ms")
+	_sCronRun.CreatedAt = field.NewTime(tableName, "created_at")
+
+	_sCronRun.fillFieldMap()
+
+	return _sCronRun
+}
+
+type sCronRun struct {
+	sCronRunDo
+
+	ALL         field.Asterisk
+	Id          field.Uint64 // 主键id
+	Name        field.String // 任务名称
+	Node        field.String // 执行节点
+	Status      field.String // 状态,success成功,failed失败,timeout超时
+	Error       field.String // 错误信息
+	ScheduledAt field.Time   // 计划执行时间
+	StartedAt   field.Time   // 开始时间
+	FinishedAt  field.Time   // 结束时间
+	DurationMs  field.Int64  // 耗时(毫秒)
+	CreatedAt   field.Time   // 添加时间
+
+	fieldMap map[string]field.Expr
+}
+
+func (s sCronRun) Table(newTableName string) *sCronRun {
+	s.sCronRunDo.UseTable(newTableName)
+	return s.updateTableName(newTableName)
+}
+
+func (s sCronRun) As(alias string) *sCronRun {
+	s.sCronRunDo.DO = *(s.sCronRunDo.As(alias).(*gen.DO))
+	return s.updateTableName(alias)
+}
+
+func (s *sCronRun) updateTableName(table string) *sCronRun {
+	s.ALL = field.NewAsterisk(table)
+	s.Id = field.NewUint64(table, "id")
+	s.Name = field.NewString(table, "name")
+	s.Node = field.NewString(table, "node")
+	s.Status = field.NewString(table, "status")
+	s.Error = field.NewString(table, "error")
+	s.ScheduledAt = field.NewTime(table, "scheduled_at")
+	s.StartedAt = field.NewTime(table, "started_at")
+	s.FinishedAt = field.NewTime(table, "finished_at")
+	s.DurationMs = field.NewInt64(table, "duration_ms")
+	s.CreatedAt = field.NewTime(table, "created_at")
+
+	s.fillFieldMap()
+
+	return s
+}
+
+func (s *sCronRun) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := s.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (s *sCronRun) fillFieldMap() {
+	s.fieldMap = make(map[string]field.Expr, 10)
+	s.fieldMap["id"] = s.Id
+	s.fieldMap["name"] = s.Name
+	s.fieldMap["node"] = s.Node
+	s.fieldMap["status"] = s.Status
+	s.fieldMap["error"] = s.Error
+	s.fieldMap["scheduled_at"] = s.ScheduledAt
+	s.fieldMap["started_at"] = s.StartedAt
+	s.fieldMap["finished_at"] = s.FinishedAt
+	s.fieldMap["duration_ms"] = s.DurationMs
+	s.fieldMap["created_at"] = s.CreatedAt
+}
+
+func (s sCronRun) clone(db *gorm.DB) sCronRun {
+	s.sCronRunDo.ReplaceConnPool(db.Statement.ConnPool)
+	return s
+}
+
+func (s sCronRun) replaceDB(db *gorm.DB) sCronRun {
+	s.sCronRunDo.ReplaceDB(db)
+	return s
+}
+
+type sCronRunDo struct{ gen.DO }
+
+type ISCronRunDo interface {
+	gen.SubQuery
+	Debug() ISCronRunDo
+	WithContext(ctx context.Context) ISCronRunDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() ISCronRunDo
+	WriteDB() ISCronRunDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) ISCronRunDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) ISCronRunDo
+	Not(conds ...gen.Condition) ISCronRunDo
+	Or(conds ...gen.Condition) ISCronRunDo
+	Select(conds ...field.Expr) ISCronRunDo
+	Where(conds ...gen.Condition) ISCronRunDo
+	Order(conds ...field.Expr) ISCronRunDo
+	Distinct(cols ...field.Expr) ISCronRunDo
+	Omit(cols ...field.Expr) ISCronRunDo
+	Join(table schema.Tabler, on ...field.Expr) ISCronRunDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) ISCronRunDo
+	RightJoin(table schema.Tabler, on ...field.Expr) ISCronRunDo
+	Group(cols ...field.Expr) ISCronRunDo
+	Having(conds ...gen.Condition) ISCronRunDo
+	Limit(limit int) ISCronRunDo
+	Offset(offset int) ISCronRunDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) ISCronRunDo
+	Unscoped() ISCronRunDo
+	Create(values ...*model.SCronRun) error
+	CreateInBatches(values []*model.SCronRun, batchSize int) error
+	Save(values ...*model.SCronRun) error
+	First() (*model.SCronRun, error)
+	Take() (*model.SCronRun, error)
+	Last() (*model.SCronRun, error)
+	Find() ([]*model.SCronRun, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.SCronRun, err error)
+	FindInBatches(result *[]*model.SCronRun, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*model.SCronRun) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) ISCronRunDo
+	Assign(attrs ...field.AssignExpr) ISCronRunDo
+	Joins(fields ...field.RelationField) ISCronRunDo
+	Preload(fields ...field.RelationField) ISCronRunDo
+	FirstOrInit() (*model.SCronRun, error)
+	FirstOrCreate() (*model.SCronRun, error)
+	FindByPage(offset int, limit int) (result []*model.SCronRun, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) ISCronRunDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (s sCronRunDo) Debug() ISCronRunDo {
+	return s.withDO(s.DO.Debug())
+}
+
+func (s sCronRunDo) WithContext(ctx context.Context) ISCronRunDo {
+	return s.withDO(s.DO.WithContext(ctx))
+}
+
+func (s sCronRunDo) ReadDB() ISCronRunDo {
+	return s.Clauses(dbresolver.Read)
+}
+
+func (s sCronRunDo) WriteDB() ISCronRunDo {
+	return s.Clauses(dbresolver.Write)
+}
+
+func (s sCronRunDo) Session(config *gorm.Session) ISCronRunDo {
+	return s.withDO(s.DO.Session(config))
+}
+
+func (s sCronRunDo) Clauses(conds ...clause.Expression) ISCronRunDo {
+	return s.withDO(s.DO.Clauses(conds...))
+}
+
+func (s sCronRunDo) Returning(value interface{}, columns ...string) ISCronRunDo {
+	return s.withDO(s.DO.Returning(value, columns...))
+}
+
+func (s sCronRunDo) Not(conds ...gen.Condition) ISCronRunDo {
+	return s.withDO(s.DO.Not(conds...))
+}
+
+func (s sCronRunDo) Or(conds ...gen.Condition) ISCronRunDo {
+	return s.withDO(s.DO.Or(conds...))
+}
+
+func (s sCronRunDo) Select(conds ...field.Expr) ISCronRunDo {
+	return s.withDO(s.DO.Select(conds...))
+}
+
+func (s sCronRunDo) Where(conds ...gen.Condition) ISCronRunDo {
+	return s.withDO(s.DO.Where(conds...))
+}
+
+func (s sCronRunDo) Order(conds ...field.Expr) ISCronRunDo {
+	return s.withDO(s.DO.Order(conds...))
+}
+
+func (s sCronRunDo) Distinct(cols ...field.Expr) ISCronRunDo {
+	return s.withDO(s.DO.Distinct(cols...))
+}
+
+func (s sCronRunDo) Omit(cols ...field.Expr) ISCronRunDo {
+	return s.withDO(s.DO.Omit(cols...))
+}
+
+func (s sCronRunDo) Join(table schema.Tabler, on ...field.Expr) ISCronRunDo {
+	return s.withDO(s.DO.Join(table, on...))
+}
+
+func (s sCronRunDo) LeftJoin(table schema.Tabler, on ...field.Expr) ISCronRunDo {
+	return s.withDO(s.DO.LeftJoin(table, on...))
+}
+
+func (s sCronRunDo) RightJoin(table schema.Tabler, on ...field.Expr) ISCronRunDo {
+	return s.withDO(s.DO.RightJoin(table, on...))
+}
+
+func (s sCronRunDo) Group(cols ...field.Expr) ISCronRunDo {
+	return s.withDO(s.DO.Group(cols...))
+}
+
+func (s sCronRunDo) Having(conds ...gen.Condition) ISCronRunDo {
+	return s.withDO(s.DO.Having(conds...))
+}
+
+func (s sCronRunDo) Limit(limit int) ISCronRunDo {
+	return s.withDO(s.DO.Limit(limit))
+}
+
+func (s sCronRunDo) Offset(offset int) ISCronRunDo {
+	return s.withDO(s.DO.Offset(offset))
+}
+
+func (s sCronRunDo) Scopes(funcs ...func(gen.Dao) gen.Dao) ISCronRunDo {
+	return s.withDO(s.DO.Scopes(funcs...))
+}
+
+func (s sCronRunDo) Unscoped() ISCronRunDo {
+	return s.withDO(s.DO.Unscoped())
+}
+
+func (s sCronRunDo) Create(values ...*model.SCronRun) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return s.DO.Create(values)
+}
+
+func (s sCronRunDo) CreateInBatches(values []*model.SCronRun, batchSize int) error {
+	return s.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (s sCronRunDo) Save(values ...*model.SCronRun) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return s.DO.Save(values)
+}
+
+func (s sCronRunDo) First() (*model.SCronRun, error) {
+	if result, err := s.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SCronRun), nil
+	}
+}
+
+func (s sCronRunDo) Take() (*model.SCronRun, error) {
+	if result, err := s.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SCronRun), nil
+	}
+}
+
+func (s sCronRunDo) Last() (*model.SCronRun, error) {
+	if result, err := s.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SCronRun), nil
+	}
+}
+
+func (s sCronRunDo) Find() ([]*model.SCronRun, error) {
+	result, err := s.DO.Find()
+	return result.([]*model.SCronRun), err
+}
+
+func (s sCronRunDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.SCronRun, err error) {
+	buf := make([]*model.SCronRun, 0, batchSize)
+	err = s.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (s sCronRunDo) FindInBatches(result *[]*model.SCronRun, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return s.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (s sCronRunDo) Attrs(attrs ...field.AssignExpr) ISCronRunDo {
+	return s.withDO(s.DO.Attrs(attrs...))
+}
+
+func (s sCronRunDo) Assign(attrs ...field.AssignExpr) ISCronRunDo {
+	return s.withDO(s.DO.Assign(attrs...))
+}
+
+func (s sCronRunDo) Joins(fields ...field.RelationField) ISCronRunDo {
+	for _, _f := range fields {
+		s = *s.withDO(s.DO.Joins(_f))
+	}
+	return &s
+}
+
+func (s sCronRunDo) Preload(fields ...field.RelationField) ISCronRunDo {
+	for _, _f := range fields {
+		s = *s.withDO(s.DO.Preload(_f))
+	}
+	return &s
+}
+
+func (s sCronRunDo) FirstOrInit() (*model.SCronRun, error) {
+	if result, err := s.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SCronRun), nil
+	}
+}
+
+func (s sCronRunDo) FirstOrCreate() (*model.SCronRun, error) {
+	if result, err := s.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SCronRun), nil
+	}
+}
+
+func (s sCronRunDo) FindByPage(offset int, limit int) (result []*model.SCronRun, count int64, err error) {
+	result, err = s.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = s.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (s sCronRunDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = s.Count()
+	if err != nil {
+		return
+	}
+
+	err = s.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (s sCronRunDo) Scan(result interface{}) (err error) {
+	return s.DO.Scan(result)
+}
+
+func (s sCronRunDo) Delete(models ...*model.SCronRun) (result gen.ResultInfo, err error) {
+	return s.DO.Delete(models)
+}
+
+func (s *sCronRunDo) withDO(do gen.Dao) *sCronRunDo {
+	s.DO = *do.(*gen.DO)
+	return s
+}
@@ -0,0 +1,431 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package dao
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"go-agent/internal/model"
+)
+
+func newSOutbox(db *gorm.DB, opts ...gen.DOOption) sOutbox {
+	_sOutbox := sOutbox{}
+
+	_sOutbox.sOutboxDo.UseDB(db, opts...)
+	_sOutbox.sOutboxDo.UseModel(&model.SOutbox{})
+
+	tableName := _sOutbox.sOutboxDo.TableName()
+	_sOutbox.ALL = field.NewAsterisk(tableName)
+	_sOutbox.Id = field.NewUint64(tableName, "id")
+	_sOutbox.MessageId = field.NewString(tableName, "message_id")
+	_sOutbox.Topic = field.NewString(tableName, "topic")
+	_sOutbox.Type = field.NewString(tableName, "type")
+	_sOutbox.Body = field.NewString(tableName, "body")
+	_sOutbox.Headers = field.NewString(tableName, "headers")
+	_sOutbox.Status = field.NewString(tableName, "status")
+	_sOutbox.Attempts = field.NewInt(tableName, "attempts")
+	_sOutbox.LastError = field.NewString(tableName, "last_error")
+	_sOutbox.NextRetryAt = field.NewTime(tableName, "next_retry_at")
+	_sOutbox.SentAt = field.NewTime(tableName, "sent_at")
+	_sOutbox.CreatedAt = field.NewTime(tableName, "created_at")
+	_sOutbox.UpdatedAt = field.NewTime(tableName, "updated_at")
+
+	_sOutbox.fillFieldMap()
+
+	return _sOutbox
+}
+
+type sOutbox struct {
+	sOutboxDo
+
+	ALL         field.Asterisk
+	Id          field.Uint64 // 主键id
+	MessageId   field.String // 消息ID,消费方据此去重
+	Topic       field.String // 主题
+	Type        field.String // 消息类型
+	Body        field.String // 消息内容
+	Headers     field.String // 消息头(JSON)
+	Status      field.String // 状态,pending待发送,sent已发送,failed超过重试次数
+	Attempts    field.Int    // 发送次数
+	LastError   field.String // 最近一次发送失败的错误
+	NextRetryAt field.Time   // 下次发送时间
+	SentAt      field.Time   // 发送时间
+	CreatedAt   field.Time   // 添加时间
+	UpdatedAt   field.Time   // 更新时间
+
+	fieldMap map[string]field.Expr
+}
+
+func (s sOutbox) Table(newTableName string) *sOutbox {
+	s.sOutboxDo.UseTable(newTableName)
+	return s.updateTableName(newTableName)
+}
+
+func (s sOutbox) As(alias string) *sOutbox {
+	s.sOutboxDo.DO = *(s.sOutboxDo.As(alias).(*gen.DO))
+	return s.updateTableName(alias)
+}
+
+func (s *sOutbox) updateTableName(table string) *sOutbox {
+	s.ALL = field.NewAsterisk(table)
+	s.Id = field.NewUint64(table, "id")
+	s.MessageId = field.NewString(table, "message_id")
+	s.Topic = field.NewString(table, "topic")
+	s.Type = field.NewString(table, "type")
+	s.Body = field.NewString(table, "body")
+	s.Headers = field.NewString(table, "headers")
+	s.Status = field.NewString(table, "status")
+	s.Attempts = field.NewInt(table, "attempts")
+	s.LastError = field.NewString(table, "last_error")
+	s.NextRetryAt = field.NewTime(table, "next_retry_at")
+	s.SentAt = field.NewTime(table, "sent_at")
+	s.CreatedAt = field.NewTime(table, "created_at")
+	s.UpdatedAt = field.NewTime(table, "updated_at")
+
+	s.fillFieldMap()
+
+	return s
+}
+
+func (s *sOutbox) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := s.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (s *sOutbox) fillFieldMap() {
+	s.fieldMap = make(map[string]field.Expr, 13)
+	s.fieldMap["id"] = s.Id
+	s.fieldMap["message_id"] = s.MessageId
+	s.fieldMap["topic"] = s.Topic
+	s.fieldMap["type"] = s.Type
+	s.fieldMap["body"] = s.Body
+	s.fieldMap["headers"] = s.Headers
+	s.fieldMap["status"] = s.Status
+	s.fieldMap["attempts"] = s.Attempts
+	s.fieldMap["last_error"] = s.LastError
+	s.fieldMap["next_retry_at"] = s.NextRetryAt
+	s.fieldMap["sent_at"] = s.SentAt
+	s.fieldMap["created_at"] = s.CreatedAt
+	s.fieldMap["updated_at"] = s.UpdatedAt
+}
+
+func (s sOutbox) clone(db *gorm.DB) sOutbox {
+	s.sOutboxDo.ReplaceConnPool(db.Statement.ConnPool)
+	return s
+}
+
+func (s sOutbox) replaceDB(db *gorm.DB) sOutbox {
+	s.sOutboxDo.ReplaceDB(db)
+	return s
+}
+
+type sOutboxDo struct{ gen.DO }
+
+type ISOutboxDo interface {
+	gen.SubQuery
+	Debug() ISOutboxDo
+	WithContext(ctx context.Context) ISOutboxDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() ISOutboxDo
+	WriteDB() ISOutboxDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) ISOutboxDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) ISOutboxDo
+	Not(conds ...gen.Condition) ISOutboxDo
+	Or(conds ...gen.Condition) ISOutboxDo
+	Select(conds ...field.Expr) ISOutboxDo
+	Where(conds ...gen.Condition) ISOutboxDo
+	Order(conds ...field.Expr) ISOutboxDo
+	Distinct(cols ...field.Expr) ISOutboxDo
+	Omit(cols ...field.Expr) ISOutboxDo
+	Join(table schema.Tabler, on ...field.Expr) ISOutboxDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) ISOutboxDo
+	RightJoin(table schema.Tabler, on ...field.Expr) ISOutboxDo
+	Group(cols ...field.Expr) ISOutboxDo
+	Having(conds ...gen.Condition) ISOutboxDo
+	Limit(limit int) ISOutboxDo
+	Offset(offset int) ISOutboxDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) ISOutboxDo
+	Unscoped() ISOutboxDo
+	Create(values ...*model.SOutbox) error
+	CreateInBatches(values []*model.SOutbox, batchSize int) error
+	Save(values ...*model.SOutbox) error
+	First() (*model.SOutbox, error)
+	Take() (*model.SOutbox, error)
+	Last() (*model.SOutbox, error)
+	Find() ([]*model.SOutbox, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.SOutbox, err error)
+	FindInBatches(result *[]*model.SOutbox, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*model.SOutbox) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) ISOutboxDo
+	Assign(attrs ...field.AssignExpr) ISOutboxDo
+	Joins(fields ...field.RelationField) ISOutboxDo
+	Preload(fields ...field.RelationField) ISOutboxDo
+	FirstOrInit() (*model.SOutbox, error)
+	FirstOrCreate() (*model.SOutbox, error)
+	FindByPage(offset int, limit int) (result []*model.SOutbox, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) ISOutboxDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (s sOutboxDo) Debug() ISOutboxDo {
+	return s.withDO(s.DO.Debug())
+}
+
+func (s sOutboxDo) WithContext(ctx context.Context) ISOutboxDo {
+	return s.withDO(s.DO.WithContext(ctx))
+}
+
+func (s sOutboxDo) ReadDB() ISOutboxDo {
+	return s.Clauses(dbresolver.Read)
+}
+
+func (s sOutboxDo) WriteDB() ISOutboxDo {
+	return s.Clauses(dbresolver.Write)
+}
+
+func (s sOutboxDo) Session(config *gorm.Session) ISOutboxDo {
+	return s.withDO(s.DO.Session(config))
+}
+
+func (s sOutboxDo) Clauses(conds ...clause.Expression) ISOutboxDo {
+	return s.withDO(s.DO.Clauses(conds...))
+}
+
+func (s sOutboxDo) Returning(value interface{}, columns ...string) ISOutboxDo {
+	return s.withDO(s.DO.Returning(value, columns...))
+}
+
+func (s sOutboxDo) Not(conds ...gen.Condition) ISOutboxDo {
+	return s.withDO(s.DO.Not(conds...))
+}
+
+func (s sOutboxDo) Or(conds ...gen.Condition) ISOutboxDo {
+	return s.withDO(s.DO.Or(conds...))
+}
+
+func (s sOutboxDo) Select(conds ...field.Expr) ISOutboxDo {
+	return s.withDO(s.DO.Select(conds...))
+}
+
+func (s sOutboxDo) Where(conds ...gen.Condition) ISOutboxDo {
+	return s.withDO(s.DO.Where(conds...))
+}
+
+func (s sOutboxDo) Order(conds ...field.Expr) ISOutboxDo {
+	return s.withDO(s.DO.Order(conds...))
+}
+
+func (s sOutboxDo) Distinct(cols ...field.Expr) ISOutboxDo {
+	return s.withDO(s.DO.Distinct(cols...))
+}
+
+func (s sOutboxDo) Omit(cols ...field.Expr) ISOutboxDo {
+	return s.withDO(s.DO.Omit(cols...))
+}
+
+func (s sOutboxDo) Join(table schema.Tabler, on ...field.Expr) ISOutboxDo {
+	return s.withDO(s.DO.Join(table, on...))
+}
+
+func (s sOutboxDo) LeftJoin(table schema.Tabler, on ...field.Expr) ISOutboxDo {
+	return s.withDO(s.DO.LeftJoin(table, on...))
+}
+
+func (s sOutboxDo) RightJoin(table schema.Tabler, on ...field.Expr) ISOutboxDo {
+	return s.withDO(s.DO.RightJoin(table, on...))
+}
+
+func (s sOutboxDo) Group(cols ...field.Expr) ISOutboxDo {
+	return s.withDO(s.DO.Group(cols...))
+}
+
+func (s sOutboxDo) Having(conds ...gen.Condition) ISOutboxDo {
+	return s.withDO(s.DO.Having(conds...))
+}
+
+func (s sOutboxDo) Limit(limit int) ISOutboxDo {
+	return s.withDO(s.DO.Limit(limit))
+}
+
+func (s sOutboxDo) Offset(offset int) ISOutboxDo {
+	return s.withDO(s.DO.Offset(offset))
+}
+
+func (s sOutboxDo) Scopes(funcs ...func(gen.Dao) gen.Dao) ISOutboxDo {
+	return s.withDO(s.DO.Scopes(funcs...))
+}
+
+func (s sOutboxDo) Unscoped() ISOutboxDo {
+	return s.withDO(s.DO.Unscoped())
+}
+
+func (s sOutboxDo) Create(values ...*model.SOutbox) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return s.DO.Create(values)
+}
+
+func (s sOutboxDo) CreateInBatches(values []*model.SOutbox, batchSize int) error {
+	return s.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (s sOutboxDo) Save(values ...*model.SOutbox) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return s.DO.Save(values)
+}
+
+func (s sOutboxDo) First() (*model.SOutbox, error) {
+	if result, err := s.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SOutbox), nil
+	}
+}
+
+func (s sOutboxDo) Take() (*model.SOutbox, error) {
+	if result, err := s.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SOutbox), nil
+	}
+}
+
+func (s sOutboxDo) Last() (*model.SOutbox, error) {
+	if result, err := s.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SOutbox), nil
+	}
+}
+
+func (s sOutboxDo) Find() ([]*model.SOutbox, error) {
+	result, err := s.DO.Find()
+	return result.([]*model.SOutbox), err
+}
+
+func (s sOutboxDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.SOutbox, err error) {
+	buf := make([]*model.SOutbox, 0, batchSize)
+	err = s.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (s sOutboxDo) FindInBatches(result *[]*model.SOutbox, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return s.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (s sOutboxDo) Attrs(attrs ...field.AssignExpr) ISOutboxDo {
+	return s.withDO(s.DO.Attrs(attrs...))
+}
+
+func (s sOutboxDo) Assign(attrs ...field.AssignExpr) ISOutboxDo {
+	return s.withDO(s.DO.Assign(attrs...))
+}
+
+func (s sOutboxDo) Joins(fields ...field.RelationField) ISOutboxDo {
+	for _, _f := range fields {
+		s = *s.withDO(s.DO.Joins(_f))
+	}
+	return &s
+}
+
+func (s sOutboxDo) Preload(fields ...field.RelationField) ISOutboxDo {
+	for _, _f := range fields {
+		s = *s.withDO(s.DO.Preload(_f))
+	}
+	return &s
+}
+
+func (s sOutboxDo) FirstOrInit() (*model.SOutbox, error) {
+	if result, err := s.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SOutbox), nil
+	}
+}
+
+func (s sOutboxDo) FirstOrCreate() (*model.SOutbox, error) {
+	if result, err := s.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SOutbox), nil
+	}
+}
+
+func (s sOutboxDo) FindByPage(offset int, limit int) (result []*model.SOutbox, count int64, err error) {
+	result, err = s.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = s.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (s sOutboxDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = s.Count()
+	if err != nil {
+		return
+	}
+
+	err = s.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (s sOutboxDo) Scan(result interface{}) (err error) {
+	return s.DO.Scan(result)
+}
+
+func (s sOutboxDo) Delete(models ...*model.SOutbox) (result gen.ResultInfo, err error) {
+	return s.DO.Delete(models)
+}
+
+func (s *sOutboxDo) withDO(do gen.Dao) *sOutboxDo {
+	s.DO = *do.(*gen.DO)
+	return s
+}
@@ -0,0 +1,407 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package dao
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"go-agent/internal/model"
+)
+
+func newSQuota(db *gorm.DB, opts ...gen.DOOption) sQuota {
+	_sQuota := sQuota{}
+
+	_sQuota.sQuotaDo.UseDB(db, opts...)
+	_sQuota.sQuotaDo.UseModel(&model.SQuota{})
+
+	tableName := _sQuota.sQuotaDo.TableName()
+	_sQuota.ALL = field.NewAsterisk(tableName)
+	_sQuota.Id = field.NewUint64(tableName, "id")
+	_sQuota.SubjectType = field.NewString(tableName, "subject_type")
+	_sQuota.SubjectId = field.NewString(tableName, "subject_id")
+	_sQuota.Period = field.NewString(tableName, "period")
+	_sQuota.TokenLimit = field.NewInt64(tableName, "token_limit")
+	_sQuota.CreatedAt = field.NewTime(tableName, "created_at")
+	_sQuota.UpdatedAt = field.NewTime(tableName, "updated_at")
+
+	_sQuota.fillFieldMap()
+
+	return _sQuota
+}
+
+type sQuota struct {
+	sQuotaDo
+
+	ALL         field.Asterisk
+	Id          field.Uint64 // 主键id
+	SubjectType field.String // 主体类型,user用户,team团队,api_key
+	SubjectId   field.String // 主体ID
+	Period      field.String // 周期,daily每日,monthly每月
+	TokenLimit  field.Int64  // 周期内可用的 token 数
+	CreatedAt   field.Time   // 添加时间
+	UpdatedAt   field.Time   // 更新时间
+
+	fieldMap map[string]field.Expr
+}
+
+func (s sQuota) Table(newTableName string) *sQuota {
+	s.sQuotaDo.UseTable(newTableName)
+	return s.updateTableName(newTableName)
+}
+
+func (s sQuota) As(alias string) *sQuota {
+	s.sQuotaDo.DO = *(s.sQuotaDo.As(alias).(*gen.DO))
+	return s.updateTableName(alias)
+}
+
+func (s *sQuota) updateTableName(table string) *sQuota {
+	s.ALL = field.NewAsterisk(table)
+	s.Id = field.NewUint64(table, "id")
+	s.SubjectType = field.NewString(table, "subject_type")
+	s.SubjectId = field.NewString(table, "subject_id")
+	s.Period = field.NewString(table, "period")
+	s.TokenLimit = field.NewInt64(table, "token_limit")
+	s.CreatedAt = field.NewTime(table, "created_at")
+	s.UpdatedAt = field.NewTime(table, "updated_at")
+
+	s.fillFieldMap()
+
+	return s
+}
+
+func (s *sQuota) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := s.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (s *sQuota) fillFieldMap() {
+	s.fieldMap = make(map[string]field.Expr, 7)
+	s.fieldMap["id"] = s.Id
+	s.fieldMap["subject_type"] = s.SubjectType
+	s.fieldMap["subject_id"] = s.SubjectId
+	s.fieldMap["period"] = s.Period
+	s.fieldMap["token_limit"] = s.TokenLimit
+	s.fieldMap["created_at"] = s.CreatedAt
+	s.fieldMap["updated_at"] = s.UpdatedAt
+}
+
+func (s sQuota) clone(db *gorm.DB) sQuota {
+	s.sQuotaDo.ReplaceConnPool(db.Statement.ConnPool)
+	return s
+}
+
+func (s sQuota) replaceDB(db *gorm.DB) sQuota {
+	s.sQuotaDo.ReplaceDB(db)
+	return s
+}
+
+type sQuotaDo struct{ gen.DO }
+
+type ISQuotaDo interface {
+	gen.SubQuery
+	Debug() ISQuotaDo
+	WithContext(ctx context.Context) ISQuotaDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() ISQuotaDo
+	WriteDB() ISQuotaDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) ISQuotaDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) ISQuotaDo
+	Not(conds ...gen.Condition) ISQuotaDo
+	Or(conds ...gen.Condition) ISQuotaDo
+	Select(conds ...field.Expr) ISQuotaDo
+	Where(conds ...gen.Condition) ISQuotaDo
+	Order(conds ...field.Expr) ISQuotaDo
+	Distinct(cols ...field.Expr) ISQuotaDo
+	Omit(cols ...field.Expr) ISQuotaDo
+	Join(table schema.Tabler, on ...field.Expr) ISQuotaDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) ISQuotaDo
+	RightJoin(table schema.Tabler, on ...field.Expr) ISQuotaDo
+	Group(cols ...field.Expr) ISQuotaDo
+	Having(conds ...gen.Condition) ISQuotaDo
+	Limit(limit int) ISQuotaDo
+	Offset(offset int) ISQuotaDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) ISQuotaDo
+	Unscoped() ISQuotaDo
+	Create(values ...*model.SQuota) error
+	CreateInBatches(values []*model.SQuota, batchSize int) error
+	Save(values ...*model.SQuota) error
+	First() (*model.SQuota, error)
+	Take() (*model.SQuota, error)
+	Last() (*model.SQuota, error)
+	Find() ([]*model.SQuota, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.SQuota, err error)
+	FindInBatches(result *[]*model.SQuota, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*model.SQuota) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) ISQuotaDo
+	Assign(attrs ...field.AssignExpr) ISQuotaDo
+	Joins(fields ...field.RelationField) ISQuotaDo
+	Preload(fields ...field.RelationField) ISQuotaDo
+	FirstOrInit() (*model.SQuota, error)
+	FirstOrCreate() (*model.SQuota, error)
+	FindByPage(offset int, limit int) (result []*model.SQuota, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) ISQuotaDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (s sQuotaDo) Debug() ISQuotaDo {
+	return s.withDO(s.DO.Debug())
+}
+
+func (s sQuotaDo) WithContext(ctx context.Context) ISQuotaDo {
+	return s.withDO(s.DO.WithContext(ctx))
+}
+
+func (s sQuotaDo) ReadDB() ISQuotaDo {
+	return s.Clauses(dbresolver.Read)
+}
+
+func (s sQuotaDo) WriteDB() ISQuotaDo {
+	return s.Clauses(dbresolver.Write)
+}
+
+func (s sQuotaDo) Session(config *gorm.Session) ISQuotaDo {
+	return s.withDO(s.DO.Session(config))
+}
+
+func (s sQuotaDo) Clauses(conds ...clause.Expression) ISQuotaDo {
+	return s.withDO(s.DO.Clauses(conds...))
+}
+
+func (s sQuotaDo) Returning(value interface{}, columns ...string) ISQuotaDo {
+	return s.withDO(s.DO.Returning(value, columns...))
+}
+
+func (s sQuotaDo) Not(conds ...gen.Condition) ISQuotaDo {
+	return s.withDO(s.DO.Not(conds...))
+}
+
+func (s sQuotaDo) Or(conds ...gen.Condition) ISQuotaDo {
+	return s.withDO(s.DO.Or(conds...))
+}
+
+func (s sQuotaDo) Select(conds ...field.Expr) ISQuotaDo {
+	return s.withDO(s.DO.Select(conds...))
+}
+
+func (s sQuotaDo) Where(conds ...gen.Condition) ISQuotaDo {
+	return s.withDO(s.DO.Where(conds...))
+}
+
+func (s sQuotaDo) Order(conds ...field.Expr) ISQuotaDo {
+	return s.withDO(s.DO.Order(conds...))
+}
+
+func (s sQuotaDo) Distinct(cols ...field.Expr) ISQuotaDo {
+	return s.withDO(s.DO.Distinct(cols...))
+}
+
+func (s sQuotaDo) Omit(cols ...field.Expr) ISQuotaDo {
+	return s.withDO(s.DO.Omit(cols...))
+}
+
+func (s sQuotaDo) Join(table schema.Tabler, on ...field.Expr) ISQuotaDo {
+	return s.withDO(s.DO.Join(table, on...))
+}
+
+func (s sQuotaDo) LeftJoin(table schema.Tabler, on ...field.Expr) ISQuotaDo {
+	return s.withDO(s.DO.LeftJoin(table, on...))
+}
+
+func (s sQuotaDo) RightJoin(table schema.Tabler, on ...field.Expr) ISQuotaDo {
+	return s.withDO(s.DO.RightJoin(table, on...))
+}
+
+func (s sQuotaDo) Group(cols ...field.Expr) ISQuotaDo {
+	return s.withDO(s.DO.Group(cols...))
+}
+
+func (s sQuotaDo) Having(conds ...gen.Condition) ISQuotaDo {
+	return s.withDO(s.DO.Having(conds...))
+}
+
+func (s sQuotaDo) Limit(limit int) ISQuotaDo {
+	return s.withDO(s.DO.Limit(limit))
+}
+
+func (s sQuotaDo) Offset(offset int) ISQuotaDo {
+	return s.withDO(s.DO.Offset(offset))
+}
+
+func (s sQuotaDo) Scopes(funcs ...func(gen.Dao) gen.Dao) ISQuotaDo {
+	return s.withDO(s.DO.Scopes(funcs...))
+}
+
+func (s sQuotaDo) Unscoped() ISQuotaDo {
+	return s.withDO(s.DO.Unscoped())
+}
+
+func (s sQuotaDo) Create(values ...*model.SQuota) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return s.DO.Create(values)
+}
+
+func (s sQuotaDo) CreateInBatches(values []*model.SQuota, batchSize int) error {
+	return s.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (s sQuotaDo) Save(values ...*model.SQuota) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return s.DO.Save(values)
+}
+
+func (s sQuotaDo) First() (*model.SQuota, error) {
+	if result, err := s.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SQuota), nil
+	}
+}
+
+func (s sQuotaDo) Take() (*model.SQuota, error) {
+	if result, err := s.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SQuota), nil
+	}
+}
+
+func (s sQuotaDo) Last() (*model.SQuota, error) {
+	if result, err := s.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SQuota), nil
+	}
+}
+
+func (s sQuotaDo) Find() ([]*model.SQuota, error) {
+	result, err := s.DO.Find()
+	return result.([]*model.SQuota), err
+}
+
+func (s sQuotaDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.SQuota, err error) {
+	buf := make([]*model.SQuota, 0, batchSize)
+	err = s.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (s sQuotaDo) FindInBatches(result *[]*model.SQuota, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return s.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (s sQuotaDo) Attrs(attrs ...field.AssignExpr) ISQuotaDo {
+	return s.withDO(s.DO.Attrs(attrs...))
+}
+
+func (s sQuotaDo) Assign(attrs ...field.AssignExpr) ISQuotaDo {
+	return s.withDO(s.DO.Assign(attrs...))
+}
+
+func (s sQuotaDo) Joins(fields ...field.RelationField) ISQuotaDo {
+	for _, _f := range fields {
+		s = *s.withDO(s.DO.Joins(_f))
+	}
+	return &s
+}
+
+func (s sQuotaDo) Preload(fields ...field.RelationField) ISQuotaDo {
+	for _, _f := range fields {
+		s = *s.withDO(s.DO.Preload(_f))
+	}
+	return &s
+}
+
+func (s sQuotaDo) FirstOrInit() (*model.SQuota, error) {
+	if result, err := s.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SQuota), nil
+	}
+}
+
+func (s sQuotaDo) FirstOrCreate() (*model.SQuota, error) {
+	if result, err := s.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SQuota), nil
+	}
+}
+
+func (s sQuotaDo) FindByPage(offset int, limit int) (result []*model.SQuota, count int64, err error) {
+	result, err = s.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = s.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (s sQuotaDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = s.Count()
+	if err != nil {
+		return
+	}
+
+	err = s.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (s sQuotaDo) Scan(result interface{}) (err error) {
+	return s.DO.Scan(result)
+}
+
+func (s sQuotaDo) Delete(models ...*model.SQuota) (result gen.ResultInfo, err error) {
+	return s.DO.Delete(models)
+}
+
+func (s *sQuotaDo) withDO(do gen.Dao) *sQuotaDo {
+	s.DO = *do.(*gen.DO)
+	return s
+}
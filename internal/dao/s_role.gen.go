@@ -0,0 +1,399 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package dao
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"go-agent/internal/model"
+)
+
+func newSRole(db *gorm.DB, opts ...gen.DOOption) sRole {
+	_sRole := sRole{}
+
+	_sRole.sRoleDo.UseDB(db, opts...)
+	_sRole.sRoleDo.UseModel(&model.SRole{})
+
+	tableName := _sRole.sRoleDo.TableName()
+	_sRole.ALL = field.NewAsterisk(tableName)
+	_sRole.Id = field.NewUint64(tableName, "id")
+	_sRole.Name = field.NewString(tableName, "name")
+	_sRole.Description = field.NewString(tableName, "description")
+	_sRole.CreatedAt = field.NewTime(tableName, "created_at")
+	_sRole.UpdatedAt = field.NewTime(tableName, "updated_at")
+
+	_sRole.fillFieldMap()
+
+	return _sRole
+}
+
+type sRole struct {
+	sRoleDo
+
+	ALL         field.Asterisk
+	Id          field.Uint64 // 主键id
+	Name        field.String // 角色名
+	Description field.String // 描述
+	CreatedAt   field.Time   // 添加时间
+	UpdatedAt   field.Time   // 更新时间
+
+	fieldMap map[string]field.Expr
+}
+
+func (s sRole) Table(newTableName string) *sRole {
+	s.sRoleDo.UseTable(newTableName)
+	return s.updateTableName(newTableName)
+}
+
+func (s sRole) As(alias string) *sRole {
+	s.sRoleDo.DO = *(s.sRoleDo.As(alias).(*gen.DO))
+	return s.updateTableName(alias)
+}
+
+func (s *sRole) updateTableName(table string) *sRole {
+	s.ALL = field.NewAsterisk(table)
+	s.Id = field.NewUint64(table, "id")
+	s.Name = field.NewString(table, "name")
+	s.Description = field.NewString(table, "description")
+	s.CreatedAt = field.NewTime(table, "created_at")
+	s.UpdatedAt = field.NewTime(table, "updated_at")
+
+	s.fillFieldMap()
+
+	return s
+}
+
+func (s *sRole) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := s.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (s *sRole) fillFieldMap() {
+	s.fieldMap = make(map[string]field.Expr, 5)
+	s.fieldMap["id"] = s.Id
+	s.fieldMap["name"] = s.Name
+	s.fieldMap["description"] = s.Description
+	s.fieldMap["created_at"] = s.CreatedAt
+	s.fieldMap["updated_at"] = s.UpdatedAt
+}
+
+func (s sRole) clone(db *gorm.DB) sRole {
+	s.sRoleDo.ReplaceConnPool(db.Statement.ConnPool)
+	return s
+}
+
+func (s sRole) replaceDB(db *gorm.DB) sRole {
+	s.sRoleDo.ReplaceDB(db)
+	return s
+}
+
+type sRoleDo struct{ gen.DO }
+
+type ISRoleDo interface {
+	gen.SubQuery
+	Debug() ISRoleDo
+	WithContext(ctx context.Context) ISRoleDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() ISRoleDo
+	WriteDB() ISRoleDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) ISRoleDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) ISRoleDo
+	Not(conds ...gen.Condition) ISRoleDo
+	Or(conds ...gen.Condition) ISRoleDo
+	Select(conds ...field.Expr) ISRoleDo
+	Where(conds ...gen.Condition) ISRoleDo
+	Order(conds ...field.Expr) ISRoleDo
+	Distinct(cols ...field.Expr) ISRoleDo
+	Omit(cols ...field.Expr) ISRoleDo
+	Join(table schema.Tabler, on ...field.Expr) ISRoleDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) ISRoleDo
+	RightJoin(table schema.Tabler, on ...field.Expr) ISRoleDo
+	Group(cols ...field.Expr) ISRoleDo
+	Having(conds ...gen.Condition) ISRoleDo
+	Limit(limit int) ISRoleDo
+	Offset(offset int) ISRoleDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) ISRoleDo
+	Unscoped() ISRoleDo
+	Create(values ...*model.SRole) error
+	CreateInBatches(values []*model.SRole, batchSize int) error
+	Save(values ...*model.SRole) error
+	First() (*model.SRole, error)
+	Take() (*model.SRole, error)
+	Last() (*model.SRole, error)
+	Find() ([]*model.SRole, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.SRole, err error)
+	FindInBatches(result *[]*model.SRole, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*model.SRole) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) ISRoleDo
+	Assign(attrs ...field.AssignExpr) ISRoleDo
+	Joins(fields ...field.RelationField) ISRoleDo
+	Preload(fields ...field.RelationField) ISRoleDo
+	FirstOrInit() (*model.SRole, error)
+	FirstOrCreate() (*model.SRole, error)
+	FindByPage(offset int, limit int) (result []*model.SRole, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) ISRoleDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (s sRoleDo) Debug() ISRoleDo {
+	return s.withDO(s.DO.Debug())
+}
+
+func (s sRoleDo) WithContext(ctx context.Context) ISRoleDo {
+	return s.withDO(s.DO.WithContext(ctx))
+}
+
+func (s sRoleDo) ReadDB() ISRoleDo {
+	return s.Clauses(dbresolver.Read)
+}
+
+func (s sRoleDo) WriteDB() ISRoleDo {
+	return s.Clauses(dbresolver.Write)
+}
+
+func (s sRoleDo) Session(config *gorm.Session) ISRoleDo {
+	return s.withDO(s.DO.Session(config))
+}
+
+func (s sRoleDo) Clauses(conds ...clause.Expression) ISRoleDo {
+	return s.withDO(s.DO.Clauses(conds...))
+}
+
+func (s sRoleDo) Returning(value interface{}, columns ...string) ISRoleDo {
+	return s.withDO(s.DO.Returning(value, columns...))
+}
+
+func (s sRoleDo) Not(conds ...gen.Condition) ISRoleDo {
+	return s.withDO(s.DO.Not(conds...))
+}
+
+func (s sRoleDo) Or(conds ...gen.Condition) ISRoleDo {
+	return s.withDO(s.DO.Or(conds...))
+}
+
+func (s sRoleDo) Select(conds ...field.Expr) ISRoleDo {
+	return s.withDO(s.DO.Select(conds...))
+}
+
+func (s sRoleDo) Where(conds ...gen.Condition) ISRoleDo {
+	return s.withDO(s.DO.Where(conds...))
+}
+
+func (s sRoleDo) Order(conds ...field.Expr) ISRoleDo {
+	return s.withDO(s.DO.Order(conds...))
+}
+
+func (s sRoleDo) Distinct(cols ...field.Expr) ISRoleDo {
+	return s.withDO(s.DO.Distinct(cols...))
+}
+
+func (s sRoleDo) Omit(cols ...field.Expr) ISRoleDo {
+	return s.withDO(s.DO.Omit(cols...))
+}
+
+func (s sRoleDo) Join(table schema.Tabler, on ...field.Expr) ISRoleDo {
+	return s.withDO(s.DO.Join(table, on...))
+}
+
+func (s sRoleDo) LeftJoin(table schema.Tabler, on ...field.Expr) ISRoleDo {
+	return s.withDO(s.DO.LeftJoin(table, on...))
+}
+
+func (s sRoleDo) RightJoin(table schema.Tabler, on ...field.Expr) ISRoleDo {
+	return s.withDO(s.DO.RightJoin(table, on...))
+}
+
+func (s sRoleDo) Group(cols ...field.Expr) ISRoleDo {
+	return s.withDO(s.DO.Group(cols...))
+}
+
+func (s sRoleDo) Having(conds ...gen.Condition) ISRoleDo {
+	return s.withDO(s.DO.Having(conds...))
+}
+
+func (s sRoleDo) Limit(limit int) ISRoleDo {
+	return s.withDO(s.DO.Limit(limit))
+}
+
+func (s sRoleDo) Offset(offset int) ISRoleDo {
+	return s.withDO(s.DO.Offset(offset))
+}
+
+func (s sRoleDo) Scopes(funcs ...func(gen.Dao) gen.Dao) ISRoleDo {
+	return s.withDO(s.DO.Scopes(funcs...))
+}
+
+func (s sRoleDo) Unscoped() ISRoleDo {
+	return s.withDO(s.DO.Unscoped())
+}
+
+func (s sRoleDo) Create(values ...*model.SRole) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return s.DO.Create(values)
+}
+
+func (s sRoleDo) CreateInBatches(values []*model.SRole, batchSize int) error {
+	return s.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (s sRoleDo) Save(values ...*model.SRole) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return s.DO.Save(values)
+}
+
+func (s sRoleDo) First() (*model.SRole, error) {
+	if result, err := s.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SRole), nil
+	}
+}
+
+func (s sRoleDo) Take() (*model.SRole, error) {
+	if result, err := s.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SRole), nil
+	}
+}
+
+func (s sRoleDo) Last() (*model.SRole, error) {
+	if result, err := s.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SRole), nil
+	}
+}
+
+func (s sRoleDo) Find() ([]*model.SRole, error) {
+	result, err := s.DO.Find()
+	return result.([]*model.SRole), err
+}
+
+func (s sRoleDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.SRole, err error) {
+	buf := make([]*model.SRole, 0, batchSize)
+	err = s.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (s sRoleDo) FindInBatches(result *[]*model.SRole, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return s.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (s sRoleDo) Attrs(attrs ...field.AssignExpr) ISRoleDo {
+	return s.withDO(s.DO.Attrs(attrs...))
+}
+
+func (s sRoleDo) Assign(attrs ...field.AssignExpr) ISRoleDo {
+	return s.withDO(s.DO.Assign(attrs...))
+}
+
+func (s sRoleDo) Joins(fields ...field.RelationField) ISRoleDo {
+	for _, _f := range fields {
+		s = *s.withDO(s.DO.Joins(_f))
+	}
+	return &s
+}
+
+func (s sRoleDo) Preload(fields ...field.RelationField) ISRoleDo {
+	for _, _f := range fields {
+		s = *s.withDO(s.DO.Preload(_f))
+	}
+	return &s
+}
+
+func (s sRoleDo) FirstOrInit() (*model.SRole, error) {
+	if result, err := s.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SRole), nil
+	}
+}
+
+func (s sRoleDo) FirstOrCreate() (*model.SRole, error) {
+	if result, err := s.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SRole), nil
+	}
+}
+
+func (s sRoleDo) FindByPage(offset int, limit int) (result []*model.SRole, count int64, err error) {
+	result, err = s.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = s.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (s sRoleDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = s.Count()
+	if err != nil {
+		return
+	}
+
+	err = s.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (s sRoleDo) Scan(result interface{}) (err error) {
+	return s.DO.Scan(result)
+}
+
+func (s sRoleDo) Delete(models ...*model.SRole) (result gen.ResultInfo, err error) {
+	return s.DO.Delete(models)
+}
+
+func (s *sRoleDo) withDO(do gen.Dao) *sRoleDo {
+	s.DO = *do.(*gen.DO)
+	return s
+}
@@ -0,0 +1,395 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package dao
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"go-agent/internal/model"
+)
+
+func newSRolePermission(db *gorm.DB, opts ...gen.DOOption) sRolePermission {
+	_sRolePermission := sRolePermission{}
+
+	_sRolePermission.sRolePermissionDo.UseDB(db, opts...)
+	_sRolePermission.sRolePermissionDo.UseModel(&model.SRolePermission{})
+
+	tableName := _sRolePermission.sRolePermissionDo.TableName()
+	_sRolePermission.ALL = field.NewAsterisk(tableName)
+	_sRolePermission.Id = field.NewUint64(tableName, "id")
+	_sRolePermission.RoleName = field.NewString(tableName, "role_name")
+	_sRolePermission.Permission = field.NewString(tableName, "permission")
+	_sRolePermission.CreatedAt = field.NewTime(tableName, "created_at")
+
+	_sRolePermission.fillFieldMap()
+
+	return _sRolePermission
+}
+
+type sRolePermission struct {
+	sRolePermissionDo
+
+	ALL        field.Asterisk
+	Id         field.Uint64 // 主键id
+	RoleName   field.String // 角色名
+	Permission field.String // 权限
+	CreatedAt  field.Time   // 添加时间
+
+	fieldMap map[string]field.Expr
+}
+
+func (s sRolePermission) Table(newTableName string) *sRolePermission {
+	s.sRolePermissionDo.UseTable(newTableName)
+	return s.updateTableName(newTableName)
+}
+
+func (s sRolePermission) As(alias string) *sRolePermission {
+	s.sRolePermissionDo.DO = *(s.sRolePermissionDo.As(alias).(*gen.DO))
+	return s.updateTableName(alias)
+}
+
+func (s *sRolePermission) updateTableName(table string) *sRolePermission {
+	s.ALL = field.NewAsterisk(table)
+	s.Id = field.NewUint64(table, "id")
+	s.RoleName = field.NewString(table, "role_name")
+	s.Permission = field.NewString(table, "permission")
+	s.CreatedAt = field.NewTime(table, "created_at")
+
+	s.fillFieldMap()
+
+	return s
+}
+
+func (s *sRolePermission) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := s.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (s *sRolePermission) fillFieldMap() {
+	s.fieldMap = make(map[string]field.Expr, 4)
+	s.fieldMap["id"] = s.Id
+	s.fieldMap["role_name"] = s.RoleName
+	s.fieldMap["permission"] = s.Permission
+	s.fieldMap["created_at"] = s.CreatedAt
+}
+
+func (s sRolePermission) clone(db *gorm.DB) sRolePermission {
+	s.sRolePermissionDo.ReplaceConnPool(db.Statement.ConnPool)
+	return s
+}
+
+func (s sRolePermission) replaceDB(db *gorm.DB) sRolePermission {
+	s.sRolePermissionDo.ReplaceDB(db)
+	return s
+}
+
+type sRolePermissionDo struct{ gen.DO }
+
+type ISRolePermissionDo interface {
+	gen.SubQuery
+	Debug() ISRolePermissionDo
+	WithContext(ctx context.Context) ISRolePermissionDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() ISRolePermissionDo
+	WriteDB() ISRolePermissionDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) ISRolePermissionDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) ISRolePermissionDo
+	Not(conds ...gen.Condition) ISRolePermissionDo
+	Or(conds ...gen.Condition) ISRolePermissionDo
+	Select(conds ...field.Expr) ISRolePermissionDo
+	Where(conds ...gen.Condition) ISRolePermissionDo
+	Order(conds ...field.Expr) ISRolePermissionDo
+	Distinct(cols ...field.Expr) ISRolePermissionDo
+	Omit(cols ...field.Expr) ISRolePermissionDo
+	Join(table schema.Tabler, on ...field.Expr) ISRolePermissionDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) ISRolePermissionDo
+	RightJoin(table schema.Tabler, on ...field.Expr) ISRolePermissionDo
+	Group(cols ...field.Expr) ISRolePermissionDo
+	Having(conds ...gen.Condition) ISRolePermissionDo
+	Limit(limit int) ISRolePermissionDo
+	Offset(offset int) ISRolePermissionDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) ISRolePermissionDo
+	Unscoped() ISRolePermissionDo
+	Create(values ...*model.SRolePermission) error
+	CreateInBatches(values []*model.SRolePermission, batchSize int) error
+	Save(values ...*model.SRolePermission) error
+	First() (*model.SRolePermission, error)
+	Take() (*model.SRolePermission, error)
+	Last() (*model.SRolePermission, error)
+	Find() ([]*model.SRolePermission, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.SRolePermission, err error)
+	FindInBatches(result *[]*model.SRolePermission, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*model.SRolePermission) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) ISRolePermissionDo
+	Assign(attrs ...field.AssignExpr) ISRolePermissionDo
+	Joins(fields ...field.RelationField) ISRolePermissionDo
+	Preload(fields ...field.RelationField) ISRolePermissionDo
+	FirstOrInit() (*model.SRolePermission, error)
+	FirstOrCreate() (*model.SRolePermission, error)
+	FindByPage(offset int, limit int) (result []*model.SRolePermission, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) ISRolePermissionDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (s sRolePermissionDo) Debug() ISRolePermissionDo {
+	return s.withDO(s.DO.Debug())
+}
+
+func (s sRolePermissionDo) WithContext(ctx context.Context) ISRolePermissionDo {
+	return s.withDO(s.DO.WithContext(ctx))
+}
+
+func (s sRolePermissionDo) ReadDB() ISRolePermissionDo {
+	return s.Clauses(dbresolver.Read)
+}
+
+func (s sRolePermissionDo) WriteDB() ISRolePermissionDo {
+	return s.Clauses(dbresolver.Write)
+}
+
+func (s sRolePermissionDo) Session(config *gorm.Session) ISRolePermissionDo {
+	return s.withDO(s.DO.Session(config))
+}
+
+func (s sRolePermissionDo) Clauses(conds ...clause.Expression) ISRolePermissionDo {
+	return s.withDO(s.DO.Clauses(conds...))
+}
+
+func (s sRolePermissionDo) Returning(value interface{}, columns ...string) ISRolePermissionDo {
+	return s.withDO(s.DO.Returning(value, columns...))
+}
+
+func (s sRolePermissionDo) Not(conds ...gen.Condition) ISRolePermissionDo {
+	return s.withDO(s.DO.Not(conds...))
+}
+
+func (s sRolePermissionDo) Or(conds ...gen.Condition) ISRolePermissionDo {
+	return s.withDO(s.DO.Or(conds...))
+}
+
+func (s sRolePermissionDo) Select(conds ...field.Expr) ISRolePermissionDo {
+	return s.withDO(s.DO.Select(conds...))
+}
+
+func (s sRolePermissionDo) Where(conds ...gen.Condition) ISRolePermissionDo {
+	return s.withDO(s.DO.Where(conds...))
+}
+
+func (s sRolePermissionDo) Order(conds ...field.Expr) ISRolePermissionDo {
+	return s.withDO(s.DO.Order(conds...))
+}
+
+func (s sRolePermissionDo) Distinct(cols ...field.Expr) ISRolePermissionDo {
+	return s.withDO(s.DO.Distinct(cols...))
+}
+
+func (s sRolePermissionDo) Omit(cols ...field.Expr) ISRolePermissionDo {
+	return s.withDO(s.DO.Omit(cols...))
+}
+
+func (s sRolePermissionDo) Join(table schema.Tabler, on ...field.Expr) ISRolePermissionDo {
+	return s.withDO(s.DO.Join(table, on...))
+}
+
+func (s sRolePermissionDo) LeftJoin(table schema.Tabler, on ...field.Expr) ISRolePermissionDo {
+	return s.withDO(s.DO.LeftJoin(table, on...))
+}
+
+func (s sRolePermissionDo) RightJoin(table schema.Tabler, on ...field.Expr) ISRolePermissionDo {
+	return s.withDO(s.DO.RightJoin(table, on...))
+}
+
+func (s sRolePermissionDo) Group(cols ...field.Expr) ISRolePermissionDo {
+	return s.withDO(s.DO.Group(cols...))
+}
+
+func (s sRolePermissionDo) Having(conds ...gen.Condition) ISRolePermissionDo {
+	return s.withDO(s.DO.Having(conds...))
+}
+
+func (s sRolePermissionDo) Limit(limit int) ISRolePermissionDo {
+	return s.withDO(s.DO.Limit(limit))
+}
+
+func (s sRolePermissionDo) Offset(offset int) ISRolePermissionDo {
+	return s.withDO(s.DO.Offset(offset))
+}
+
+func (s sRolePermissionDo) Scopes(funcs ...func(gen.Dao) gen.Dao) ISRolePermissionDo {
+	return s.withDO(s.DO.Scopes(funcs...))
+}
+
+func (s sRolePermissionDo) Unscoped() ISRolePermissionDo {
+	return s.withDO(s.DO.Unscoped())
+}
+
+func (s sRolePermissionDo) Create(values ...*model.SRolePermission) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return s.DO.Create(values)
+}
+
+func (s sRolePermissionDo) CreateInBatches(values []*model.SRolePermission, batchSize int) error {
+	return s.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (s sRolePermissionDo) Save(values ...*model.SRolePermission) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return s.DO.Save(values)
+}
+
+func (s sRolePermissionDo) First() (*model.SRolePermission, error) {
+	if result, err := s.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SRolePermission), nil
+	}
+}
+
+func (s sRolePermissionDo) Take() (*model.SRolePermission, error) {
+	if result, err := s.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SRolePermission), nil
+	}
+}
+
+func (s sRolePermissionDo) Last() (*model.SRolePermission, error) {
+	if result, err := s.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SRolePermission), nil
+	}
+}
+
+func (s sRolePermissionDo) Find() ([]*model.SRolePermission, error) {
+	result, err := s.DO.Find()
+	return result.([]*model.SRolePermission), err
+}
+
+func (s sRolePermissionDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.SRolePermission, err error) {
+	buf := make([]*model.SRolePermission, 0, batchSize)
+	err = s.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (s sRolePermissionDo) FindInBatches(result *[]*model.SRolePermission, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return s.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (s sRolePermissionDo) Attrs(attrs ...field.AssignExpr) ISRolePermissionDo {
+	return s.withDO(s.DO.Attrs(attrs...))
+}
+
+func (s sRolePermissionDo) Assign(attrs ...field.AssignExpr) ISRolePermissionDo {
+	return s.withDO(s.DO.Assign(attrs...))
+}
+
+func (s sRolePermissionDo) Joins(fields ...field.RelationField) ISRolePermissionDo {
+	for _, _f := range fields {
+		s = *s.withDO(s.DO.Joins(_f))
+	}
+	return &s
+}
+
+func (s sRolePermissionDo) Preload(fields ...field.RelationField) ISRolePermissionDo {
+	for _, _f := range fields {
+		s = *s.withDO(s.DO.Preload(_f))
+	}
+	return &s
+}
+
+func (s sRolePermissionDo) FirstOrInit() (*model.SRolePermission, error) {
+	if result, err := s.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SRolePermission), nil
+	}
+}
+
+func (s sRolePermissionDo) FirstOrCreate() (*model.SRolePermission, error) {
+	if result, err := s.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SRolePermission), nil
+	}
+}
+
+func (s sRolePermissionDo) FindByPage(offset int, limit int) (result []*model.SRolePermission, count int64, err error) {
+	result, err = s.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = s.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (s sRolePermissionDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = s.Count()
+	if err != nil {
+		return
+	}
+
+	err = s.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (s sRolePermissionDo) Scan(result interface{}) (err error) {
+	return s.DO.Scan(result)
+}
+
+func (s sRolePermissionDo) Delete(models ...*model.SRolePermission) (result gen.ResultInfo, err error) {
+	return s.DO.Delete(models)
+}
+
+func (s *sRolePermissionDo) withDO(do gen.Dao) *sRolePermissionDo {
+	s.DO = *do.(*gen.DO)
+	return s
+}
@@ -0,0 +1,411 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package dao
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"go-agent/internal/model"
+)
+
+func newSUser(db *gorm.DB, opts ...gen.DOOption) sUser {
+	_sUser := sUser{}
+
+	_sUser.sUserDo.UseDB(db, opts...)
+	_sUser.sUserDo.UseModel(&model.SUser{})
+
+	tableName := _sUser.sUserDo.TableName()
+	_sUser.ALL = field.NewAsterisk(tableName)
+	_sUser.Id = field.NewUint64(tableName, "id")
+	_sUser.UserId = field.NewString(tableName, "user_id")
+	_sUser.Username = field.NewString(tableName, "username")
+	_sUser.PasswordHash = field.NewString(tableName, "password_hash")
+	_sUser.TeamId = field.NewString(tableName, "team_id")
+	_sUser.Status = field.NewString(tableName, "status")
+	_sUser.CreatedAt = field.NewTime(tableName, "created_at")
+	_sUser.UpdatedAt = field.NewTime(tableName, "updated_at")
+
+	_sUser.fillFieldMap()
+
+	return _sUser
+}
+
+type sUser struct {
+	sUserDo
+
+	ALL          field.Asterisk
+	Id           field.Uint64 // 主键id
+	UserId       field.String // 用户ID
+	Username     field.String // 登录名
+	PasswordHash field.String // bcrypt 密码哈希
+	TeamId       field.String // 所属团队ID
+	Status       field.String // 状态,on启用,off禁用
+	CreatedAt    field.Time   // 添加时间
+	UpdatedAt    field.Time   // 更新时间
+
+	fieldMap map[string]field.Expr
+}
+
+func (s sUser) Table(newTableName string) *sUser {
+	s.sUserDo.UseTable(newTableName)
+	return s.updateTableName(newTableName)
+}
+
+func (s sUser) As(alias string) *sUser {
+	s.sUserDo.DO = *(s.sUserDo.As(alias).(*gen.DO))
+	return s.updateTableName(alias)
+}
+
+func (s *sUser) updateTableName(table string) *sUser {
+	s.ALL = field.NewAsterisk(table)
+	s.Id = field.NewUint64(table, "id")
+	s.UserId = field.NewString(table, "user_id")
+	s.Username = field.NewString(table, "username")
+	s.PasswordHash = field.NewString(table, "password_hash")
+	s.TeamId = field.NewString(table, "team_id")
+	s.Status = field.NewString(table, "status")
+	s.CreatedAt = field.NewTime(table, "created_at")
+	s.UpdatedAt = field.NewTime(table, "updated_at")
+
+	s.fillFieldMap()
+
+	return s
+}
+
+func (s *sUser) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := s.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (s *sUser) fillFieldMap() {
+	s.fieldMap = make(map[string]field.Expr, 8)
+	s.fieldMap["id"] = s.Id
+	s.fieldMap["user_id"] = s.UserId
+	s.fieldMap["username"] = s.Username
+	s.fieldMap["password_hash"] = s.PasswordHash
+	s.fieldMap["team_id"] = s.TeamId
+	s.fieldMap["status"] = s.Status
+	s.fieldMap["created_at"] = s.CreatedAt
+	s.fieldMap["updated_at"] = s.UpdatedAt
+}
+
+func (s sUser) clone(db *gorm.DB) sUser {
+	s.sUserDo.ReplaceConnPool(db.Statement.ConnPool)
+	return s
+}
+
+func (s sUser) replaceDB(db *gorm.DB) sUser {
+	s.sUserDo.ReplaceDB(db)
+	return s
+}
+
+type sUserDo struct{ gen.DO }
+
+type ISUserDo interface {
+	gen.SubQuery
+	Debug() ISUserDo
+	WithContext(ctx context.Context) ISUserDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() ISUserDo
+	WriteDB() ISUserDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) ISUserDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) ISUserDo
+	Not(conds ...gen.Condition) ISUserDo
+	Or(conds ...gen.Condition) ISUserDo
+	Select(conds ...field.Expr) ISUserDo
+	Where(conds ...gen.Condition) ISUserDo
+	Order(conds ...field.Expr) ISUserDo
+	Distinct(cols ...field.Expr) ISUserDo
+	Omit(cols ...field.Expr) ISUserDo
+	Join(table schema.Tabler, on ...field.Expr) ISUserDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) ISUserDo
+	RightJoin(table schema.Tabler, on ...field.Expr) ISUserDo
+	Group(cols ...field.Expr) ISUserDo
+	Having(conds ...gen.Condition) ISUserDo
+	Limit(limit int) ISUserDo
+	Offset(offset int) ISUserDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) ISUserDo
+	Unscoped() ISUserDo
+	Create(values ...*model.SUser) error
+	CreateInBatches(values []*model.SUser, batchSize int) error
+	Save(values ...*model.SUser) error
+	First() (*model.SUser, error)
+	Take() (*model.SUser, error)
+	Last() (*model.SUser, error)
+	Find() ([]*model.SUser, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.SUser, err error)
+	FindInBatches(result *[]*model.SUser, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*model.SUser) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) ISUserDo
+	Assign(attrs ...field.AssignExpr) ISUserDo
+	Joins(fields ...field.RelationField) ISUserDo
+	Preload(fields ...field.RelationField) ISUserDo
+	FirstOrInit() (*model.SUser, error)
+	FirstOrCreate() (*model.SUser, error)
+	FindByPage(offset int, limit int) (result []*model.SUser, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) ISUserDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (s sUserDo) Debug() ISUserDo {
+	return s.withDO(s.DO.Debug())
+}
+
+func (s sUserDo) WithContext(ctx context.Context) ISUserDo {
+	return s.withDO(s.DO.WithContext(ctx))
+}
+
+func (s sUserDo) ReadDB() ISUserDo {
+	return s.Clauses(dbresolver.Read)
+}
+
+func (s sUserDo) WriteDB() ISUserDo {
+	return s.Clauses(dbresolver.Write)
+}
+
+func (s sUserDo) Session(config *gorm.Session) ISUserDo {
+	return s.withDO(s.DO.Session(config))
+}
+
+func (s sUserDo) Clauses(conds ...clause.Expression) ISUserDo {
+	return s.withDO(s.DO.Clauses(conds...))
+}
+
+func (s sUserDo) Returning(value interface{}, columns ...string) ISUserDo {
+	return s.withDO(s.DO.Returning(value, columns...))
+}
+
+func (s sUserDo) Not(conds ...gen.Condition) ISUserDo {
+	return s.withDO(s.DO.Not(conds...))
+}
+
+func (s sUserDo) Or(conds ...gen.Condition) ISUserDo {
+	return s.withDO(s.DO.Or(conds...))
+}
+
+func (s sUserDo) Select(conds ...field.Expr) ISUserDo {
+	return s.withDO(s.DO.Select(conds...))
+}
+
+func (s sUserDo) Where(conds ...gen.Condition) ISUserDo {
+	return s.withDO(s.DO.Where(conds...))
+}
+
+func (s sUserDo) Order(conds ...field.Expr) ISUserDo {
+	return s.withDO(s.DO.Order(conds...))
+}
+
+func (s sUserDo) Distinct(cols ...field.Expr) ISUserDo {
+	return s.withDO(s.DO.Distinct(cols...))
+}
+
+func (s sUserDo) Omit(cols ...field.Expr) ISUserDo {
+	return s.withDO(s.DO.Omit(cols...))
+}
+
+func (s sUserDo) Join(table schema.Tabler, on ...field.Expr) ISUserDo {
+	return s.withDO(s.DO.Join(table, on...))
+}
+
+func (s sUserDo) LeftJoin(table schema.Tabler, on ...field.Expr) ISUserDo {
+	return s.withDO(s.DO.LeftJoin(table, on...))
+}
+
+func (s sUserDo) RightJoin(table schema.Tabler, on ...field.Expr) ISUserDo {
+	return s.withDO(s.DO.RightJoin(table, on...))
+}
+
+func (s sUserDo) Group(cols ...field.Expr) ISUserDo {
+	return s.withDO(s.DO.Group(cols...))
+}
+
+func (s sUserDo) Having(conds ...gen.Condition) ISUserDo {
+	return s.withDO(s.DO.Having(conds...))
+}
+
+func (s sUserDo) Limit(limit int) ISUserDo {
+	return s.withDO(s.DO.Limit(limit))
+}
+
+func (s sUserDo) Offset(offset int) ISUserDo {
+	return s.withDO(s.DO.Offset(offset))
+}
+
+func (s sUserDo) Scopes(funcs ...func(gen.Dao) gen.Dao) ISUserDo {
+	return s.withDO(s.DO.Scopes(funcs...))
+}
+
+func (s sUserDo) Unscoped() ISUserDo {
+	return s.withDO(s.DO.Unscoped())
+}
+
+func (s sUserDo) Create(values ...*model.SUser) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return s.DO.Create(values)
+}
+
+func (s sUserDo) CreateInBatches(values []*model.SUser, batchSize int) error {
+	return s.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (s sUserDo) Save(values ...*model.SUser) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return s.DO.Save(values)
+}
+
+func (s sUserDo) First() (*model.SUser, error) {
+	if result, err := s.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SUser), nil
+	}
+}
+
+func (s sUserDo) Take() (*model.SUser, error) {
+	if result, err := s.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SUser), nil
+	}
+}
+
+func (s sUserDo) Last() (*model.SUser, error) {
+	if result, err := s.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SUser), nil
+	}
+}
+
+func (s sUserDo) Find() ([]*model.SUser, error) {
+	result, err := s.DO.Find()
+	return result.([]*model.SUser), err
+}
+
+func (s sUserDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.SUser, err error) {
+	buf := make([]*model.SUser, 0, batchSize)
+	err = s.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (s sUserDo) FindInBatches(result *[]*model.SUser, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return s.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (s sUserDo) Attrs(attrs ...field.AssignExpr) ISUserDo {
+	return s.withDO(s.DO.Attrs(attrs...))
+}
+
+func (s sUserDo) Assign(attrs ...field.AssignExpr) ISUserDo {
+	return s.withDO(s.DO.Assign(attrs...))
+}
+
+func (s sUserDo) Joins(fields ...field.RelationField) ISUserDo {
+	for _, _f := range fields {
+		s = *s.withDO(s.DO.Joins(_f))
+	}
+	return &s
+}
+
+func (s sUserDo) Preload(fields ...field.RelationField) ISUserDo {
+	for _, _f := range fields {
+		s = *s.withDO(s.DO.Preload(_f))
+	}
+	return &s
+}
+
+func (s sUserDo) FirstOrInit() (*model.SUser, error) {
+	if result, err := s.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SUser), nil
+	}
+}
+
+func (s sUserDo) FirstOrCreate() (*model.SUser, error) {
+	if result, err := s.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SUser), nil
+	}
+}
+
+func (s sUserDo) FindByPage(offset int, limit int) (result []*model.SUser, count int64, err error) {
+	result, err = s.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = s.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (s sUserDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = s.Count()
+	if err != nil {
+		return
+	}
+
+	err = s.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (s sUserDo) Scan(result interface{}) (err error) {
+	return s.DO.Scan(result)
+}
+
+func (s sUserDo) Delete(models ...*model.SUser) (result gen.ResultInfo, err error) {
+	return s.DO.Delete(models)
+}
+
+func (s *sUserDo) withDO(do gen.Dao) *sUserDo {
+	s.DO = *do.(*gen.DO)
+	return s
+}
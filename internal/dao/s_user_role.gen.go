@@ -0,0 +1,395 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package dao
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"go-agent/internal/model"
+)
+
+func newSUserRole(db *gorm.DB, opts ...gen.DOOption) sUserRole {
+	_sUserRole := sUserRole{}
+
+	_sUserRole.sUserRoleDo.UseDB(db, opts...)
+	_sUserRole.sUserRoleDo.UseModel(&model.SUserRole{})
+
+	tableName := _sUserRole.sUserRoleDo.TableName()
+	_sUserRole.ALL = field.NewAsterisk(tableName)
+	_sUserRole.Id = field.NewUint64(tableName, "id")
+	_sUserRole.UserId = field.NewString(tableName, "user_id")
+	_sUserRole.RoleName = field.NewString(tableName, "role_name")
+	_sUserRole.CreatedAt = field.NewTime(tableName, "created_at")
+
+	_sUserRole.fillFieldMap()
+
+	return _sUserRole
+}
+
+type sUserRole struct {
+	sUserRoleDo
+
+	ALL       field.Asterisk
+	Id        field.Uint64 // 主键id
+	UserId    field.String // 用户ID
+	RoleName  field.String // 角色名
+	CreatedAt field.Time   // 添加时间
+
+	fieldMap map[string]field.Expr
+}
+
+func (s sUserRole) Table(newTableName string) *sUserRole {
+	s.sUserRoleDo.UseTable(newTableName)
+	return s.updateTableName(newTableName)
+}
+
+func (s sUserRole) As(alias string) *sUserRole {
+	s.sUserRoleDo.DO = *(s.sUserRoleDo.As(alias).(*gen.DO))
+	return s.updateTableName(alias)
+}
+
+func (s *sUserRole) updateTableName(table string) *sUserRole {
+	s.ALL = field.NewAsterisk(table)
+	s.Id = field.NewUint64(table, "id")
+	s.UserId = field.NewString(table, "user_id")
+	s.RoleName = field.NewString(table, "role_name")
+	s.CreatedAt = field.NewTime(table, "created_at")
+
+	s.fillFieldMap()
+
+	return s
+}
+
+func (s *sUserRole) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := s.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (s *sUserRole) fillFieldMap() {
+	s.fieldMap = make(map[string]field.Expr, 4)
+	s.fieldMap["id"] = s.Id
+	s.fieldMap["user_id"] = s.UserId
+	s.fieldMap["role_name"] = s.RoleName
+	s.fieldMap["created_at"] = s.CreatedAt
+}
+
+func (s sUserRole) clone(db *gorm.DB) sUserRole {
+	s.sUserRoleDo.ReplaceConnPool(db.Statement.ConnPool)
+	return s
+}
+
+func (s sUserRole) replaceDB(db *gorm.DB) sUserRole {
+	s.sUserRoleDo.ReplaceDB(db)
+	return s
+}
+
+type sUserRoleDo struct{ gen.DO }
+
+type ISUserRoleDo interface {
+	gen.SubQuery
+	Debug() ISUserRoleDo
+	WithContext(ctx context.Context) ISUserRoleDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() ISUserRoleDo
+	WriteDB() ISUserRoleDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) ISUserRoleDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) ISUserRoleDo
+	Not(conds ...gen.Condition) ISUserRoleDo
+	Or(conds ...gen.Condition) ISUserRoleDo
+	Select(conds ...field.Expr) ISUserRoleDo
+	Where(conds ...gen.Condition) ISUserRoleDo
+	Order(conds ...field.Expr) ISUserRoleDo
+	Distinct(cols ...field.Expr) ISUserRoleDo
+	Omit(cols ...field.Expr) ISUserRoleDo
+	Join(table schema.Tabler, on ...field.Expr) ISUserRoleDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) ISUserRoleDo
+	RightJoin(table schema.Tabler, on ...field.Expr) ISUserRoleDo
+	Group(cols ...field.Expr) ISUserRoleDo
+	Having(conds ...gen.Condition) ISUserRoleDo
+	Limit(limit int) ISUserRoleDo
+	Offset(offset int) ISUserRoleDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) ISUserRoleDo
+	Unscoped() ISUserRoleDo
+	Create(values ...*model.SUserRole) error
+	CreateInBatches(values []*model.SUserRole, batchSize int) error
+	Save(values ...*model.SUserRole) error
+	First() (*model.SUserRole, error)
+	Take() (*model.SUserRole, error)
+	Last() (*model.SUserRole, error)
+	Find() ([]*model.SUserRole, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.SUserRole, err error)
+	FindInBatches(result *[]*model.SUserRole, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*model.SUserRole) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) ISUserRoleDo
+	Assign(attrs ...field.AssignExpr) ISUserRoleDo
+	Joins(fields ...field.RelationField) ISUserRoleDo
+	Preload(fields ...field.RelationField) ISUserRoleDo
+	FirstOrInit() (*model.SUserRole, error)
+	FirstOrCreate() (*model.SUserRole, error)
+	FindByPage(offset int, limit int) (result []*model.SUserRole, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) ISUserRoleDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (s sUserRoleDo) Debug() ISUserRoleDo {
+	return s.withDO(s.DO.Debug())
+}
+
+func (s sUserRoleDo) WithContext(ctx context.Context) ISUserRoleDo {
+	return s.withDO(s.DO.WithContext(ctx))
+}
+
+func (s sUserRoleDo) ReadDB() ISUserRoleDo {
+	return s.Clauses(dbresolver.Read)
+}
+
+func (s sUserRoleDo) WriteDB() ISUserRoleDo {
+	return s.Clauses(dbresolver.Write)
+}
+
+func (s sUserRoleDo) Session(config *gorm.Session) ISUserRoleDo {
+	return s.withDO(s.DO.Session(config))
+}
+
+func (s sUserRoleDo) Clauses(conds ...clause.Expression) ISUserRoleDo {
+	return s.withDO(s.DO.Clauses(conds...))
+}
+
+func (s sUserRoleDo) Returning(value interface{}, columns ...string) ISUserRoleDo {
+	return s.withDO(s.DO.Returning(value, columns...))
+}
+
+func (s sUserRoleDo) Not(conds ...gen.Condition) ISUserRoleDo {
+	return s.withDO(s.DO.Not(conds...))
+}
+
+func (s sUserRoleDo) Or(conds ...gen.Condition) ISUserRoleDo {
+	return s.withDO(s.DO.Or(conds...))
+}
+
+func (s sUserRoleDo) Select(conds ...field.Expr) ISUserRoleDo {
+	return s.withDO(s.DO.Select(conds...))
+}
+
+func (s sUserRoleDo) Where(conds ...gen.Condition) ISUserRoleDo {
+	return s.withDO(s.DO.Where(conds...))
+}
+
+func (s sUserRoleDo) Order(conds ...field.Expr) ISUserRoleDo {
+	return s.withDO(s.DO.Order(conds...))
+}
+
+func (s sUserRoleDo) Distinct(cols ...field.Expr) ISUserRoleDo {
+	return s.withDO(s.DO.Distinct(cols...))
+}
+
+func (s sUserRoleDo) Omit(cols ...field.Expr) ISUserRoleDo {
+	return s.withDO(s.DO.Omit(cols...))
+}
+
+func (s sUserRoleDo) Join(table schema.Tabler, on ...field.Expr) ISUserRoleDo {
+	return s.withDO(s.DO.Join(table, on...))
+}
+
+func (s sUserRoleDo) LeftJoin(table schema.Tabler, on ...field.Expr) ISUserRoleDo {
+	return s.withDO(s.DO.LeftJoin(table, on...))
+}
+
+func (s sUserRoleDo) RightJoin(table schema.Tabler, on ...field.Expr) ISUserRoleDo {
+	return s.withDO(s.DO.RightJoin(table, on...))
+}
+
+func (s sUserRoleDo) Group(cols ...field.Expr) ISUserRoleDo {
+	return s.withDO(s.DO.Group(cols...))
+}
+
+func (s sUserRoleDo) Having(conds ...gen.Condition) ISUserRoleDo {
+	return s.withDO(s.DO.Having(conds...))
+}
+
+func (s sUserRoleDo) Limit(limit int) ISUserRoleDo {
+	return s.withDO(s.DO.Limit(limit))
+}
+
+func (s sUserRoleDo) Offset(offset int) ISUserRoleDo {
+	return s.withDO(s.DO.Offset(offset))
+}
+
+func (s sUserRoleDo) Scopes(funcs ...func(gen.Dao) gen.Dao) ISUserRoleDo {
+	return s.withDO(s.DO.Scopes(funcs...))
+}
+
+func (s sUserRoleDo) Unscoped() ISUserRoleDo {
+	return s.withDO(s.DO.Unscoped())
+}
+
+func (s sUserRoleDo) Create(values ...*model.SUserRole) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return s.DO.Create(values)
+}
+
+func (s sUserRoleDo) CreateInBatches(values []*model.SUserRole, batchSize int) error {
+	return s.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (s sUserRoleDo) Save(values ...*model.SUserRole) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return s.DO.Save(values)
+}
+
+func (s sUserRoleDo) First() (*model.SUserRole, error) {
+	if result, err := s.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SUserRole), nil
+	}
+}
+
+func (s sUserRoleDo) Take() (*model.SUserRole, error) {
+	if result, err := s.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SUserRole), nil
+	}
+}
+
+func (s sUserRoleDo) Last() (*model.SUserRole, error) {
+	if result, err := s.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SUserRole), nil
+	}
+}
+
+func (s sUserRoleDo) Find() ([]*model.SUserRole, error) {
+	result, err := s.DO.Find()
+	return result.([]*model.SUserRole), err
+}
+
+func (s sUserRoleDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.SUserRole, err error) {
+	buf := make([]*model.SUserRole, 0, batchSize)
+	err = s.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (s sUserRoleDo) FindInBatches(result *[]*model.SUserRole, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return s.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (s sUserRoleDo) Attrs(attrs ...field.AssignExpr) ISUserRoleDo {
+	return s.withDO(s.DO.Attrs(attrs...))
+}
+
+func (s sUserRoleDo) Assign(attrs ...field.AssignExpr) ISUserRoleDo {
+	return s.withDO(s.DO.Assign(attrs...))
+}
+
+func (s sUserRoleDo) Joins(fields ...field.RelationField) ISUserRoleDo {
+	for _, _f := range fields {
+		s = *s.withDO(s.DO.Joins(_f))
+	}
+	return &s
+}
+
+func (s sUserRoleDo) Preload(fields ...field.RelationField) ISUserRoleDo {
+	for _, _f := range fields {
+		s = *s.withDO(s.DO.Preload(_f))
+	}
+	return &s
+}
+
+func (s sUserRoleDo) FirstOrInit() (*model.SUserRole, error) {
+	if result, err := s.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SUserRole), nil
+	}
+}
+
+func (s sUserRoleDo) FirstOrCreate() (*model.SUserRole, error) {
+	if result, err := s.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.SUserRole), nil
+	}
+}
+
+func (s sUserRoleDo) FindByPage(offset int, limit int) (result []*model.SUserRole, count int64, err error) {
+	result, err = s.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = s.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (s sUserRoleDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = s.Count()
+	if err != nil {
+		return
+	}
+
+	err = s.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (s sUserRoleDo) Scan(result interface{}) (err error) {
+	return s.DO.Scan(result)
+}
+
+func (s sUserRoleDo) Delete(models ...*model.SUserRole) (result gen.ResultInfo, err error) {
+	return s.DO.Delete(models)
+}
+
+func (s *sUserRoleDo) withDO(do gen.Dao) *sUserRoleDo {
+	s.DO = *do.(*gen.DO)
+	return s
+}
@@ -0,0 +1,20 @@
+package dao
+
+import (
+	"context"
+	"go-agent/internal/model"
+)
+
+// 用户状态
+const (
+	UserStatusOn  = "on"
+	UserStatusOff = "off"
+)
+
+type User interface {
+	Create(ctx context.Context, user *model.SUser) error
+	// FindByUsername 按登录名查询，不存在时返回 nil
+	FindByUsername(ctx context.Context, username string) (*model.SUser, error)
+	// FindByUserID 按用户ID查询，不存在时返回 nil
+	FindByUserID(ctx context.Context, userID string) (*model.SUser, error)
+}
@@ -0,0 +1,15 @@
+package user
+
+import (
+	"context"
+	"go-agent/internal/dao"
+	"go-agent/internal/model"
+)
+
+func (d *Dao) Create(ctx context.Context, user *model.SUser) error {
+	if err := dao.SUser.WithContext(ctx).Create(user); err != nil {
+		return d.ConvertError(err)
+	}
+
+	return nil
+}
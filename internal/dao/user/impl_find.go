@@ -0,0 +1,27 @@
+package user
+
+import (
+	"context"
+	"go-agent/internal/dao"
+	"go-agent/internal/model"
+)
+
+func (d *Dao) FindByUsername(ctx context.Context, username string) (*model.SUser, error) {
+	u := dao.SUser
+	user, err := u.WithContext(ctx).Where(u.Username.Eq(username)).First()
+	if err != nil {
+		return nil, d.ConvertError(err)
+	}
+
+	return user, nil
+}
+
+func (d *Dao) FindByUserID(ctx context.Context, userID string) (*model.SUser, error) {
+	u := dao.SUser
+	user, err := u.WithContext(ctx).Where(u.UserId.Eq(userID)).First()
+	if err != nil {
+		return nil, d.ConvertError(err)
+	}
+
+	return user, nil
+}
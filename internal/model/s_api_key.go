@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+)
+
+// API Key 表，供内部服务调用接口，只保存 key 的 SHA-256 哈希
+type SApiKey struct {
+	Id         uint64     `gorm:"column:id;type:bigint(20) unsigned;primary_key;AUTO_INCREMENT;comment:主键id" json:"id"`
+	KeyId      string     `gorm:"column:key_id;type:char(32);default:'';comment:key ID;NOT NULL;uniqueIndex:uk_key_id" json:"key_id"`
+	KeyHash    string     `gorm:"column:key_hash;type:char(64);default:'';comment:key 的 SHA-256 哈希;NOT NULL;uniqueIndex:uk_key_hash" json:"-"`
+	Prefix     string     `gorm:"column:prefix;type:varchar(16);default:'';comment:key 前缀,用于识别;NOT NULL" json:"prefix"`
+	Name       string     `gorm:"column:name;type:varchar(64);default:'';comment:名称;NOT NULL" json:"name"`
+	OwnerId    string     `gorm:"column:owner_id;type:char(32);default:'';comment:所属用户ID;NOT NULL;index:idx_owner_id" json:"owner_id"`
+	TeamId     string     `gorm:"column:team_id;type:varchar(64);default:'';comment:所属团队ID;NOT NULL" json:"team_id"`
+	Scopes     string     `gorm:"column:scopes;type:varchar(255);default:'';comment:授权范围,逗号分隔,*表示全部;NOT NULL" json:"scopes"`
+	Status     string     `gorm:"column:status;type:varchar(20);default:on;comment:状态,on启用,revoked已吊销;NOT NULL" json:"status"`
+	ExpiresAt  *time.Time `gorm:"column:expires_at;type:timestamp NULL;comment:过期时间,为空表示不过期" json:"expires_at"`
+	LastUsedAt *time.Time `gorm:"column:last_used_at;type:timestamp NULL;comment:最后使用时间" json:"last_used_at"`
+	CreatedAt  time.Time  `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP;comment:添加时间;NOT NULL" json:"created_at"`
+	UpdatedAt  time.Time  `gorm:"column:updated_at;type:timestamp;default:CURRENT_TIMESTAMP;comment:更新时间;NOT NULL" json:"updated_at"`
+}
+
+func (m *SApiKey) TableName() string {
+	return "s_api_key"
+}
@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+)
+
+// 定时任务执行记录表
+type SCronRun struct {
+	Id          uint64    `gorm:"column:id;type:bigint(20) unsigned;primary_key;AUTO_INCREMENT;comment:主键id" json:"id"`
+	Name        string    `gorm:"column:name;type:varchar(128);default:'';comment:任务名称;NOT NULL;index:idx_name_started_at" json:"name"`
+	Node        string    `gorm:"column:node;type:varchar(255);default:'';comment:执行节点;NOT NULL" json:"node"`
+	Status      string    `gorm:"column:status;type:varchar(20);default:'';comment:状态,success成功,failed失败,timeout超时;NOT NULL" json:"status"`
+	Error       string    `gorm:"column:error;type:text;comment:错误信息" json:"error"`
+	ScheduledAt time.Time `gorm:"column:scheduled_at;type:timestamp;default:CURRENT_TIMESTAMP;comment:计划执行时间;NOT NULL" json:"scheduled_at"`
+	StartedAt   time.Time `gorm:"column:started_at;type:timestamp;default:CURRENT_TIMESTAMP;comment:开始时间;NOT NULL;index:idx_name_started_at" json:"started_at"`
+	FinishedAt  time.Time `gorm:"column:finished_at;type:timestamp;default:CURRENT_TIMESTAMP;comment:结束时间;NOT NULL" json:"finished_at"`
+	DurationMs  int64     `gorm:"column:duration_ms;type:bigint(20);default:0;comment:耗时(毫秒);NOT NULL" json:"duration_ms"`
+	CreatedAt   time.Time `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP;comment:添加时间;NOT NULL" json:"created_at"`
+}
+
+func (m *SCronRun) TableName() string {
+	return "s_cron_run"
+}
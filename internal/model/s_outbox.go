@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+)
+
+// 事务发件箱表，业务写入与待发送事件在同一事务中提交，由 relay 异步发送
+type SOutbox struct {
+	Id          uint64     `gorm:"column:id;type:bigint(20) unsigned;primary_key;AUTO_INCREMENT;comment:主键id" json:"id"`
+	MessageId   string     `gorm:"column:message_id;type:varchar(64);default:'';comment:消息ID,消费方据此去重;NOT NULL;uniqueIndex:uk_message_id" json:"message_id"`
+	Topic       string     `gorm:"column:topic;type:varchar(255);default:'';comment:主题;NOT NULL" json:"topic"`
+	Type        string     `gorm:"column:type;type:varchar(128);default:'';comment:消息类型;NOT NULL" json:"type"`
+	Body        string     `gorm:"column:body;type:mediumtext;comment:消息内容" json:"body"`
+	Headers     string     `gorm:"column:headers;type:text;comment:消息头(JSON)" json:"headers"`
+	Status      string     `gorm:"column:status;type:varchar(20);default:'pending';comment:状态,pending待发送,sent已发送,failed超过重试次数;NOT NULL;index:idx_status_next_retry_at" json:"status"`
+	Attempts    int        `gorm:"column:attempts;type:int(11);default:0;comment:发送次数;NOT NULL" json:"attempts"`
+	LastError   string     `gorm:"column:last_error;type:text;comment:最近一次发送失败的错误" json:"last_error"`
+	NextRetryAt time.Time  `gorm:"column:next_retry_at;type:timestamp;default:CURRENT_TIMESTAMP;comment:下次发送时间;NOT NULL;index:idx_status_next_retry_at" json:"next_retry_at"`
+	SentAt      *time.Time `gorm:"column:sent_at;type:timestamp NULL;comment:发送时间" json:"sent_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP;comment:添加时间;NOT NULL" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at;type:timestamp;default:CURRENT_TIMESTAMP;comment:更新时间;NOT NULL" json:"updated_at"`
+}
+
+func (m *SOutbox) TableName() string {
+	return "s_outbox"
+}
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+)
+
+// token 额度表，按用户、团队或 API Key 配置每日/每月可用的模型 token 数
+type SQuota struct {
+	Id          uint64    `gorm:"column:id;type:bigint(20) unsigned;primary_key;AUTO_INCREMENT;comment:主键id" json:"id"`
+	SubjectType string    `gorm:"column:subject_type;type:varchar(20);default:'';comment:主体类型,user用户,team团队,api_key;NOT NULL;uniqueIndex:uk_subject_period" json:"subject_type"`
+	SubjectId   string    `gorm:"column:subject_id;type:varchar(64);default:'';comment:主体ID;NOT NULL;uniqueIndex:uk_subject_period" json:"subject_id"`
+	Period      string    `gorm:"column:period;type:varchar(20);default:'';comment:周期,daily每日,monthly每月;NOT NULL;uniqueIndex:uk_subject_period" json:"period"`
+	TokenLimit  int64     `gorm:"column:token_limit;type:bigint(20);default:0;comment:周期内可用的 token 数;NOT NULL" json:"token_limit"`
+	CreatedAt   time.Time `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP;comment:添加时间;NOT NULL" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;type:timestamp;default:CURRENT_TIMESTAMP;comment:更新时间;NOT NULL" json:"updated_at"`
+}
+
+func (m *SQuota) TableName() string {
+	return "s_quota"
+}
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+)
+
+// 角色表
+type SRole struct {
+	Id          uint64    `gorm:"column:id;type:bigint(20) unsigned;primary_key;AUTO_INCREMENT;comment:主键id" json:"id"`
+	Name        string    `gorm:"column:name;type:varchar(64);default:'';comment:角色名;NOT NULL;uniqueIndex:uk_name" json:"name"`
+	Description string    `gorm:"column:description;type:varchar(255);default:'';comment:描述;NOT NULL" json:"description"`
+	CreatedAt   time.Time `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP;comment:添加时间;NOT NULL" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;type:timestamp;default:CURRENT_TIMESTAMP;comment:更新时间;NOT NULL" json:"updated_at"`
+}
+
+func (m *SRole) TableName() string {
+	return "s_role"
+}
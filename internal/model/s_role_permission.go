@@ -0,0 +1,17 @@
+package model
+
+import (
+	"time"
+)
+
+// 角色权限表，权限格式为 资源:操作，如 agent:chat，支持 admin:* 及 * 通配
+type SRolePermission struct {
+	Id         uint64    `gorm:"column:id;type:bigint(20) unsigned;primary_key;AUTO_INCREMENT;comment:主键id" json:"id"`
+	RoleName   string    `gorm:"column:role_name;type:varchar(64);default:'';comment:角色名;NOT NULL;uniqueIndex:uk_role_permission" json:"role_name"`
+	Permission string    `gorm:"column:permission;type:varchar(128);default:'';comment:权限;NOT NULL;uniqueIndex:uk_role_permission" json:"permission"`
+	CreatedAt  time.Time `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP;comment:添加时间;NOT NULL" json:"created_at"`
+}
+
+func (m *SRolePermission) TableName() string {
+	return "s_role_permission"
+}
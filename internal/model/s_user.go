@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+)
+
+// 用户表
+type SUser struct {
+	Id           uint64    `gorm:"column:id;type:bigint(20) unsigned;primary_key;AUTO_INCREMENT;comment:主键id" json:"id"`
+	UserId       string    `gorm:"column:user_id;type:char(32);default:'';comment:用户ID;NOT NULL;uniqueIndex:uk_user_id" json:"user_id"`
+	Username     string    `gorm:"column:username;type:varchar(64);default:'';comment:登录名;NOT NULL;uniqueIndex:uk_username" json:"username"`
+	PasswordHash string    `gorm:"column:password_hash;type:varchar(255);default:'';comment:bcrypt 密码哈希;NOT NULL" json:"-"`
+	TeamId       string    `gorm:"column:team_id;type:varchar(64);default:'';comment:所属团队ID;NOT NULL" json:"team_id"`
+	Status       string    `gorm:"column:status;type:varchar(20);default:on;comment:状态,on启用,off禁用;NOT NULL" json:"status"`
+	CreatedAt    time.Time `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP;comment:添加时间;NOT NULL" json:"created_at"`
+	UpdatedAt    time.Time `gorm:"column:updated_at;type:timestamp;default:CURRENT_TIMESTAMP;comment:更新时间;NOT NULL" json:"updated_at"`
+}
+
+func (m *SUser) TableName() string {
+	return "s_user"
+}
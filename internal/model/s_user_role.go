@@ -0,0 +1,17 @@
+package model
+
+import (
+	"time"
+)
+
+// 用户角色表
+type SUserRole struct {
+	Id        uint64    `gorm:"column:id;type:bigint(20) unsigned;primary_key;AUTO_INCREMENT;comment:主键id" json:"id"`
+	UserId    string    `gorm:"column:user_id;type:char(32);default:'';comment:用户ID;NOT NULL;uniqueIndex:uk_user_role" json:"user_id"`
+	RoleName  string    `gorm:"column:role_name;type:varchar(64);default:'';comment:角色名;NOT NULL;uniqueIndex:uk_user_role;index:idx_role_name" json:"role_name"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP;comment:添加时间;NOT NULL" json:"created_at"`
+}
+
+func (m *SUserRole) TableName() string {
+	return "s_user_role"
+}
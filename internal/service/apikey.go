@@ -0,0 +1,11 @@
+package service
+
+import (
+	"context"
+	"go-agent/internal/model"
+)
+
+type APIKey interface {
+	// Authenticate 校验 API Key 并记录最后使用时间，key 不存在、已吊销、已过期或所属用户已禁用时返回业务错误
+	Authenticate(ctx context.Context, key string) (*model.SApiKey, error)
+}
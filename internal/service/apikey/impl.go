@@ -0,0 +1,59 @@
+package apikey
+
+import (
+	"go-agent/gopkg/auth"
+	"go-agent/gopkg/services"
+	"go-agent/internal/dao"
+	"go-agent/internal/dao/api_key"
+	"go-agent/internal/dao/user"
+	"go-agent/internal/model"
+	"strings"
+	"time"
+)
+
+var (
+	ErrAPIKeyInvalid  = services.NewError(10501, "API Key 无效")
+	ErrAPIKeyRevoked  = services.NewError(10502, "API Key 已吊销")
+	ErrAPIKeyExpired  = services.NewError(10503, "API Key 已过期")
+	ErrOwnerDisabled  = services.NewError(10504, "API Key 所属用户已禁用")
+	ErrScopeForbidden = services.NewError(10505, "API Key 无权访问该接口")
+)
+
+// 授权范围
+const (
+	ScopeAll       = auth.PermissionAll // 全部接口
+	ScopeAgentChat = "agent:chat"
+)
+
+// touchInterval 最后使用时间的更新间隔，避免每次请求都写库
+const touchInterval = time.Minute
+
+type Service struct {
+	keys  dao.APIKey
+	users dao.User
+	now   func() time.Time
+}
+
+func NewService() *Service {
+	return &Service{
+		keys:  api_key.NewDao(),
+		users: user.NewDao(),
+		now:   time.Now,
+	}
+}
+
+// Scopes 解析逗号分隔的授权范围
+func Scopes(scopes string) []string {
+	var result []string
+	for _, scope := range strings.Split(scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			result = append(result, scope)
+		}
+	}
+	return result
+}
+
+// HasScope key 是否授权了 scope，scope 与权限格式相同，支持 admin:* 及 * 通配
+func HasScope(key *model.SApiKey, scope string) bool {
+	return auth.HasPermission(Scopes(key.Scopes), scope)
+}
@@ -0,0 +1,48 @@
+package apikey
+
+import (
+	"context"
+	"go-agent/gopkg/auth"
+	"go-agent/gopkg/log"
+	"go-agent/internal/dao"
+	"go-agent/internal/model"
+)
+
+func (s *Service) Authenticate(ctx context.Context, key string) (*model.SApiKey, error) {
+	if !auth.IsAPIKey(key) {
+		return nil, ErrAPIKeyInvalid
+	}
+	k, err := s.keys.FindByHash(ctx, auth.HashAPIKey(key))
+	if err != nil {
+		return nil, err
+	}
+	if k == nil {
+		return nil, ErrAPIKeyInvalid
+	}
+	if k.Status != dao.APIKeyStatusOn {
+		return nil, ErrAPIKeyRevoked
+	}
+	now := s.now()
+	if k.ExpiresAt != nil && !now.Before(*k.ExpiresAt) {
+		return nil, ErrAPIKeyExpired
+	}
+
+	// 所属用户禁用后其 API Key 同时失效
+	u, err := s.users.FindByUserID(ctx, k.OwnerId)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil || u.Status != dao.UserStatusOn {
+		return nil, ErrOwnerDisabled
+	}
+
+	if k.LastUsedAt == nil || now.Sub(*k.LastUsedAt) >= touchInterval {
+		// 记录失败不影响本次请求
+		if err := s.keys.TouchLastUsed(ctx, k.KeyId, now); err != nil {
+			log.Sugar().Warnf("api key %s touch last used failed: %v", k.KeyId, err)
+		} else {
+			k.LastUsedAt = &now
+		}
+	}
+	return k, nil
+}
@@ -0,0 +1,81 @@
+package apikey
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-agent/gopkg/auth"
+	"go-agent/internal/dao"
+	"go-agent/internal/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryKeys struct {
+	dao.APIKey
+	keys    map[string]*model.SApiKey
+	touched int
+}
+
+func (m *memoryKeys) FindByHash(_ context.Context, keyHash string) (*model.SApiKey, error) {
+	return m.keys[keyHash], nil
+}
+
+func (m *memoryKeys) TouchLastUsed(_ context.Context, _ string, _ time.Time) error {
+	m.touched++
+	return nil
+}
+
+type memoryUsers struct {
+	dao.User
+	users map[string]*model.SUser
+}
+
+func (m *memoryUsers) FindByUserID(_ context.Context, userID string) (*model.SUser, error) {
+	return m.users[userID], nil
+}
+
+func TestService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	key, prefix, hash, err := auth.GenerateAPIKey()
+	assert.NoError(t, err)
+	assert.Equal(t, prefix, key[:len(prefix)])
+
+	keys := &memoryKeys{keys: map[string]*model.SApiKey{
+		hash: {KeyId: "k1", KeyHash: hash, OwnerId: "u1", Scopes: "agent:chat", Status: dao.APIKeyStatusOn},
+	}}
+	users := &memoryUsers{users: map[string]*model.SUser{
+		"u1": {UserId: "u1", Status: dao.UserStatusOn},
+	}}
+	s := &Service{keys: keys, users: users, now: func() time.Time { return now }}
+
+	k, err := s.Authenticate(ctx, key)
+	assert.NoError(t, err)
+	assert.Equal(t, "k1", k.KeyId)
+	assert.True(t, HasScope(k, ScopeAgentChat))
+	assert.False(t, HasScope(k, "admin"))
+
+	// 间隔内不重复记录最后使用时间
+	_, err = s.Authenticate(ctx, key)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, keys.touched)
+
+	_, err = s.Authenticate(ctx, key+"x")
+	assert.Equal(t, ErrAPIKeyInvalid, err)
+
+	expiresAt := now
+	keys.keys[hash].ExpiresAt = &expiresAt
+	_, err = s.Authenticate(ctx, key)
+	assert.Equal(t, ErrAPIKeyExpired, err)
+
+	keys.keys[hash].ExpiresAt = nil
+	users.users["u1"].Status = dao.UserStatusOff
+	_, err = s.Authenticate(ctx, key)
+	assert.Equal(t, ErrOwnerDisabled, err)
+
+	keys.keys[hash].Status = dao.APIKeyStatusRevoked
+	_, err = s.Authenticate(ctx, key)
+	assert.Equal(t, ErrAPIKeyRevoked, err)
+}
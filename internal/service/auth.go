@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+	"go-agent/gopkg/auth"
+	"go-agent/gopkg/services"
+)
+
+type Auth interface {
+	Login(ctx context.Context, username, password string) (services.Result, error)
+	Refresh(ctx context.Context, refreshToken string) (services.Result, error)
+	Logout(ctx context.Context, claims *auth.Claims) (services.Result, error)
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"go-agent/gopkg/auth"
+	"go-agent/gopkg/services"
+	"go-agent/internal/dao"
+	"go-agent/internal/dao/user"
+)
+
+var (
+	ErrLoginFailed       = services.NewError(10401, "用户名或密码错误")
+	ErrUserDisabled      = services.NewError(10402, "用户已禁用")
+	ErrTokenInvalid      = services.NewError(10403, "token 无效")
+	ErrTokenExpired      = services.NewError(10404, "token 已过期")
+	ErrTokenRevoked      = services.NewError(10405, "token 已失效")
+	ErrAuthNotConfigured = services.NewError(10406, "认证未配置")
+	ErrUnauthorized      = services.NewError(10407, "未登录")
+)
+
+type Service struct {
+	tokens *auth.TokenManager
+	users  dao.User
+}
+
+func NewService() *Service {
+	return &Service{
+		tokens: auth.Default(),
+		users:  user.NewDao(),
+	}
+}
+
+// TokenError 将 token 校验错误转换为业务错误，其他错误原样返回
+func TokenError(err error) error {
+	switch {
+	case errors.Is(err, auth.ErrTokenExpired):
+		return ErrTokenExpired
+	case errors.Is(err, auth.ErrTokenRevoked):
+		return ErrTokenRevoked
+	case errors.Is(err, auth.ErrTokenInvalid):
+		return ErrTokenInvalid
+	default:
+		return err
+	}
+}
+
+// failed 将 token 错误转换为业务错误
+func (s *Service) failed(ctx context.Context, err error) (services.Result, error) {
+	return services.Failed(ctx, TokenError(err))
+}
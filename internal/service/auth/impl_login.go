@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"context"
+	"go-agent/gopkg/services"
+	"go-agent/internal/dao"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// dummyHash 用户不存在时也进行一次 bcrypt 比较，避免通过响应时间判断用户是否存在
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("go-agent"), bcrypt.DefaultCost)
+
+func (s *Service) Login(ctx context.Context, username, password string) (services.Result, error) {
+	if s.tokens == nil {
+		return services.Failed(ctx, ErrAuthNotConfigured)
+	}
+
+	u, err := s.users.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return services.Failed(ctx, ErrLoginFailed)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)); err != nil {
+		return services.Failed(ctx, ErrLoginFailed)
+	}
+	if u.Status != dao.UserStatusOn {
+		return services.Failed(ctx, ErrUserDisabled)
+	}
+
+	pair, err := s.tokens.Issue(u.UserId, u.TeamId)
+	if err != nil {
+		return nil, err
+	}
+
+	return services.Success(ctx, pair)
+}
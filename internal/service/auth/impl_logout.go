@@ -0,0 +1,22 @@
+package auth
+
+import (
+	"context"
+	"go-agent/gopkg/auth"
+	"go-agent/gopkg/services"
+)
+
+// Logout 吊销 access token 所属的会话，会话内的 refresh token 同时失效
+func (s *Service) Logout(ctx context.Context, claims *auth.Claims) (services.Result, error) {
+	if s.tokens == nil {
+		return services.Failed(ctx, ErrAuthNotConfigured)
+	}
+	if claims == nil {
+		return services.Failed(ctx, ErrUnauthorized)
+	}
+	if err := s.tokens.RevokeSession(ctx, claims); err != nil {
+		return nil, err
+	}
+
+	return services.Success(ctx, nil)
+}
@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"context"
+	"go-agent/gopkg/services"
+	"go-agent/internal/dao"
+)
+
+func (s *Service) Refresh(ctx context.Context, refreshToken string) (services.Result, error) {
+	if s.tokens == nil {
+		return services.Failed(ctx, ErrAuthNotConfigured)
+	}
+
+	claims, err := s.tokens.ParseRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return s.failed(ctx, err)
+	}
+
+	// 用户被禁用后不再续期，并吊销当前会话
+	u, err := s.users.FindByUserID(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil || u.Status != dao.UserStatusOn {
+		if err := s.tokens.RevokeSession(ctx, claims); err != nil {
+			return nil, err
+		}
+		return services.Failed(ctx, ErrUserDisabled)
+	}
+
+	pair, err := s.tokens.Rotate(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return services.Success(ctx, pair)
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+	"go-agent/gopkg/services"
+)
+
+type Cron interface {
+	List(ctx context.Context) (services.Result, error)
+	Run(ctx context.Context, name string) (services.Result, error)
+	Pause(ctx context.Context, name string) (services.Result, error)
+	Resume(ctx context.Context, name string) (services.Result, error)
+	UpdateSpec(ctx context.Context, name, spec string) (services.Result, error)
+}
@@ -0,0 +1,42 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"go-agent/gopkg/cron"
+	rxCron "go-agent/gopkg/cron/base"
+	"go-agent/gopkg/services"
+)
+
+var (
+	ErrCronNotEnabled = services.NewError(10101, "定时任务未开启")
+	ErrJobNotFound    = services.NewError(10102, "定时任务不存在")
+	ErrJobDisabled    = services.NewError(10103, "定时任务未启用")
+	ErrSpecInvalid    = services.NewError(10104, "定时任务 spec 格式错误")
+)
+
+type Service struct {
+}
+
+func NewService() *Service {
+	return &Service{}
+}
+
+// scheduler 当前进程的调度器，cron.switch 关闭时为 nil
+func (s *Service) scheduler() *rxCron.Scheduler {
+	return cron.Scheduler()
+}
+
+// failed 将调度器错误转换为业务错误
+func (s *Service) failed(ctx context.Context, err error) (services.Result, error) {
+	switch {
+	case errors.Is(err, rxCron.ErrJobNotFound):
+		return services.Failed(ctx, ErrJobNotFound)
+	case errors.Is(err, rxCron.ErrJobDisabled):
+		return services.Failed(ctx, ErrJobDisabled)
+	case errors.Is(err, rxCron.ErrInvalidSpec):
+		return services.Failed(ctx, ErrSpecInvalid)
+	default:
+		return nil, err
+	}
+}
@@ -0,0 +1,15 @@
+package cron
+
+import (
+	"context"
+	"go-agent/gopkg/services"
+)
+
+func (s *Service) List(ctx context.Context) (services.Result, error) {
+	scheduler := s.scheduler()
+	if scheduler == nil {
+		return services.Failed(ctx, ErrCronNotEnabled)
+	}
+
+	return services.Success(ctx, scheduler.Jobs())
+}
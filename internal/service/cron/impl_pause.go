@@ -0,0 +1,40 @@
+package cron
+
+import (
+	"context"
+	"go-agent/gopkg/services"
+)
+
+func (s *Service) Pause(ctx context.Context, name string) (services.Result, error) {
+	scheduler := s.scheduler()
+	if scheduler == nil {
+		return services.Failed(ctx, ErrCronNotEnabled)
+	}
+	if err := scheduler.Pause(ctx, name); err != nil {
+		return s.failed(ctx, err)
+	}
+
+	return s.detail(ctx, name)
+}
+
+func (s *Service) Resume(ctx context.Context, name string) (services.Result, error) {
+	scheduler := s.scheduler()
+	if scheduler == nil {
+		return services.Failed(ctx, ErrCronNotEnabled)
+	}
+	if err := scheduler.Resume(ctx, name); err != nil {
+		return s.failed(ctx, err)
+	}
+
+	return s.detail(ctx, name)
+}
+
+// detail 返回任务最新信息
+func (s *Service) detail(ctx context.Context, name string) (services.Result, error) {
+	job, err := s.scheduler().Job(name)
+	if err != nil {
+		return s.failed(ctx, err)
+	}
+
+	return services.Success(ctx, job)
+}
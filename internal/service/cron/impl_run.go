@@ -0,0 +1,18 @@
+package cron
+
+import (
+	"context"
+	"go-agent/gopkg/services"
+)
+
+func (s *Service) Run(ctx context.Context, name string) (services.Result, error) {
+	scheduler := s.scheduler()
+	if scheduler == nil {
+		return services.Failed(ctx, ErrCronNotEnabled)
+	}
+	if err := scheduler.Trigger(ctx, name); err != nil {
+		return s.failed(ctx, err)
+	}
+
+	return services.Success(ctx, nil)
+}
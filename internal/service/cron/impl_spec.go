@@ -0,0 +1,18 @@
+package cron
+
+import (
+	"context"
+	"go-agent/gopkg/services"
+)
+
+func (s *Service) UpdateSpec(ctx context.Context, name, spec string) (services.Result, error) {
+	scheduler := s.scheduler()
+	if scheduler == nil {
+		return services.Failed(ctx, ErrCronNotEnabled)
+	}
+	if err := scheduler.UpdateSpec(ctx, name, spec); err != nil {
+		return s.failed(ctx, err)
+	}
+
+	return s.detail(ctx, name)
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+	"go-agent/gopkg/services"
+)
+
+type Quota interface {
+	List(ctx context.Context, subjectType, subjectID string) (services.Result, error)
+	// Save 设置主体一个周期的 token 额度，立即失效额度缓存
+	Save(ctx context.Context, subjectType, subjectID, period string, tokenLimit int64) (services.Result, error)
+	Delete(ctx context.Context, subjectType, subjectID, period string) (services.Result, error)
+}
@@ -0,0 +1,47 @@
+package quota
+
+import (
+	"context"
+	"go-agent/gopkg/cache"
+	"go-agent/gopkg/services"
+	agentQuota "go-agent/internal/agent/quota"
+	"go-agent/internal/dao"
+	"go-agent/internal/dao/quota"
+)
+
+var (
+	ErrSubjectTypeInvalid = services.NewError(10302, "额度主体类型错误")
+	ErrPeriodInvalid      = services.NewError(10303, "额度周期错误")
+	ErrLimitInvalid       = services.NewError(10304, "额度不能小于 0")
+	ErrQuotaNotFound      = services.NewError(10305, "额度不存在")
+)
+
+type Service struct {
+	quotas dao.Quota
+}
+
+func NewService() *Service {
+	return &Service{
+		quotas: quota.NewDao(),
+	}
+}
+
+// validate 校验主体类型及周期
+func validate(subjectType, period string) *services.BaseError {
+	switch subjectType {
+	case agentQuota.SubjectUser, agentQuota.SubjectTeam, agentQuota.SubjectAPIKey:
+	default:
+		return ErrSubjectTypeInvalid
+	}
+	switch period {
+	case agentQuota.PeriodDaily, agentQuota.PeriodMonthly:
+	default:
+		return ErrPeriodInvalid
+	}
+	return nil
+}
+
+// invalidate 失效额度配置缓存，使修改立即对额度检查生效
+func (s *Service) invalidate(ctx context.Context) error {
+	return cache.InvalidateTags(ctx, agentQuota.CacheTag)
+}
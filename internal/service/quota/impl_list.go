@@ -0,0 +1,15 @@
+package quota
+
+import (
+	"context"
+	"go-agent/gopkg/services"
+)
+
+func (s *Service) List(ctx context.Context, subjectType, subjectID string) (services.Result, error) {
+	rows, err := s.quotas.FindBySubject(ctx, subjectType, subjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return services.Success(ctx, rows)
+}
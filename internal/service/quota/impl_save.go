@@ -0,0 +1,46 @@
+package quota
+
+import (
+	"context"
+	"go-agent/gopkg/services"
+	"go-agent/internal/model"
+)
+
+func (s *Service) Save(ctx context.Context, subjectType, subjectID, period string, tokenLimit int64) (services.Result, error) {
+	if err := validate(subjectType, period); err != nil {
+		return services.Failed(ctx, err)
+	}
+	if tokenLimit < 0 {
+		return services.Failed(ctx, ErrLimitInvalid)
+	}
+
+	row := &model.SQuota{
+		SubjectType: subjectType,
+		SubjectId:   subjectID,
+		Period:      period,
+		TokenLimit:  tokenLimit,
+	}
+	if err := s.quotas.Save(ctx, row); err != nil {
+		return nil, err
+	}
+	if err := s.invalidate(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.List(ctx, subjectType, subjectID)
+}
+
+func (s *Service) Delete(ctx context.Context, subjectType, subjectID, period string) (services.Result, error) {
+	ok, err := s.quotas.Delete(ctx, subjectType, subjectID, period)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return services.Failed(ctx, ErrQuotaNotFound)
+	}
+	if err := s.invalidate(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.List(ctx, subjectType, subjectID)
+}
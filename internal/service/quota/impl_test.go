@@ -0,0 +1,79 @@
+package quota
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-agent/gopkg/cache"
+	"go-agent/gopkg/utils"
+	agentQuota "go-agent/internal/agent/quota"
+	"go-agent/internal/dao"
+	"go-agent/internal/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryQuotas struct {
+	dao.Quota
+	rows map[string]*model.SQuota
+}
+
+func (m *memoryQuotas) FindBySubject(_ context.Context, subjectType, subjectID string) ([]*model.SQuota, error) {
+	var rows []*model.SQuota
+	for _, row := range m.rows {
+		if row.SubjectType == subjectType && row.SubjectId == subjectID {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (m *memoryQuotas) Save(_ context.Context, quota *model.SQuota) error {
+	m.rows[quota.SubjectType+":"+quota.SubjectId+":"+quota.Period] = quota
+	return nil
+}
+
+type memoryCounter map[string]int64
+
+func (m memoryCounter) Reserve(_ context.Context, key string, n, limit int64, _ time.Time) (int64, bool, error) {
+	if m[key]+n > limit {
+		return m[key], false, nil
+	}
+	m[key] += n
+	return m[key], true, nil
+}
+
+func (m memoryCounter) Add(_ context.Context, key string, n int64) error {
+	m[key] += n
+	return nil
+}
+
+func TestService_Save(t *testing.T) {
+	cache.SetDefault(cache.New(nil, cache.WithLocal(10, time.Minute)))
+	defer cache.SetDefault(nil)
+
+	ctx := utils.SetUserID(context.Background(), "u1")
+	quotas := &memoryQuotas{rows: map[string]*model.SQuota{}}
+	s := &Service{quotas: quotas}
+	enforcer := agentQuota.NewEnforcer(memoryCounter{}, quotas, agentQuota.Config{CacheTTL: time.Minute})
+
+	res, err := s.Save(ctx, agentQuota.SubjectUser, "u1", agentQuota.PeriodDaily, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, res.GetCode())
+	_, err = enforcer.Reserve(ctx, 150)
+	assert.ErrorIs(t, err, agentQuota.ErrQuotaExceeded)
+
+	// 修改后立即生效，不等待缓存过期
+	_, err = s.Save(ctx, agentQuota.SubjectUser, "u1", agentQuota.PeriodDaily, 200)
+	assert.NoError(t, err)
+	_, err = enforcer.Reserve(ctx, 150)
+	assert.NoError(t, err)
+
+	res, err = s.Save(ctx, "group", "u1", agentQuota.PeriodDaily, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrSubjectTypeInvalid.GetCode(), res.GetCode())
+	res, err = s.Save(ctx, agentQuota.SubjectUser, "u1", "weekly", 100)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrPeriodInvalid.GetCode(), res.GetCode())
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+	"go-agent/gopkg/services"
+)
+
+type RBAC interface {
+	// Permissions 用户通过角色获得的全部权限，结果缓存在 Redis
+	Permissions(ctx context.Context, userID string) ([]string, error)
+	ListRoles(ctx context.Context) (services.Result, error)
+	UserRoles(ctx context.Context, userID string) (services.Result, error)
+	AssignRole(ctx context.Context, userID, roleName string) (services.Result, error)
+	UnassignRole(ctx context.Context, userID, roleName string) (services.Result, error)
+}
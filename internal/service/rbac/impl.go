@@ -0,0 +1,105 @@
+package rbac
+
+import (
+	"context"
+	"go-agent/gopkg/cache"
+	"go-agent/gopkg/services"
+	"go-agent/internal/dao"
+	"go-agent/internal/dao/role"
+	"go-agent/internal/dao/user"
+	"go-agent/internal/model"
+
+	"github.com/spf13/viper"
+)
+
+var (
+	ErrPermissionDenied = services.NewError(10601, "无权访问")
+	ErrRoleNotFound     = services.NewError(10602, "角色不存在")
+	ErrUserNotFound     = services.NewError(10603, "用户不存在")
+	ErrRoleNotAssigned  = services.NewError(10604, "用户未分配该角色")
+)
+
+// 权限
+const (
+	PermissionAgentChat        = "agent:chat"
+	PermissionPictureBookWrite = "picturebook:write"
+	PermissionCronRead         = "cron:read"
+	PermissionCronWrite        = "cron:write"
+	PermissionTaskRead         = "task:read"
+	PermissionTaskWrite        = "task:write"
+	PermissionTaskAdmin        = "task:admin" // 查看、取消其他用户提交的任务
+	PermissionAdminRoles       = "admin:roles"
+	PermissionAdminQuotas      = "admin:quotas"
+)
+
+// 默认角色，migrate up 时不存在则创建
+const (
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+)
+
+// DefaultRoles 默认角色的权限
+var DefaultRoles = map[string][]string{
+	RoleAdmin:  {"*"},
+	RoleMember: {PermissionAgentChat},
+}
+
+// CacheTag 全部用户权限的缓存标签，角色权限变更后通过 InvalidateAll 失效
+const CacheTag = "rbac"
+
+// Role 角色及其权限
+type Role struct {
+	*model.SRole
+	Permissions []string `json:"permissions"`
+}
+
+// UserRoles 用户的角色及权限
+type UserRoles struct {
+	UserId      string   `json:"user_id"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+}
+
+type Service struct {
+	roles dao.Role
+	users dao.User
+	// defaultPermissions 未分配任何角色的用户拥有的权限
+	defaultPermissions []string
+}
+
+func NewService() *Service {
+	return NewServiceWith(role.NewDao(), user.NewDao(), DefaultPermissions())
+}
+
+// NewServiceWith 使用指定的 dao 实例化Service，用于测试
+func NewServiceWith(roles dao.Role, users dao.User, defaultPermissions []string) *Service {
+	return &Service{
+		roles:              roles,
+		users:              users,
+		defaultPermissions: defaultPermissions,
+	}
+}
+
+// DefaultPermissions 未分配任何角色的用户拥有的权限，读取 rbac.default_permissions，
+// 未配置时为 agent:chat，兼容引入角色前只校验 API Key 授权范围的已有用户
+func DefaultPermissions() []string {
+	if !viper.IsSet("rbac.default_permissions") {
+		return []string{PermissionAgentChat}
+	}
+	return viper.GetStringSlice("rbac.default_permissions")
+}
+
+// UserCacheTag 用户权限的缓存标签
+func UserCacheTag(userID string) string {
+	return CacheTag + ":user:" + userID
+}
+
+// InvalidateUser 用户角色变更后失效其权限缓存
+func InvalidateUser(ctx context.Context, userID string) error {
+	return cache.InvalidateTags(ctx, UserCacheTag(userID))
+}
+
+// InvalidateAll 角色权限变更后失效全部用户的权限缓存
+func InvalidateAll(ctx context.Context) error {
+	return cache.InvalidateTags(ctx, CacheTag)
+}
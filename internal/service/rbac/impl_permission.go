@@ -0,0 +1,42 @@
+package rbac
+
+import (
+	"context"
+	"go-agent/gopkg/cache"
+	"time"
+)
+
+// permissionCacheTTL 用户权限的缓存时间，角色变更时主动失效
+const permissionCacheTTL = 10 * time.Minute
+
+func (s *Service) Permissions(ctx context.Context, userID string) ([]string, error) {
+	return cache.GetOrLoad(ctx, "rbac:user:"+userID, permissionCacheTTL, func(ctx context.Context) ([]string, error) {
+		_, permissions, err := s.userPermissions(ctx, userID)
+		return permissions, err
+	}, cache.WithTags(CacheTag, UserCacheTag(userID)))
+}
+
+// userPermissions 查询用户的角色及去重后的权限，未分配任何角色时使用 defaultPermissions
+func (s *Service) userPermissions(ctx context.Context, userID string) ([]string, []string, error) {
+	roles, err := s.roles.UserRoles(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(roles) == 0 {
+		return roles, append([]string{}, s.defaultPermissions...), nil
+	}
+	rows, err := s.roles.Permissions(ctx, roles...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	seen := make(map[string]bool, len(rows))
+	permissions := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if !seen[row.Permission] {
+			seen[row.Permission] = true
+			permissions = append(permissions, row.Permission)
+		}
+	}
+	return roles, permissions, nil
+}
@@ -0,0 +1,98 @@
+package rbac
+
+import (
+	"context"
+	"go-agent/gopkg/log"
+	"go-agent/gopkg/services"
+)
+
+func (s *Service) ListRoles(ctx context.Context) (services.Result, error) {
+	roles, err := s.roles.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(roles))
+	for _, r := range roles {
+		names = append(names, r.Name)
+	}
+	rows, err := s.roles.Permissions(ctx, names...)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := make(map[string][]string, len(roles))
+	for _, row := range rows {
+		permissions[row.RoleName] = append(permissions[row.RoleName], row.Permission)
+	}
+	list := make([]*Role, 0, len(roles))
+	for _, r := range roles {
+		list = append(list, &Role{SRole: r, Permissions: permissions[r.Name]})
+	}
+
+	return services.Success(ctx, list)
+}
+
+func (s *Service) UserRoles(ctx context.Context, userID string) (services.Result, error) {
+	u, err := s.users.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return services.Failed(ctx, ErrUserNotFound)
+	}
+
+	return s.userRoles(ctx, userID)
+}
+
+func (s *Service) AssignRole(ctx context.Context, userID, roleName string) (services.Result, error) {
+	u, err := s.users.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return services.Failed(ctx, ErrUserNotFound)
+	}
+	r, err := s.roles.FindByName(ctx, roleName)
+	if err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return services.Failed(ctx, ErrRoleNotFound)
+	}
+
+	if err := s.roles.AssignUser(ctx, userID, roleName); err != nil {
+		return nil, err
+	}
+	s.invalidateUser(ctx, userID)
+
+	return s.userRoles(ctx, userID)
+}
+
+func (s *Service) UnassignRole(ctx context.Context, userID, roleName string) (services.Result, error) {
+	ok, err := s.roles.UnassignUser(ctx, userID, roleName)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return services.Failed(ctx, ErrRoleNotAssigned)
+	}
+	s.invalidateUser(ctx, userID)
+
+	return s.userRoles(ctx, userID)
+}
+
+func (s *Service) userRoles(ctx context.Context, userID string) (services.Result, error) {
+	roles, permissions, err := s.userPermissions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return services.Success(ctx, &UserRoles{UserId: userID, Roles: roles, Permissions: permissions})
+}
+
+// invalidateUser 失效用户权限缓存，失败时只记录日志，缓存在 permissionCacheTTL 后过期
+func (s *Service) invalidateUser(ctx context.Context, userID string) {
+	if err := InvalidateUser(ctx, userID); err != nil {
+		log.Sugar().Warnf("rbac invalidate user %s cache failed: %v", userID, err)
+	}
+}
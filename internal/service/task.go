@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"go-agent/gopkg/paging"
+	"go-agent/gopkg/services"
+)
+
+type Task interface {
+	// Detail 查询任务状态，owner 不为空时只能查询该用户提交的任务
+	Detail(ctx context.Context, id, owner string) (services.Result, error)
+	// Cancel 取消任务，owner 不为空时只能取消该用户提交的任务
+	Cancel(ctx context.Context, id, owner string) (services.Result, error)
+	DeadLetterList(ctx context.Context, page paging.Page) (services.Result, error)
+	DeadLetterRequeue(ctx context.Context, id string) (services.Result, error)
+	DeadLetterDelete(ctx context.Context, id string) (services.Result, error)
+	DeadLetterPurge(ctx context.Context) (services.Result, error)
+	WorkflowDetail(ctx context.Context, id string) (services.Result, error)
+}
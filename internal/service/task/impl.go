@@ -0,0 +1,46 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"go-agent/gopkg/services"
+	"go-agent/internal/worker/base"
+	"go-agent/internal/worker/workflow"
+)
+
+var (
+	ErrQueueNotConfigured       = services.NewError(10001, "任务队列未配置")
+	ErrDeadLetterNotFound       = services.NewError(10002, "死信任务不存在")
+	ErrDeadLetterNotRequeueable = services.NewError(10003, "进程内任务不支持重新入队")
+	ErrTaskNotFound             = services.NewError(10004, "任务不存在或已过期")
+	ErrTaskFinished             = services.NewError(10005, "任务已结束，无法取消")
+	ErrWorkflowNotFound         = services.NewError(10006, "工作流不存在或已过期")
+)
+
+type Service struct {
+	queue       base.Queue
+	deadLetters base.DeadLetterStore
+	status      base.StatusStore
+	workflows   workflow.Store
+}
+
+func NewService() *Service {
+	return &Service{
+		queue:       base.DefaultQueue(),
+		deadLetters: base.DefaultDeadLetters(),
+		status:      base.DefaultStatus(),
+		workflows:   workflow.DefaultStore(),
+	}
+}
+
+// failed 将任务状态错误转换为业务错误
+func (s *Service) failed(ctx context.Context, err error) (services.Result, error) {
+	switch {
+	case errors.Is(err, base.ErrTaskNotFound):
+		return services.Failed(ctx, ErrTaskNotFound)
+	case errors.Is(err, base.ErrTaskFinished):
+		return services.Failed(ctx, ErrTaskFinished)
+	default:
+		return nil, err
+	}
+}
@@ -0,0 +1,19 @@
+package task
+
+import (
+	"context"
+	"go-agent/gopkg/services"
+	"go-agent/internal/worker/base"
+)
+
+func (s *Service) Cancel(ctx context.Context, id, owner string) (services.Result, error) {
+	if _, err := s.record(ctx, id, owner); err != nil {
+		return s.failed(ctx, err)
+	}
+	record, err := base.CancelTask(ctx, s.status, id)
+	if err != nil {
+		return s.failed(ctx, err)
+	}
+
+	return services.Success(ctx, record)
+}
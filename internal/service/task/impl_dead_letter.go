@@ -0,0 +1,78 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"go-agent/gopkg/paging"
+	"go-agent/gopkg/services"
+	"go-agent/internal/worker/base"
+)
+
+func (s *Service) DeadLetterList(ctx context.Context, page paging.Page) (services.Result, error) {
+	if s.deadLetters == nil {
+		return services.Failed(ctx, ErrQueueNotConfigured)
+	}
+
+	if page.PageIndex < 1 {
+		page.PageIndex = 1
+	}
+	if page.PageSize < 1 {
+		page.PageSize = 20
+	}
+
+	letters, total, err := s.deadLetters.List(ctx, (page.PageIndex-1)*page.PageSize, page.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return services.Success(ctx, paging.NewPaging(total, letters))
+}
+
+func (s *Service) DeadLetterRequeue(ctx context.Context, id string) (services.Result, error) {
+	if s.queue == nil || s.deadLetters == nil {
+		return services.Failed(ctx, ErrQueueNotConfigured)
+	}
+
+	job, err := base.RequeueDeadLetter(ctx, s.deadLetters, s.queue, id)
+	switch {
+	case errors.Is(err, base.ErrDeadLetterNotFound):
+		return services.Failed(ctx, ErrDeadLetterNotFound)
+	case errors.Is(err, base.ErrDeadLetterNotRequeueable):
+		return services.Failed(ctx, ErrDeadLetterNotRequeueable)
+	case err != nil:
+		return nil, err
+	}
+
+	return services.Success(ctx, job)
+}
+
+func (s *Service) DeadLetterDelete(ctx context.Context, id string) (services.Result, error) {
+	if s.deadLetters == nil {
+		return services.Failed(ctx, ErrQueueNotConfigured)
+	}
+
+	if _, err := s.deadLetters.Get(ctx, id); err != nil {
+		if errors.Is(err, base.ErrDeadLetterNotFound) {
+			return services.Failed(ctx, ErrDeadLetterNotFound)
+		}
+		return nil, err
+	}
+	if err := s.deadLetters.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return services.Success(ctx, nil)
+}
+
+func (s *Service) DeadLetterPurge(ctx context.Context) (services.Result, error) {
+	if s.deadLetters == nil {
+		return services.Failed(ctx, ErrQueueNotConfigured)
+	}
+
+	count, err := s.deadLetters.Purge(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return services.Success(ctx, map[string]int{"count": count})
+}
@@ -0,0 +1,28 @@
+package task
+
+import (
+	"context"
+	"go-agent/gopkg/services"
+	"go-agent/internal/worker/base"
+)
+
+func (s *Service) Detail(ctx context.Context, id, owner string) (services.Result, error) {
+	record, err := s.record(ctx, id, owner)
+	if err != nil {
+		return s.failed(ctx, err)
+	}
+
+	return services.Success(ctx, record)
+}
+
+// record 查询任务状态，owner 不为空且不是任务的提交者时按不存在处理，避免泄露其他用户的任务
+func (s *Service) record(ctx context.Context, id, owner string) (*base.TaskRecord, error) {
+	record, err := s.status.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if owner != "" && record.Owner != owner {
+		return nil, base.ErrTaskNotFound
+	}
+	return record, nil
+}
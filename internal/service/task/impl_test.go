@@ -0,0 +1,38 @@
+package task
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-agent/internal/worker/base"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_Owner(t *testing.T) {
+	ctx := context.Background()
+	status := base.NewMemoryStatusStore(time.Hour)
+	assert.NoError(t, status.Save(ctx, &base.TaskRecord{ID: "t1", Owner: "u1", State: base.TaskStateQueued}))
+	s := &Service{status: status}
+
+	// 其他用户按不存在处理
+	res, err := s.Detail(ctx, "t1", "u2")
+	assert.NoError(t, err)
+	assert.Equal(t, ErrTaskNotFound.GetCode(), res.GetCode())
+	res, err = s.Cancel(ctx, "t1", "u2")
+	assert.NoError(t, err)
+	assert.Equal(t, ErrTaskNotFound.GetCode(), res.GetCode())
+
+	res, err = s.Detail(ctx, "t1", "u1")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, res.GetCode())
+
+	// owner 为空表示可操作全部任务
+	res, err = s.Cancel(ctx, "t1", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, res.GetCode())
+	record, err := status.Get(ctx, "t1")
+	assert.NoError(t, err)
+	assert.Equal(t, base.TaskStateCancelled, record.State)
+}
@@ -0,0 +1,20 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"go-agent/gopkg/services"
+	"go-agent/internal/worker/workflow"
+)
+
+func (s *Service) WorkflowDetail(ctx context.Context, id string) (services.Result, error) {
+	record, err := s.workflows.Get(ctx, id)
+	if errors.Is(err, workflow.ErrWorkflowNotFound) {
+		return services.Failed(ctx, ErrWorkflowNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return services.Success(ctx, record)
+}
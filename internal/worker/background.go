@@ -6,36 +6,76 @@ import (
 	"sync"
 	"time"
 
+	"go-agent/gopkg/log"
 	"go-agent/internal/worker/base"
-	"go-agent/internal/worker/order"
+
+	"github.com/spf13/viper"
 )
 
-// BackgroundWorker 后台工作进程
+// defaultStopTimeout Stop 等待任务执行完毕的最长时间
+const defaultStopTimeout = 30 * time.Second
+
+// BackgroundWorker 后台工作进程，在 http 服务进程内执行 SubmitTask 提交的非持久化任务
 type BackgroundWorker struct {
-	manager   *base.TaskManager
-	ctx       context.Context
-	cancel    context.CancelFunc
-	wg        sync.WaitGroup
-	taskQueue chan *base.Task
-	isRunning bool
+	manager     *base.TaskManager
+	mu          sync.RWMutex // 保护 isRunning 及 taskQueue 的关闭，避免向已关闭的通道发送
+	wg          sync.WaitGroup
+	taskQueue   chan *base.Task
+	isRunning   bool
+	stopped     bool
+	stopTimeout time.Duration
+	lostMu      sync.Mutex
+	lost        []string // 提交到任务管理器失败的任务ID
 }
 
 // NewBackgroundWorker 创建新的后台工作进程
 func NewBackgroundWorker(maxWorkers, queueSize int) *BackgroundWorker {
-	ctx, cancel := context.WithCancel(context.Background())
+	return newBackgroundWorker(base.NewTaskManager(maxWorkers, queueSize), queueSize)
+}
+
+// NewBackgroundWorkerFromViper 根据 worker 配置创建后台工作进程
+func NewBackgroundWorkerFromViper() (*BackgroundWorker, error) {
+	manager, err := base.NewTaskManagerFromViper()
+	if err != nil {
+		return nil, err
+	}
+	queueSize := viper.GetInt("worker.queue_size")
+	if queueSize <= 0 {
+		queueSize = 100
+	}
+	bw := newBackgroundWorker(manager, queueSize)
+	if timeout := viper.GetDuration("worker.shutdown_timeout"); timeout > 0 {
+		bw.stopTimeout = timeout
+	}
+	return bw, nil
+}
 
+func newBackgroundWorker(manager *base.TaskManager, queueSize int) *BackgroundWorker {
 	return &BackgroundWorker{
-		manager:   base.NewTaskManager(maxWorkers, queueSize),
-		ctx:       ctx,
-		cancel:    cancel,
-		taskQueue: make(chan *base.Task, queueSize),
-		isRunning: false,
+		manager:     manager,
+		taskQueue:   make(chan *base.Task, queueSize),
+		isRunning:   false,
+		stopTimeout: defaultStopTimeout,
 	}
 }
 
+var defaultBackground *BackgroundWorker
+
+// Background 默认后台工作进程，未启动 http 服务时为 nil
+func Background() *BackgroundWorker {
+	return defaultBackground
+}
+
+// SetBackground 设置默认后台工作进程
+func SetBackground(bw *BackgroundWorker) {
+	defaultBackground = bw
+}
+
 // Start 启动后台工作进程
 func (bw *BackgroundWorker) Start() {
-	if bw.isRunning {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+	if bw.isRunning || bw.stopped {
 		return
 	}
 
@@ -45,26 +85,54 @@ func (bw *BackgroundWorker) Start() {
 	bw.wg.Add(1)
 	go bw.processTasks()
 
-	fmt.Println("后台工作进程已启动")
+	log.Sugar().Info("后台工作进程已启动")
+}
+
+// Stop 停止后台工作进程，最多等待 worker.shutdown_timeout（默认 30 秒）让已提交的任务执行完毕
+func (bw *BackgroundWorker) Stop() *base.ShutdownReport {
+	ctx, cancel := context.WithTimeout(context.Background(), bw.stopTimeout)
+	defer cancel()
+	return bw.StopAndWait(ctx)
 }
 
-// Stop 停止后台工作进程
-func (bw *BackgroundWorker) Stop() {
+// StopAndWait 停止接收新任务，将队列中的任务交给任务管理器后等待执行完毕，直到 ctx 结束
+func (bw *BackgroundWorker) StopAndWait(ctx context.Context) *base.ShutdownReport {
+	bw.mu.Lock()
 	if !bw.isRunning {
-		return
+		bw.mu.Unlock()
+		return nil
 	}
-
 	bw.isRunning = false
-	bw.cancel()
+	bw.stopped = true
+	// 持有写锁时没有 SubmitTask 正在发送，可以安全关闭通道
 	close(bw.taskQueue)
+	bw.mu.Unlock()
+
 	bw.wg.Wait()
-	bw.manager.Shutdown()
+	report := *bw.manager.StopAndWait(ctx)
+	bw.lostMu.Lock()
+	report.Lost = append(report.Lost, bw.lost...)
+	bw.lostMu.Unlock()
+
+	if len(report.Lost) > 0 {
+		log.Sugar().Warnf("后台工作进程已停止, 丢失任务: %v", report.Lost)
+	} else {
+		log.Sugar().Info("后台工作进程已停止")
+	}
+	return &report
+}
 
-	fmt.Println("后台工作进程已停止")
+// GracefulStart 实现 graceful.Graceful，ctx 取消时停止并等待任务执行完毕
+func (bw *BackgroundWorker) GracefulStart(ctx context.Context) {
+	bw.Start()
+	<-ctx.Done()
+	bw.Stop()
 }
 
 // SubmitTask 提交任务到后台工作进程
 func (bw *BackgroundWorker) SubmitTask(task *base.Task) error {
+	bw.mu.RLock()
+	defer bw.mu.RUnlock()
 	if !bw.isRunning {
 		return fmt.Errorf("后台工作进程未运行")
 	}
@@ -72,73 +140,32 @@ func (bw *BackgroundWorker) SubmitTask(task *base.Task) error {
 	select {
 	case bw.taskQueue <- task:
 		return nil
-	case <-bw.ctx.Done():
-		return fmt.Errorf("后台工作进程已停止")
 	default:
 		return fmt.Errorf("任务队列已满")
 	}
 }
 
-// processTasks 处理任务队列中的任务
-func (bw *BackgroundWorker) processTasks() {
-	defer bw.wg.Done()
+// Manager 获取任务管理器
+func (bw *BackgroundWorker) Manager() *base.TaskManager {
+	return bw.manager
+}
 
-	for {
-		select {
-		case task, ok := <-bw.taskQueue:
-			if !ok {
-				// 通道已关闭
-				return
-			}
-
-			// 提交任务到任务管理器
-			if err := bw.manager.Submit(task); err != nil {
-				fmt.Printf("提交任务失败: %v\n", err)
-			}
-
-		case <-bw.ctx.Done():
-			// 上下文已取消
-			return
-		}
-	}
+// Enqueue 提交持久化任务，重启后不会丢失
+func (bw *BackgroundWorker) Enqueue(ctx context.Context, jobType string, payload any) (*base.Job, error) {
+	return bw.manager.Enqueue(ctx, jobType, payload)
 }
 
-// SubmitSampleTasks 提交示例任务
-func (bw *BackgroundWorker) SubmitSampleTasks() {
-	// 提交一些示例任务
-	tasks := []*base.Task{
-		order.ProcessOrderTask("bg-001"),
-		order.ProcessOrderTask("bg-002"),
-		order.ProcessRefundTask("bg-refund-001"),
-	}
+// processTasks 处理任务队列中的任务，通道关闭后返回；提交失败的任务记录到关闭结果的 Lost 中
+func (bw *BackgroundWorker) processTasks() {
+	defer bw.wg.Done()
 
-	for _, task := range tasks {
-		if err := bw.SubmitTask(task); err != nil {
-			fmt.Printf("提交示例任务失败 %s: %v\n", task.ID, err)
-		} else {
-			fmt.Printf("成功提交示例任务: %s\n", task.ID)
+	for task := range bw.taskQueue {
+		// 提交任务到任务管理器
+		if err := bw.manager.Submit(task); err != nil {
+			log.Sugar().Errorf("提交任务失败: %s (ID: %s), 错误: %v", task.Name, task.ID, err)
+			bw.lostMu.Lock()
+			bw.lost = append(bw.lost, task.ID)
+			bw.lostMu.Unlock()
 		}
 	}
-
-	// 定时提交更多任务
-	go func() {
-		ticker := time.NewTicker(30 * time.Second)
-		defer ticker.Stop()
-
-		taskCounter := 3
-		for {
-			select {
-			case <-ticker.C:
-				taskCounter++
-				task := order.ProcessOrderTask(fmt.Sprintf("bg-timed-%d", taskCounter))
-				if err := bw.SubmitTask(task); err != nil {
-					fmt.Printf("提交定时任务失败 %s: %v\n", task.ID, err)
-				} else {
-					fmt.Printf("成功提交定时任务: %s\n", task.ID)
-				}
-			case <-bw.ctx.Done():
-				return
-			}
-		}
-	}()
 }
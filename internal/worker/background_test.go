@@ -0,0 +1,35 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-agent/internal/worker/base"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackgroundWorker_StopAndWait(t *testing.T) {
+	bw := NewBackgroundWorker(2, 10)
+	bw.Start()
+
+	done := make(chan struct{})
+	assert.NoError(t, bw.SubmitTask(&base.Task{ID: "ok", Name: "ok", Function: func(ctx context.Context) error {
+		close(done)
+		return nil
+	}}))
+	<-done
+
+	// 任务管理器已关闭时提交失败的任务记录为丢失
+	bw.Manager().Shutdown()
+	assert.NoError(t, bw.SubmitTask(&base.Task{ID: "lost", Name: "lost", Function: func(ctx context.Context) error {
+		return nil
+	}}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	report := bw.StopAndWait(ctx)
+	assert.Equal(t, []string{"lost"}, report.Lost)
+	assert.Error(t, bw.SubmitTask(&base.Task{ID: "late"}))
+}
@@ -0,0 +1,285 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-agent/gopkg/utils"
+)
+
+// SetQueue 设置持久化队列，未设置时使用 InitFromViper 初始化的默认队列
+func (tm *TaskManager) SetQueue(queue Queue, cfg QueueConfig) {
+	cfg.format()
+	tm.queue = queue
+	tm.queueCfg = cfg
+}
+
+// SetDeadLetters 设置死信存储，未设置时使用 InitFromViper 初始化的默认存储
+func (tm *TaskManager) SetDeadLetters(store DeadLetterStore) {
+	tm.deadLetters = store
+}
+
+// SetRegistry 设置任务处理器注册表，默认使用包级注册表
+func (tm *TaskManager) SetRegistry(registry *HandlerRegistry) {
+	tm.registry = registry
+}
+
+// Queue 当前使用的持久化队列
+func (tm *TaskManager) Queue() (Queue, QueueConfig) {
+	if tm.queue != nil {
+		return tm.queue, tm.queueCfg
+	}
+	return defaultQueue, defaultQueueCfg
+}
+
+// DeadLetters 当前使用的死信存储
+func (tm *TaskManager) DeadLetters() DeadLetterStore {
+	if tm.deadLetters != nil {
+		return tm.deadLetters
+	}
+	return defaultDeadLetters
+}
+
+// Enqueue 将命名任务持久化到队列，由 Consume 所在的进程执行，ctx 中的用户ID记录为任务的提交者
+func (tm *TaskManager) Enqueue(ctx context.Context, jobType string, payload any) (*Job, error) {
+	queue, _ := tm.Queue()
+	if queue == nil {
+		return nil, ErrQueueNotConfigured
+	}
+	if tm.IsClosed() {
+		return nil, ErrManagerClosed
+	}
+
+	job, err := NewJob(jobType, payload)
+	if err != nil {
+		return nil, err
+	}
+	tm.saveStatus(&TaskRecord{
+		ID:        job.ID,
+		Name:      job.Type,
+		Owner:     utils.GetUserID(ctx),
+		State:     TaskStateQueued,
+		CreatedAt: job.CreatedAt,
+	})
+	if err := queue.Enqueue(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// RequeueDeadLetter 将死信重新入队
+func (tm *TaskManager) RequeueDeadLetter(ctx context.Context, id string) (*Job, error) {
+	queue, _ := tm.Queue()
+	return RequeueDeadLetter(ctx, tm.DeadLetters(), queue, id)
+}
+
+// Consume 持续从队列中取出任务并提交到线程池执行，直到 ctx 取消或任务管理器关闭
+//
+// types 为空时消费注册表中的全部任务类型。
+func (tm *TaskManager) Consume(ctx context.Context, types ...string) error {
+	queue, cfg := tm.Queue()
+	if queue == nil {
+		return ErrQueueNotConfigured
+	}
+	if len(types) == 0 {
+		types = tm.registry.Types()
+	}
+	if len(types) == 0 {
+		return fmt.Errorf("no job handler registered")
+	}
+
+	// 进程崩溃后残留的 inflight 任务在启动时恢复
+	tm.recoverJobs(ctx, queue)
+
+	recoverTicker := time.NewTicker(cfg.RecoverInterval)
+	defer recoverTicker.Stop()
+
+	for !tm.IsClosed() {
+		// 没有空闲 worker 或任务类型均达到限制时不取任务，避免任务在本地排队期间可见性超时
+		var ready []string
+		if tm.sched.idle() {
+			ready = tm.sched.ready(types)
+		}
+		if len(ready) == 0 {
+			if !tm.waitConsume(ctx, recoverTicker, queue, dispatchInterval) {
+				return nil
+			}
+			continue
+		}
+
+		job, err := queue.Dequeue(ctx, ready, cfg.VisibilityTimeout)
+		if err != nil || job == nil {
+			if err != nil {
+				tm.logger.Errorf("任务出队失败: %v", err)
+			}
+			if !tm.waitConsume(ctx, recoverTicker, queue, cfg.PollInterval) {
+				return nil
+			}
+			continue
+		}
+
+		if err := tm.sched.push(&scheduledTask{
+			id:       job.ID,
+			taskType: job.Type,
+			run: func() {
+				tm.runJob(queue, cfg, job)
+			},
+			abandon: func() {
+				// 关闭时尚未执行的任务放回队列，由其他 worker 进程执行
+				_ = queue.Nack(context.Background(), job, 0)
+			},
+		}, PriorityNormal); err != nil {
+			tm.logger.Errorf("提交持久化任务失败: %s (ID: %s), 错误: %v", job.Type, job.ID, err)
+			_ = queue.Nack(context.Background(), job, 0)
+		}
+	}
+	return nil
+}
+
+// waitConsume 等待下一次取任务，期间按时恢复超时任务，ctx 取消或任务管理器关闭时返回 false
+func (tm *TaskManager) waitConsume(ctx context.Context, recoverTicker *time.Ticker, queue Queue, wait time.Duration) bool {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-tm.ctx.Done():
+			return false
+		case <-recoverTicker.C:
+			tm.recoverJobs(ctx, queue)
+		case <-timer.C:
+			return true
+		}
+	}
+}
+
+// runJob 执行持久化任务，失败后按重试策略延迟重投，超过最大次数转入死信
+func (tm *TaskManager) runJob(queue Queue, cfg QueueConfig, job *Job) {
+	handler, ok := tm.registry.Get(job.Type)
+	if !ok {
+		tm.logger.Errorf("未注册的任务类型: %s (ID: %s)", job.Type, job.ID)
+		_ = queue.Nack(context.Background(), job, 0)
+		return
+	}
+
+	record := tm.loadStatus(job.ID, job.Type)
+	if record.State == TaskStateCancelled {
+		tm.logger.Infof("任务已取消: %s (ID: %s)", job.Type, job.ID)
+		tm.ackJob(queue, job)
+		return
+	}
+
+	options := tm.registry.Options(job.Type)
+	policy := defaultRetryPolicy
+	if options.Retry != nil {
+		policy = *options.Retry
+	}
+
+	tm.markRunning(record, job.Attempts)
+	stopHeartbeat := tm.heartbeat(queue, cfg, job)
+	result, err := tm.runOnce(&Task{
+		ID:      job.ID,
+		Name:    job.Type,
+		Timeout: options.Timeout,
+		Function: func(ctx context.Context) error {
+			// 任务可能在其他进程中被取消
+			ctx, cancel := tm.watchCancel(ctx, job.ID, cfg.PollInterval)
+			defer cancel()
+			return handler(ctx, job)
+		},
+	})
+	stopHeartbeat()
+	if err == nil {
+		tm.markFinished(record, TaskStateSucceeded, result, nil)
+		tm.ackJob(queue, job)
+		return
+	}
+	if tm.isCancelled(job.ID) {
+		tm.ackJob(queue, job)
+		return
+	}
+
+	if job.Attempts < policy.Attempts() {
+		delay := policy.Delay(job.Attempts)
+		tm.logger.Warnf("任务将在 %v 后重试: %s (ID: %s), 第 %d/%d 次", delay, job.Type, job.ID, job.Attempts+1, policy.Attempts())
+		tm.markRetrying(record, err)
+		if err := queue.Nack(context.Background(), job, delay); err != nil {
+			tm.logger.Errorf("任务放回队列失败: %s (ID: %s), 错误: %v", job.Type, job.ID, err)
+		}
+		return
+	}
+
+	// 先保存死信再确认，保存失败时任务会在可见性超时后重新投递
+	if !tm.addDeadLetter(newDeadLetter(job.ID, job.Type, job, job.Attempts, err)) {
+		return
+	}
+	tm.markFinished(record, TaskStateFailed, nil, err)
+	tm.ackJob(queue, job)
+}
+
+// heartbeat 任务执行期间定时延长可见性超时，避免执行时间超过 visibility_timeout 的任务被恢复后重复执行，返回的函数停止续期
+func (tm *TaskManager) heartbeat(queue Queue, cfg QueueConfig, job *Job) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(cfg.VisibilityTimeout / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				ok, err := queue.Extend(ctx, job, cfg.VisibilityTimeout)
+				if err != nil {
+					tm.logger.Warnf("任务续期失败: %s (ID: %s), 错误: %v", job.Type, job.ID, err)
+					continue
+				}
+				if !ok {
+					tm.logger.Warnf("任务已超时被恢复，可能被重复执行: %s (ID: %s)", job.Type, job.ID)
+					return
+				}
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// ackJob 确认任务，失败只记录日志
+func (tm *TaskManager) ackJob(queue Queue, job *Job) {
+	if err := queue.Ack(context.Background(), job); err != nil {
+		tm.logger.Errorf("任务确认失败: %s (ID: %s), 错误: %v", job.Type, job.ID, err)
+	}
+}
+
+// addDeadLetter 保存死信，未配置死信存储时只记录日志
+func (tm *TaskManager) addDeadLetter(letter *DeadLetter) bool {
+	store := tm.DeadLetters()
+	if store == nil {
+		tm.logger.Errorf("任务最终失败: %s (ID: %s), 尝试 %d 次, 错误: %s", letter.Name, letter.ID, letter.Attempts, letter.Error)
+		return true
+	}
+	if err := store.Add(context.Background(), letter); err != nil {
+		tm.logger.Errorf("保存死信失败: %s (ID: %s), 错误: %v", letter.Name, letter.ID, err)
+		return false
+	}
+	tm.logger.Errorf("任务已转入死信: %s (ID: %s), 尝试 %d 次, 错误: %s", letter.Name, letter.ID, letter.Attempts, letter.Error)
+	return true
+}
+
+// recoverJobs 恢复可见性超时及延迟到期的任务
+func (tm *TaskManager) recoverJobs(ctx context.Context, queue Queue) {
+	n, err := queue.Recover(ctx)
+	if err != nil {
+		tm.logger.Errorf("恢复超时任务失败: %v", err)
+		return
+	}
+	if n > 0 {
+		tm.logger.Infof("已恢复 %d 个任务", n)
+	}
+}
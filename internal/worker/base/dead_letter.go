@@ -0,0 +1,86 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrDeadLetterNotFound 死信不存在
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+// ErrDeadLetterNotRequeueable 进程内任务无法序列化，不支持重新入队
+var ErrDeadLetterNotRequeueable = errors.New("dead letter is not requeueable")
+
+// DeadLetter 永久失败的任务
+type DeadLetter struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	Job      *Job      `json:"job,omitempty"` // 持久化任务，进程内任务为空
+	Error    string    `json:"error"`
+	Stack    string    `json:"stack,omitempty"`
+	Attempts int       `json:"attempts"`
+	FailedAt time.Time `json:"failed_at"`
+}
+
+// Requeueable 是否可以重新入队
+func (d *DeadLetter) Requeueable() bool {
+	return d.Job != nil
+}
+
+// DeadLetterStore 死信存储
+type DeadLetterStore interface {
+	// Add 保存死信
+	Add(ctx context.Context, letter *DeadLetter) error
+	// Get 获取死信，不存在返回 ErrDeadLetterNotFound
+	Get(ctx context.Context, id string) (*DeadLetter, error)
+	// List 按失败时间倒序分页查询
+	List(ctx context.Context, offset, limit int) ([]*DeadLetter, int, error)
+	// Delete 删除死信
+	Delete(ctx context.Context, id string) error
+	// Purge 清空死信，返回删除的数量
+	Purge(ctx context.Context) (int, error)
+}
+
+// newDeadLetter 根据执行错误生成死信
+func newDeadLetter(id, name string, job *Job, attempts int, err error) *DeadLetter {
+	letter := &DeadLetter{
+		ID:       id,
+		Name:     name,
+		Job:      job,
+		Error:    err.Error(),
+		Attempts: attempts,
+		FailedAt: time.Now(),
+	}
+
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) {
+		letter.Stack = string(panicErr.Stack)
+	}
+	return letter
+}
+
+// RequeueDeadLetter 将死信中的持久化任务重新入队并删除死信
+func RequeueDeadLetter(ctx context.Context, store DeadLetterStore, queue Queue, id string) (*Job, error) {
+	if store == nil || queue == nil {
+		return nil, ErrQueueNotConfigured
+	}
+
+	letter, err := store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !letter.Requeueable() {
+		return nil, ErrDeadLetterNotRequeueable
+	}
+
+	job := letter.Job
+	job.Attempts = 0
+	if err := queue.Enqueue(ctx, job); err != nil {
+		return nil, err
+	}
+	if err := store.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
@@ -0,0 +1,80 @@
+package base
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// MemoryDeadLetterStore 进程内死信存储
+type MemoryDeadLetterStore struct {
+	mu      sync.RWMutex
+	letters map[string]*DeadLetter
+}
+
+// NewMemoryDeadLetterStore 实例化MemoryDeadLetterStore
+func NewMemoryDeadLetterStore() *MemoryDeadLetterStore {
+	return &MemoryDeadLetterStore{
+		letters: make(map[string]*DeadLetter),
+	}
+}
+
+// Add 保存死信
+func (s *MemoryDeadLetterStore) Add(_ context.Context, letter *DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.letters[letter.ID] = letter
+	return nil
+}
+
+// Get 获取死信
+func (s *MemoryDeadLetterStore) Get(_ context.Context, id string) (*DeadLetter, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	letter, ok := s.letters[id]
+	if !ok {
+		return nil, ErrDeadLetterNotFound
+	}
+	return letter, nil
+}
+
+// List 按失败时间倒序分页查询
+func (s *MemoryDeadLetterStore) List(_ context.Context, offset, limit int) ([]*DeadLetter, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	letters := make([]*DeadLetter, 0, len(s.letters))
+	for _, letter := range s.letters {
+		letters = append(letters, letter)
+	}
+	sort.Slice(letters, func(i, j int) bool {
+		return letters[i].FailedAt.After(letters[j].FailedAt)
+	})
+
+	total := len(letters)
+	if offset >= total {
+		return []*DeadLetter{}, total, nil
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	return letters[offset:end], total, nil
+}
+
+// Delete 删除死信
+func (s *MemoryDeadLetterStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.letters, id)
+	return nil
+}
+
+// Purge 清空死信
+func (s *MemoryDeadLetterStore) Purge(_ context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count := len(s.letters)
+	s.letters = make(map[string]*DeadLetter)
+	return count, nil
+}
@@ -0,0 +1,119 @@
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// RedisDeadLetterStore 基于 Redis 的死信存储
+type RedisDeadLetterStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisDeadLetterStore 实例化RedisDeadLetterStore
+func NewRedisDeadLetterStore(client redis.UniversalClient, prefix string) *RedisDeadLetterStore {
+	return &RedisDeadLetterStore{
+		client: client,
+		prefix: "{" + prefix + "}",
+	}
+}
+
+func (s *RedisDeadLetterStore) lettersKey() string {
+	return s.prefix + ":dead"
+}
+
+func (s *RedisDeadLetterStore) indexKey() string {
+	return s.prefix + ":dead:index"
+}
+
+// Add 保存死信
+func (s *RedisDeadLetterStore) Add(ctx context.Context, letter *DeadLetter) error {
+	data, err := json.Marshal(letter)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, s.lettersKey(), letter.ID, data)
+		pipe.ZAdd(ctx, s.indexKey(), &redis.Z{Score: float64(letter.FailedAt.UnixMilli()), Member: letter.ID})
+		return nil
+	})
+	return err
+}
+
+// Get 获取死信
+func (s *RedisDeadLetterStore) Get(ctx context.Context, id string) (*DeadLetter, error) {
+	data, err := s.client.HGet(ctx, s.lettersKey(), id).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrDeadLetterNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	letter := &DeadLetter{}
+	if err := json.Unmarshal(data, letter); err != nil {
+		return nil, err
+	}
+	return letter, nil
+}
+
+// List 按失败时间倒序分页查询
+func (s *RedisDeadLetterStore) List(ctx context.Context, offset, limit int) ([]*DeadLetter, int, error) {
+	total, err := s.client.ZCard(ctx, s.indexKey()).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids, err := s.client.ZRevRange(ctx, s.indexKey(), int64(offset), int64(offset+limit-1)).Result()
+	if err != nil || len(ids) == 0 {
+		return []*DeadLetter{}, int(total), err
+	}
+
+	values, err := s.client.HMGet(ctx, s.lettersKey(), ids...).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	letters := make([]*DeadLetter, 0, len(values))
+	for _, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+		letter := &DeadLetter{}
+		if err := json.Unmarshal([]byte(data), letter); err != nil {
+			return nil, 0, err
+		}
+		letters = append(letters, letter)
+	}
+	return letters, int(total), nil
+}
+
+// Delete 删除死信
+func (s *RedisDeadLetterStore) Delete(ctx context.Context, id string) error {
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HDel(ctx, s.lettersKey(), id)
+		pipe.ZRem(ctx, s.indexKey(), id)
+		return nil
+	})
+	return err
+}
+
+// Purge 清空死信
+func (s *RedisDeadLetterStore) Purge(ctx context.Context) (int, error) {
+	var count *redis.IntCmd
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		count = pipe.ZCard(ctx, s.indexKey())
+		pipe.Del(ctx, s.lettersKey(), s.indexKey())
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(count.Val()), nil
+}
@@ -0,0 +1,168 @@
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// handlePollInterval 持久化任务句柄等待结果时的轮询间隔
+const handlePollInterval = 500 * time.Millisecond
+
+// TaskHandle 任务句柄，用于查询状态、等待结果及取消任务
+type TaskHandle struct {
+	id    string
+	store StatusStore
+	done  <-chan struct{} // 进程内任务执行结束后关闭，持久化任务为 nil
+}
+
+// ID 任务ID
+func (h *TaskHandle) ID() string {
+	return h.id
+}
+
+// Status 查询任务状态
+func (h *TaskHandle) Status(ctx context.Context) (*TaskRecord, error) {
+	return h.store.Get(ctx, h.id)
+}
+
+// Wait 等待任务结束并返回最终状态
+func (h *TaskHandle) Wait(ctx context.Context) (*TaskRecord, error) {
+	if h.done != nil {
+		select {
+		case <-h.done:
+			return h.Status(ctx)
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	ticker := time.NewTicker(handlePollInterval)
+	defer ticker.Stop()
+	for {
+		record, err := h.Status(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if record.State.Finished() {
+			return record, nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Cancel 取消任务
+func (h *TaskHandle) Cancel(ctx context.Context) error {
+	_, err := CancelTask(ctx, h.store, h.id)
+	return err
+}
+
+// SetStatus 设置任务状态存储，未设置时使用 InitFromViper 初始化的默认存储
+func (tm *TaskManager) SetStatus(store StatusStore) {
+	tm.status = store
+}
+
+// Status 当前使用的任务状态存储
+func (tm *TaskManager) Status() StatusStore {
+	if tm.status != nil {
+		return tm.status
+	}
+	return defaultStatus
+}
+
+// Handle 根据任务ID获取任务句柄
+func (tm *TaskManager) Handle(id string) *TaskHandle {
+	return &TaskHandle{id: id, store: tm.Status()}
+}
+
+// EnqueueAsync 提交持久化任务并返回任务句柄
+func (tm *TaskManager) EnqueueAsync(ctx context.Context, jobType string, payload any) (*TaskHandle, error) {
+	job, err := tm.Enqueue(ctx, jobType, payload)
+	if err != nil {
+		return nil, err
+	}
+	return tm.Handle(job.ID), nil
+}
+
+// loadStatus 读取任务状态，不存在时创建新的记录
+func (tm *TaskManager) loadStatus(id, name string) *TaskRecord {
+	record, err := tm.Status().Get(context.Background(), id)
+	if err == nil {
+		return record
+	}
+	if !errors.Is(err, ErrTaskNotFound) {
+		tm.logger.Errorf("读取任务状态失败: %s (ID: %s), 错误: %v", name, id, err)
+	}
+	return &TaskRecord{
+		ID:        id,
+		Name:      name,
+		State:     TaskStateQueued,
+		CreatedAt: time.Now(),
+	}
+}
+
+// saveStatus 保存任务状态，失败只记录日志
+func (tm *TaskManager) saveStatus(record *TaskRecord) {
+	if err := tm.Status().Save(context.Background(), record); err != nil {
+		tm.logger.Errorf("保存任务状态失败: %s (ID: %s), 错误: %v", record.Name, record.ID, err)
+	}
+}
+
+// isCancelled 任务是否已被取消
+func (tm *TaskManager) isCancelled(id string) bool {
+	record, err := tm.Status().Get(context.Background(), id)
+	return err == nil && record.State == TaskStateCancelled
+}
+
+func (tm *TaskManager) markRunning(record *TaskRecord, attempts int) {
+	now := time.Now()
+	record.State = TaskStateRunning
+	record.Attempts = attempts
+	record.StartedAt = &now
+	tm.saveStatus(record)
+}
+
+func (tm *TaskManager) markRetrying(record *TaskRecord, err error) {
+	record.State = TaskStateQueued
+	record.Error = err.Error()
+	tm.saveStatus(record)
+}
+
+func (tm *TaskManager) markFinished(record *TaskRecord, state TaskState, result json.RawMessage, err error) {
+	now := time.Now()
+	record.State = state
+	record.Result = result
+	record.Error = ""
+	if err != nil {
+		record.Error = err.Error()
+	}
+	record.FinishedAt = &now
+	tm.saveStatus(record)
+}
+
+// watchCancel 轮询任务状态，任务在其他进程被取消时取消 ctx
+func (tm *TaskManager) watchCancel(ctx context.Context, id string, interval time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if tm.isCancelled(id) {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+	return ctx, cancel
+}
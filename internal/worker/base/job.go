@@ -0,0 +1,115 @@
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go-agent/gopkg/utils"
+)
+
+// Job 可序列化的持久化任务，通过任务类型找到对应的处理器执行
+type Job struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+	Attempts  int             `json:"attempts"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// NewJob 创建持久化任务，payload 会被编码为 JSON
+func NewJob(jobType string, payload any) (*Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshal job payload: %w", err)
+	}
+
+	return &Job{
+		ID:        utils.GenUUIDWithoutUnderline(),
+		Type:      jobType,
+		Payload:   data,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// Bind 将任务载荷解码到 v
+func (j *Job) Bind(v any) error {
+	return json.Unmarshal(j.Payload, v)
+}
+
+// JobHandler 持久化任务处理函数
+type JobHandler func(ctx context.Context, job *Job) error
+
+// registeredHandler 已注册的处理器及其选项
+type registeredHandler struct {
+	handler JobHandler
+	options HandlerOptions
+}
+
+// HandlerRegistry 任务类型与处理器的注册表
+type HandlerRegistry struct {
+	mu       sync.RWMutex
+	handlers map[string]registeredHandler
+}
+
+// NewHandlerRegistry 实例化HandlerRegistry
+func NewHandlerRegistry() *HandlerRegistry {
+	return &HandlerRegistry{
+		handlers: make(map[string]registeredHandler),
+	}
+}
+
+// Add 注册任务处理器，同一类型重复注册会覆盖
+func (r *HandlerRegistry) Add(jobType string, handler JobHandler, opts ...HandlerOption) {
+	var options HandlerOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[jobType] = registeredHandler{handler: handler, options: options}
+}
+
+// Get 获取任务处理器
+func (r *HandlerRegistry) Get(jobType string) (JobHandler, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	h, ok := r.handlers[jobType]
+	return h.handler, ok
+}
+
+// Options 获取任务处理器选项
+func (r *HandlerRegistry) Options(jobType string) HandlerOptions {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.handlers[jobType].options
+}
+
+// Types 已注册的任务类型
+func (r *HandlerRegistry) Types() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	types := make([]string, 0, len(r.handlers))
+	for jobType := range r.handlers {
+		types = append(types, jobType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// registry 默认注册表
+var registry = NewHandlerRegistry()
+
+// RegisterHandler 注册任务处理器到默认注册表
+func RegisterHandler(jobType string, handler JobHandler, opts ...HandlerOption) {
+	registry.Add(jobType, handler, opts...)
+}
+
+// Registry 获取默认注册表
+func Registry() *HandlerRegistry {
+	return registry
+}
@@ -0,0 +1,85 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrManagerClosed 任务管理器已关闭或正在关闭，不再接收新任务
+var ErrManagerClosed = errors.New("task manager is shutdown")
+
+// defaultDrainTimeout 未配置 worker.shutdown_timeout 时关闭任务管理器的最长等待时间
+const defaultDrainTimeout = 30 * time.Second
+
+// ShutdownReport 任务管理器关闭结果
+type ShutdownReport struct {
+	Drained     bool          // 截止时间前全部任务执行完毕
+	Abandoned   []string      // 尚未开始执行即被丢弃的任务ID
+	Interrupted []string      // 截止时仍在执行、被取消的任务ID
+	Lost        []string      // 提交到任务管理器失败而未执行的任务ID
+	Duration    time.Duration // 关闭耗时
+}
+
+// SetDrainTimeout 设置 Shutdown 等待任务执行完毕的最长时间
+func (tm *TaskManager) SetDrainTimeout(timeout time.Duration) {
+	tm.drainTimeout = timeout
+}
+
+// StopAndWait 停止接收新任务，等待等待中及执行中的任务完成，直到 ctx 结束
+//
+// ctx 结束时尚未开始的任务被丢弃（持久化任务放回队列），执行中的任务被取消，
+// 随后等待线程池中的任务全部返回。重复调用返回第一次关闭的结果。
+func (tm *TaskManager) StopAndWait(ctx context.Context) *ShutdownReport {
+	tm.stopOnce.Do(func() {
+		tm.report = tm.stop(ctx)
+	})
+	return tm.report
+}
+
+func (tm *TaskManager) stop(ctx context.Context) *ShutdownReport {
+	startTime := time.Now()
+	tm.closing.Store(true)
+	tm.logger.Info("正在关闭任务管理器，等待任务执行完毕...")
+
+	report := &ShutdownReport{Drained: true}
+	ticker := time.NewTicker(dispatchInterval)
+	defer ticker.Stop()
+wait:
+	for !tm.sched.empty() {
+		select {
+		case <-ctx.Done():
+			report.Drained = false
+			break wait
+		case <-ticker.C:
+		}
+	}
+
+	if !report.Drained {
+		for _, task := range tm.sched.drain() {
+			report.Abandoned = append(report.Abandoned, task.id)
+			if task.abandon != nil {
+				task.abandon()
+			}
+		}
+		report.Interrupted = tm.sched.activeIDs()
+	}
+
+	// 取消执行中任务的 ctx 并等待其返回
+	tm.cancel()
+	tm.pool.StopAndWait()
+
+	report.Duration = time.Since(startTime)
+	if report.Drained {
+		tm.logger.Infof("任务管理器已关闭, 耗时: %v", report.Duration)
+	} else {
+		tm.logger.Warnf("任务管理器已关闭, 耗时: %v, 丢弃任务: %v, 中断任务: %v", report.Duration, report.Abandoned, report.Interrupted)
+	}
+	return report
+}
+
+// GracefulStart 实现 graceful.Graceful，ctx 取消时关闭任务管理器
+func (tm *TaskManager) GracefulStart(ctx context.Context) {
+	<-ctx.Done()
+	tm.Shutdown()
+}
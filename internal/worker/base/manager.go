@@ -1,10 +1,12 @@
 package base
 
 import (
-	"go-agent/gopkg/log"
 	"context"
-	"fmt"
+	"encoding/json"
+	"go-agent/gopkg/log"
+	"runtime/debug"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/alitto/pond/v2"
@@ -19,16 +21,32 @@ type Task struct {
 	ID       string
 	Name     string
 	Function TaskFunc
+	Timeout  time.Duration // 单次执行超时，0 表示不限制
+	Retry    RetryPolicy   // 重试策略，默认只执行一次
+	Type     string        // 任务类型，用于匹配 worker.types 中的并发及速率限制
+	Priority Priority      // 优先级，未指定时使用任务类型配置
+	Owner    string        // 提交任务的用户ID，只有该用户及拥有 task:admin 权限的用户可查看、取消
 }
 
 // TaskManager 任务管理器
 type TaskManager struct {
-	pool       pond.Pool
-	ctx        context.Context
-	cancel     context.CancelFunc
-	logger     *zap.SugaredLogger
-	taskStats  map[string]*TaskStats
-	statsMutex sync.RWMutex
+	pool        pond.Pool
+	sched       *scheduler
+	ctx         context.Context
+	cancel      context.CancelFunc
+	logger      *zap.SugaredLogger
+	taskStats   map[string]*TaskStats
+	statsMutex  sync.RWMutex
+	queue       Queue
+	queueCfg    QueueConfig
+	deadLetters DeadLetterStore
+	status      StatusStore
+	registry    *HandlerRegistry
+
+	closing      atomic.Bool
+	stopOnce     sync.Once
+	report       *ShutdownReport
+	drainTimeout time.Duration
 }
 
 // TaskStats 任务统计信息
@@ -39,61 +57,176 @@ type TaskStats struct {
 	LastExecution   time.Time
 }
 
-// NewTaskManager 创建新的任务管理器
+// NewTaskManager 创建新的任务管理器，maxWorkers 为最大并发数，queueSize 为最多等待调度的任务数
 func NewTaskManager(maxWorkers int, queueSize int) *TaskManager {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	manager := &TaskManager{
-		pool:      pond.NewPool(maxWorkers, pond.WithQueueSize(queueSize)),
+		pool:      pond.NewPool(maxWorkers),
+		sched:     newScheduler(maxWorkers, queueSize),
 		ctx:       ctx,
 		cancel:    cancel,
 		logger:    log.Sugar(),
 		taskStats: make(map[string]*TaskStats),
+		registry:  registry,
+
+		drainTimeout: defaultDrainTimeout,
 	}
 
+	// 调度协程按优先级及类型限制将任务派发到线程池
+	go manager.sched.dispatch(ctx, manager.dispatch)
+
 	return manager
 }
 
+// SetTypeConfig 设置任务类型的优先级、并发及速率限制
+func (tm *TaskManager) SetTypeConfig(taskType string, cfg TypeConfig) error {
+	return tm.sched.setLimit(taskType, cfg)
+}
+
+// dispatch 将调度出的任务提交到线程池
+func (tm *TaskManager) dispatch(task *scheduledTask) {
+	if err := tm.pool.Go(func() {
+		defer tm.sched.done(task)
+		task.run()
+	}); err != nil {
+		tm.sched.done(task)
+		tm.logger.Errorf("提交任务到线程池失败: %v", err)
+	}
+}
+
 // Submit 提交任务到线程池
 func (tm *TaskManager) Submit(task *Task) error {
-	select {
-	case <-tm.ctx.Done():
-		return fmt.Errorf("task manager is shutdown")
-	default:
-		return tm.pool.Go(tm.wrapTask(task))
+	_, err := tm.SubmitAsync(task)
+	return err
+}
+
+// SubmitAsync 异步提交任务到线程池，返回可查询状态、等待结果及取消的任务句柄
+func (tm *TaskManager) SubmitAsync(task *Task) (*TaskHandle, error) {
+	if tm.IsClosed() {
+		return nil, ErrManagerClosed
 	}
+
+	tm.saveStatus(&TaskRecord{
+		ID:        task.ID,
+		Name:      task.Name,
+		Owner:     task.Owner,
+		State:     TaskStateQueued,
+		CreatedAt: time.Now(),
+	})
+
+	done := make(chan struct{})
+	if err := tm.sched.push(tm.scheduled(task, 1, nil, done), task.Priority); err != nil {
+		record := tm.loadStatus(task.ID, task.Name)
+		tm.markFinished(record, TaskStateFailed, nil, err)
+		return nil, err
+	}
+
+	return &TaskHandle{id: task.ID, store: tm.Status(), done: done}, nil
 }
 
-// SubmitAsync 异步提交任务到线程池
-func (tm *TaskManager) SubmitAsync(task *Task) (pond.Task, error) {
-	select {
-	case <-tm.ctx.Done():
-		return nil, fmt.Errorf("task manager is shutdown")
-	default:
-		return tm.pool.Submit(tm.wrapTask(task)), nil
+// scheduled 构造任务第 attempt 次执行的调度项，lastErr 为上一次执行的错误
+//
+// 执行失败需要重试时按退避时间重新加入调度，等待期间不占用 worker。
+func (tm *TaskManager) scheduled(task *Task, attempt int, lastErr error, done chan struct{}) *scheduledTask {
+	return &scheduledTask{
+		id:       task.ID,
+		taskType: task.Type,
+		run: func() {
+			retry, err := tm.execute(task, attempt)
+			if !retry {
+				close(done)
+				return
+			}
+			tm.sched.pushAfter(tm.scheduled(task, attempt+1, err, done), task.Priority, task.Retry.Delay(attempt))
+		},
+		abandon: func() {
+			defer close(done)
+			record := tm.loadStatus(task.ID, task.Name)
+			if lastErr == nil {
+				tm.markFinished(record, TaskStateCancelled, nil, ErrManagerClosed)
+				return
+			}
+			// 关闭时仍在等待重试的任务记录到死信
+			tm.addDeadLetter(newDeadLetter(task.ID, task.Name, nil, attempt-1, lastErr))
+			tm.markFinished(record, TaskStateFailed, nil, lastErr)
+		},
 	}
 }
 
-// wrapTask 包装任务函数以添加统计和错误处理
-func (tm *TaskManager) wrapTask(task *Task) func() {
-	return func() {
-		startTime := time.Now()
-		tm.logger.Infof("开始执行任务: %s (ID: %s)", task.Name, task.ID)
+// execute 执行任务的第 attempt 次尝试，失败且未超过重试次数时返回 retry，最终失败时记录到死信
+func (tm *TaskManager) execute(task *Task, attempt int) (retry bool, err error) {
+	record := tm.loadStatus(task.ID, task.Name)
+	if record.State == TaskStateCancelled {
+		tm.logger.Infof("任务已取消: %s (ID: %s)", task.Name, task.ID)
+		return false, context.Canceled
+	}
+
+	tm.markRunning(record, attempt)
+	result, err := tm.runOnce(task)
+	if err == nil {
+		tm.markFinished(record, TaskStateSucceeded, result, nil)
+		return false, nil
+	}
+	if tm.isCancelled(task.ID) {
+		return false, err
+	}
+	attempts := task.Retry.Attempts()
+	if attempt >= attempts || tm.IsClosed() {
+		tm.addDeadLetter(newDeadLetter(task.ID, task.Name, nil, attempt, err))
+		tm.markFinished(record, TaskStateFailed, nil, err)
+		return false, err
+	}
 
-		// 执行任务
-		err := task.Function(tm.ctx)
+	tm.logger.Warnf("任务将在 %v 后重试: %s (ID: %s), 第 %d/%d 次", task.Retry.Delay(attempt), task.Name, task.ID, attempt+1, attempts)
+	tm.markRetrying(record, err)
+	return true, err
+}
 
-		// 更新统计信息
-		tm.updateStats(task.ID, err == nil)
+// runOnce 执行一次任务并记录统计信息，panic 会被转换为 PanicError
+func (tm *TaskManager) runOnce(task *Task) (result json.RawMessage, err error) {
+	startTime := time.Now()
+	tm.logger.Infof("开始执行任务: %s (ID: %s)", task.Name, task.ID)
 
-		// 记录执行结果
-		duration := time.Since(startTime)
-		if err != nil {
-			tm.logger.Errorf("任务执行失败: %s (ID: %s), 耗时: %v, 错误: %v", task.Name, task.ID, duration, err)
-		} else {
-			tm.logger.Infof("任务执行成功: %s (ID: %s), 耗时: %v", task.Name, task.ID, duration)
-		}
+	ctx, cancel := context.WithCancel(tm.ctx)
+	defer cancel()
+	if task.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, task.Timeout)
+		defer cancel()
 	}
+
+	// 登记取消函数并注入结果容器
+	running.Store(task.ID, cancel)
+	defer running.Delete(task.ID)
+	holder := &resultHolder{}
+	ctx = context.WithValue(ctx, resultKey{}, holder)
+
+	// 执行任务
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				stack := debug.Stack()
+				tm.logger.Errorf("任务执行panic: %s (ID: %s), %v\n%s", task.Name, task.ID, r, stack)
+				err = &PanicError{Value: r, Stack: stack}
+			}
+		}()
+		err = task.Function(ctx)
+	}()
+
+	// 更新统计信息
+	tm.updateStats(task.ID, err == nil)
+
+	// 记录执行结果
+	duration := time.Since(startTime)
+	if err != nil {
+		tm.logger.Errorf("任务执行失败: %s (ID: %s), 耗时: %v, 错误: %v", task.Name, task.ID, duration, err)
+	} else {
+		tm.logger.Infof("任务执行成功: %s (ID: %s), 耗时: %v", task.Name, task.ID, duration)
+	}
+
+	holder.mu.Lock()
+	defer holder.mu.Unlock()
+	return holder.value, err
 }
 
 // updateStats 更新任务统计信息
@@ -153,20 +286,14 @@ func (tm *TaskManager) GetAllStats() map[string]*TaskStats {
 	return result
 }
 
-// Shutdown 关闭任务管理器
+// Shutdown 关闭任务管理器，最多等待 drainTimeout 让已提交的任务执行完毕
 func (tm *TaskManager) Shutdown() {
-	tm.logger.Info("正在关闭任务管理器...")
-	tm.cancel()
-	// 注意：pond/v2 不需要显式停止池
-	tm.logger.Info("任务管理器已关闭")
+	ctx, cancel := context.WithTimeout(context.Background(), tm.drainTimeout)
+	defer cancel()
+	tm.StopAndWait(ctx)
 }
 
-// IsClosed 检查任务管理器是否已关闭
+// IsClosed 检查任务管理器是否已关闭或正在关闭
 func (tm *TaskManager) IsClosed() bool {
-	select {
-	case <-tm.ctx.Done():
-		return true
-	default:
-		return false
-	}
+	return tm.closing.Load()
 }
@@ -0,0 +1,197 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, Backoff: time.Second, MaxBackoff: 5 * time.Second}
+	assert.Equal(t, time.Second, policy.Delay(1))
+	assert.Equal(t, 2*time.Second, policy.Delay(2))
+	assert.Equal(t, 4*time.Second, policy.Delay(3))
+	assert.Equal(t, 5*time.Second, policy.Delay(4))
+	assert.Equal(t, 1, RetryPolicy{}.Attempts())
+}
+
+func TestTaskManagerRetryAndDeadLetter(t *testing.T) {
+	tm := NewTaskManager(1, 1)
+	defer tm.Shutdown()
+	tm.SetStatus(NewMemoryStatusStore(time.Minute))
+	store := NewMemoryDeadLetterStore()
+	tm.SetDeadLetters(store)
+
+	calls := 0
+	handle, err := tm.SubmitAsync(&Task{
+		ID:    "panic-task",
+		Name:  "panic",
+		Retry: RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond},
+		Function: func(ctx context.Context) error {
+			calls++
+			panic("boom")
+		},
+	})
+	assert.NoError(t, err)
+
+	record, err := handle.Wait(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, TaskStateFailed, record.State)
+	assert.Equal(t, 2, record.Attempts)
+	assert.Equal(t, 2, calls)
+
+	letter, err := store.Get(context.Background(), "panic-task")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, letter.Attempts)
+	assert.NotEmpty(t, letter.Stack)
+	assert.False(t, letter.Requeueable())
+	assert.Contains(t, letter.Error, "boom")
+}
+
+func TestTaskManagerRetryBackoff(t *testing.T) {
+	tm := NewTaskManager(1, 10)
+	tm.SetStatus(NewMemoryStatusStore(time.Minute))
+	store := NewMemoryDeadLetterStore()
+	tm.SetDeadLetters(store)
+	ctx := context.Background()
+
+	failing, err := tm.SubmitAsync(&Task{
+		ID:       "failing-task",
+		Name:     "failing",
+		Retry:    RetryPolicy{MaxAttempts: 3, Backoff: time.Hour},
+		Function: func(ctx context.Context) error { return errors.New("down") },
+	})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		record, err := failing.Status(ctx)
+		return err == nil && record.State == TaskStateQueued && record.Error == "down"
+	}, time.Second, 5*time.Millisecond)
+
+	// 退避等待期间不占用 worker，后续任务可以立即执行
+	next, err := tm.SubmitAsync(&Task{
+		ID:       "next-task",
+		Name:     "next",
+		Function: func(ctx context.Context) error { return nil },
+	})
+	assert.NoError(t, err)
+	waitCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	record, err := next.Wait(waitCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, TaskStateSucceeded, record.State)
+
+	// 关闭时仍在退避的任务被丢弃并记录到死信
+	stopCtx, stop := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer stop()
+	report := tm.StopAndWait(stopCtx)
+	assert.False(t, report.Drained)
+	assert.Equal(t, []string{"failing-task"}, report.Abandoned)
+
+	record, err = failing.Wait(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, TaskStateFailed, record.State)
+	letter, err := store.Get(ctx, "failing-task")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, letter.Attempts)
+}
+
+func TestTaskManagerTimeout(t *testing.T) {
+	tm := NewTaskManager(1, 1)
+	defer tm.Shutdown()
+
+	_, err := tm.execute(&Task{
+		ID:      "slow-task",
+		Name:    "slow",
+		Timeout: 10 * time.Millisecond,
+		Function: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}, 1)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestTaskHandle(t *testing.T) {
+	tm := NewTaskManager(2, 10)
+	defer tm.Shutdown()
+	tm.SetStatus(NewMemoryStatusStore(time.Minute))
+	ctx := context.Background()
+
+	handle, err := tm.SubmitAsync(&Task{
+		ID:   "result-task",
+		Name: "result",
+		Function: func(ctx context.Context) error {
+			return SetResult(ctx, map[string]int{"count": 3})
+		},
+	})
+	assert.NoError(t, err)
+
+	record, err := handle.Wait(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, TaskStateSucceeded, record.State)
+	assert.JSONEq(t, `{"count":3}`, string(record.Result))
+
+	started := make(chan struct{})
+	handle, err = tm.SubmitAsync(&Task{
+		ID:   "cancel-task",
+		Name: "cancel",
+		Function: func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+	assert.NoError(t, err)
+
+	<-started
+	assert.NoError(t, handle.Cancel(ctx))
+	record, err = handle.Wait(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, TaskStateCancelled, record.State)
+	assert.ErrorIs(t, handle.Cancel(ctx), ErrTaskFinished)
+}
+
+func TestTaskManagerStopAndWait(t *testing.T) {
+	tm := NewTaskManager(1, 10)
+	tm.SetStatus(NewMemoryStatusStore(time.Minute))
+
+	started := make(chan struct{})
+	blocking, err := tm.SubmitAsync(&Task{
+		ID:   "blocking-task",
+		Name: "blocking",
+		Function: func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+	assert.NoError(t, err)
+	waiting, err := tm.SubmitAsync(&Task{
+		ID:       "waiting-task",
+		Name:     "waiting",
+		Function: func(ctx context.Context) error { return nil },
+	})
+	assert.NoError(t, err)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	report := tm.StopAndWait(ctx)
+	assert.False(t, report.Drained)
+	assert.Equal(t, []string{"waiting-task"}, report.Abandoned)
+	assert.Equal(t, []string{"blocking-task"}, report.Interrupted)
+	assert.Same(t, report, tm.StopAndWait(context.Background()))
+
+	record, err := waiting.Wait(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, TaskStateCancelled, record.State)
+	record, err = blocking.Wait(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, TaskStateFailed, record.State)
+
+	_, err = tm.SubmitAsync(&Task{ID: "late-task", Function: func(ctx context.Context) error { return nil }})
+	assert.ErrorIs(t, err, ErrManagerClosed)
+}
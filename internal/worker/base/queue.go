@@ -0,0 +1,168 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	rxRedis "go-agent/gopkg/cache/redis"
+
+	"github.com/spf13/viper"
+)
+
+// ErrQueueNotConfigured 未配置持久化队列
+var ErrQueueNotConfigured = errors.New("task queue not configured")
+
+// Queue 持久化任务队列，至少投递一次
+//
+// Dequeue 取出的任务在 visibility 时间内对其他消费者不可见，执行期间通过 Extend 续期，
+// 超时未 Ack 的任务会被 Recover 重新放回队列。
+type Queue interface {
+	// Enqueue 入队
+	Enqueue(ctx context.Context, job *Job) error
+	// Dequeue 按 types 顺序取出一个任务，没有任务时返回 nil, nil
+	Dequeue(ctx context.Context, types []string, visibility time.Duration) (*Job, error)
+	// Ack 确认任务已完成并删除
+	Ack(ctx context.Context, job *Job) error
+	// Nack 任务执行失败，delay 后重新投递，delay 为 0 时立即放回队列
+	Nack(ctx context.Context, job *Job, delay time.Duration) error
+	// Extend 将仍在执行中的任务的可见性超时延长到 visibility 之后，任务已被恢复或确认时返回 false
+	Extend(ctx context.Context, job *Job, visibility time.Duration) (bool, error)
+	// Recover 将可见性超时及延迟到期的任务放回队列，返回恢复的数量
+	Recover(ctx context.Context) (int, error)
+}
+
+// QueueConfig 持久化队列配置
+type QueueConfig struct {
+	Backend           string        `json:"backend" mapstructure:"backend"`                       // memory, redis
+	Client            string        `json:"client" mapstructure:"client"`                         // redis 客户端名称
+	Prefix            string        `json:"prefix" mapstructure:"prefix"`                         // redis 键前缀
+	VisibilityTimeout time.Duration `json:"visibility_timeout" mapstructure:"visibility_timeout"` // 可见性超时
+	PollInterval      time.Duration `json:"poll_interval" mapstructure:"poll_interval"`           // 空队列轮询间隔
+	RecoverInterval   time.Duration `json:"recover_interval" mapstructure:"recover_interval"`     // 超时及延迟任务的恢复间隔
+	StatusTTL         time.Duration `json:"status_ttl" mapstructure:"status_ttl"`                 // 任务状态保留时间
+}
+
+const (
+	defaultQueuePrefix       = "go-agent:task"
+	defaultVisibilityTimeout = 5 * time.Minute
+	defaultPollInterval      = time.Second
+	defaultRecoverInterval   = 5 * time.Second
+	defaultStatusTTL         = 24 * time.Hour
+)
+
+// defaultRetryPolicy 持久化任务未配置重试策略时使用
+var defaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	Backoff:     time.Second,
+	MaxBackoff:  time.Minute,
+}
+
+func (c *QueueConfig) format() {
+	if c.Prefix == "" {
+		c.Prefix = defaultQueuePrefix
+	}
+	if c.VisibilityTimeout <= 0 {
+		c.VisibilityTimeout = defaultVisibilityTimeout
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = defaultPollInterval
+	}
+	if c.RecoverInterval <= 0 {
+		c.RecoverInterval = defaultRecoverInterval
+	}
+	if c.StatusTTL <= 0 {
+		c.StatusTTL = defaultStatusTTL
+	}
+}
+
+// QueueConfigFromViper 读取 worker.queue 配置
+func QueueConfigFromViper() (QueueConfig, error) {
+	var cfg QueueConfig
+	if err := viper.UnmarshalKey("worker.queue", &cfg); err != nil {
+		return cfg, err
+	}
+	cfg.format()
+	return cfg, nil
+}
+
+// Backend 持久化队列及其配套存储
+type Backend struct {
+	Queue       Queue
+	DeadLetters DeadLetterStore
+	Status      StatusStore
+}
+
+// NewBackend 根据配置创建持久化队列、死信存储及任务状态存储
+func NewBackend(cfg QueueConfig) (*Backend, error) {
+	cfg.format()
+	switch cfg.Backend {
+	case "redis":
+		client, err := rxRedis.ClientAndErr(cfg.Client)
+		if err != nil {
+			return nil, err
+		}
+		return &Backend{
+			Queue:       NewRedisQueue(client, cfg.Prefix),
+			DeadLetters: NewRedisDeadLetterStore(client, cfg.Prefix),
+			Status:      NewRedisStatusStore(client, cfg.Prefix, cfg.StatusTTL),
+		}, nil
+	case "", "memory":
+		return &Backend{
+			Queue:       NewMemoryQueue(),
+			DeadLetters: NewMemoryDeadLetterStore(),
+			Status:      NewMemoryStatusStore(cfg.StatusTTL),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown task queue backend: %s", cfg.Backend)
+	}
+}
+
+var (
+	defaultQueue       Queue
+	defaultDeadLetters DeadLetterStore
+	defaultQueueCfg    QueueConfig
+	defaultStatus      StatusStore = NewMemoryStatusStore(defaultStatusTTL)
+)
+
+// InitFromViper 根据 worker 配置初始化默认的持久化队列、死信存储、任务状态存储及重试策略
+//
+// 未单独设置的 TaskManager 使用这里的默认值。
+func InitFromViper() error {
+	cfg, err := QueueConfigFromViper()
+	if err != nil {
+		return err
+	}
+	backend, err := NewBackend(cfg)
+	if err != nil {
+		return err
+	}
+
+	if viper.IsSet("worker.retry") {
+		var policy RetryPolicy
+		if err := viper.UnmarshalKey("worker.retry", &policy); err != nil {
+			return err
+		}
+		defaultRetryPolicy = policy
+	}
+
+	defaultQueue, defaultDeadLetters, defaultStatus = backend.Queue, backend.DeadLetters, backend.Status
+	defaultQueueCfg = cfg
+	return nil
+}
+
+// DefaultQueue 默认持久化队列，未初始化时为 nil
+func DefaultQueue() Queue {
+	return defaultQueue
+}
+
+// DefaultDeadLetters 默认死信存储，未初始化时为 nil
+func DefaultDeadLetters() DeadLetterStore {
+	return defaultDeadLetters
+}
+
+// DefaultStatus 默认任务状态存储，未初始化时为进程内存储
+func DefaultStatus() StatusStore {
+	return defaultStatus
+}
@@ -0,0 +1,114 @@
+package base
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryQueue 进程内队列，语义与 RedisQueue 一致，用于本地开发与测试
+type MemoryQueue struct {
+	mu       sync.Mutex
+	pending  map[string][]string
+	jobs     map[string]Job
+	inflight map[string]time.Time
+	delayed  map[string]time.Time
+}
+
+// NewMemoryQueue 实例化MemoryQueue
+func NewMemoryQueue() *MemoryQueue {
+	return &MemoryQueue{
+		pending:  make(map[string][]string),
+		jobs:     make(map[string]Job),
+		inflight: make(map[string]time.Time),
+		delayed:  make(map[string]time.Time),
+	}
+}
+
+// Enqueue 入队
+func (q *MemoryQueue) Enqueue(_ context.Context, job *Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.jobs[job.ID] = *job
+	q.pending[job.Type] = append(q.pending[job.Type], job.ID)
+	return nil
+}
+
+// Dequeue 按 types 顺序取出一个任务
+func (q *MemoryQueue) Dequeue(_ context.Context, types []string, visibility time.Duration) (*Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, jobType := range types {
+		for len(q.pending[jobType]) > 0 {
+			id := q.pending[jobType][0]
+			q.pending[jobType] = q.pending[jobType][1:]
+
+			job, ok := q.jobs[id]
+			if !ok {
+				continue
+			}
+			job.Attempts++
+			q.jobs[id] = job
+			q.inflight[id] = time.Now().Add(visibility)
+			return &job, nil
+		}
+	}
+	return nil, nil
+}
+
+// Ack 确认任务已完成并删除
+func (q *MemoryQueue) Ack(_ context.Context, job *Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.inflight, job.ID)
+	delete(q.jobs, job.ID)
+	return nil
+}
+
+// Nack 任务执行失败，delay 后重新投递
+func (q *MemoryQueue) Nack(_ context.Context, job *Job, delay time.Duration) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, ok := q.inflight[job.ID]; !ok {
+		return nil
+	}
+	delete(q.inflight, job.ID)
+	if delay > 0 {
+		q.delayed[job.ID] = time.Now().Add(delay)
+		return nil
+	}
+	q.pending[job.Type] = append(q.pending[job.Type], job.ID)
+	return nil
+}
+
+// Extend 延长执行中任务的可见性超时
+func (q *MemoryQueue) Extend(_ context.Context, job *Job, visibility time.Duration) (bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, ok := q.inflight[job.ID]; !ok {
+		return false, nil
+	}
+	q.inflight[job.ID] = time.Now().Add(visibility)
+	return true, nil
+}
+
+// Recover 将可见性超时及延迟到期的任务放回队列
+func (q *MemoryQueue) Recover(_ context.Context) (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	now := time.Now()
+	count := 0
+	for _, set := range []map[string]time.Time{q.inflight, q.delayed} {
+		for id, deadline := range set {
+			if deadline.After(now) {
+				continue
+			}
+			delete(set, id)
+			if job, ok := q.jobs[id]; ok {
+				q.pending[job.Type] = append(q.pending[job.Type], id)
+			}
+			count++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,117 @@
+package base
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryQueue(t *testing.T) {
+	ctx := context.Background()
+	q := NewMemoryQueue()
+
+	job, err := NewJob("order.process", map[string]string{"order_id": "001"})
+	assert.NoError(t, err)
+	assert.NoError(t, q.Enqueue(ctx, job))
+
+	// 出队后在可见性超时内不可再次获取
+	got, err := q.Dequeue(ctx, []string{"order.process"}, time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, job.ID, got.ID)
+	assert.Equal(t, 1, got.Attempts)
+
+	got, err = q.Dequeue(ctx, []string{"order.process"}, time.Millisecond)
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+
+	// 超时后被恢复并再次投递
+	time.Sleep(2 * time.Millisecond)
+	n, err := q.Recover(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	got, err = q.Dequeue(ctx, []string{"order.process"}, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, got.Attempts)
+
+	var payload map[string]string
+	assert.NoError(t, got.Bind(&payload))
+	assert.Equal(t, "001", payload["order_id"])
+
+	assert.NoError(t, q.Ack(ctx, got))
+	n, err = q.Recover(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestTaskManagerConsume(t *testing.T) {
+	tm := NewTaskManager(2, 10)
+	defer tm.Shutdown()
+
+	done := make(chan string, 1)
+	reg := NewHandlerRegistry()
+	reg.Add("echo", func(ctx context.Context, job *Job) error {
+		var msg string
+		if err := job.Bind(&msg); err != nil {
+			return err
+		}
+		done <- msg
+		return nil
+	})
+	tm.SetRegistry(reg)
+	tm.SetQueue(NewMemoryQueue(), QueueConfig{PollInterval: 10 * time.Millisecond})
+
+	_, err := tm.Enqueue(context.Background(), "echo", "hello")
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = tm.Consume(ctx) }()
+
+	select {
+	case msg := <-done:
+		assert.Equal(t, "hello", msg)
+	case <-time.After(time.Second):
+		t.Fatal("job not consumed")
+	}
+}
+
+func TestTaskManagerConsume_Heartbeat(t *testing.T) {
+	tm := NewTaskManager(2, 10)
+	defer tm.Shutdown()
+
+	var runs int32
+	done := make(chan struct{}, 2)
+	reg := NewHandlerRegistry()
+	reg.Add("slow", func(ctx context.Context, job *Job) error {
+		atomic.AddInt32(&runs, 1)
+		// 执行时间超过可见性超时，续期后不会被恢复重复执行
+		time.Sleep(300 * time.Millisecond)
+		done <- struct{}{}
+		return nil
+	})
+	tm.SetRegistry(reg)
+	tm.SetQueue(NewMemoryQueue(), QueueConfig{
+		VisibilityTimeout: 60 * time.Millisecond,
+		PollInterval:      5 * time.Millisecond,
+		RecoverInterval:   5 * time.Millisecond,
+	})
+
+	_, err := tm.Enqueue(context.Background(), "slow", nil)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = tm.Consume(ctx) }()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("job not consumed")
+	}
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&runs))
+}
@@ -0,0 +1,218 @@
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// dequeueScript 弹出任务并登记到 inflight，同时累加尝试次数
+var dequeueScript = redis.NewScript(`
+while true do
+	local id = redis.call('RPOP', KEYS[1])
+	if not id then
+		return false
+	end
+	local data = redis.call('HGET', KEYS[3], id)
+	if data then
+		redis.call('ZADD', KEYS[2], ARGV[1], id)
+		local attempts = redis.call('HINCRBY', KEYS[4], id, 1)
+		return {data, attempts}
+	end
+end
+`)
+
+// nackScript 仍在 inflight 中的任务才放回队列，避免与 Recover 重复入队
+var nackScript = redis.NewScript(`
+if redis.call('ZREM', KEYS[1], ARGV[1]) == 0 then
+	return 0
+end
+if tonumber(ARGV[2]) > 0 then
+	redis.call('ZADD', KEYS[3], ARGV[2], ARGV[1])
+else
+	redis.call('LPUSH', KEYS[2], ARGV[1])
+end
+return 1
+`)
+
+// extendScript 仍在 inflight 中的任务才更新可见性超时
+var extendScript = redis.NewScript(`
+if not redis.call('ZSCORE', KEYS[1], ARGV[1]) then
+	return 0
+end
+redis.call('ZADD', KEYS[1], 'XX', ARGV[2], ARGV[1])
+return 1
+`)
+
+// requeueScript 仍在 inflight 或 delayed 中且已到期的任务才放回待处理队列，
+// 避免与 Ack、Nack、Extend 及其他节点的 Recover 重复入队
+var requeueScript = redis.NewScript(`
+local score = redis.call('ZSCORE', KEYS[1], ARGV[1])
+if not score or tonumber(score) > tonumber(ARGV[2]) then
+	return 0
+end
+redis.call('ZREM', KEYS[1], ARGV[1])
+redis.call('LPUSH', KEYS[2], ARGV[1])
+return 1
+`)
+
+// recoverBatchSize 单次恢复的最大任务数
+const recoverBatchSize = 100
+
+// RedisQueue 基于 Redis 的持久化队列
+//
+// 所有键使用同一个 hash tag，保证集群模式下 Lua 脚本访问的键位于同一个 slot。
+type RedisQueue struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisQueue 实例化RedisQueue
+func NewRedisQueue(client redis.UniversalClient, prefix string) *RedisQueue {
+	return &RedisQueue{
+		client: client,
+		prefix: "{" + prefix + "}",
+	}
+}
+
+func (q *RedisQueue) pendingKey(jobType string) string {
+	return q.prefix + ":pending:" + jobType
+}
+
+func (q *RedisQueue) jobsKey() string {
+	return q.prefix + ":jobs"
+}
+
+func (q *RedisQueue) attemptsKey() string {
+	return q.prefix + ":attempts"
+}
+
+func (q *RedisQueue) inflightKey() string {
+	return q.prefix + ":inflight"
+}
+
+func (q *RedisQueue) delayedKey() string {
+	return q.prefix + ":delayed"
+}
+
+// Enqueue 入队
+func (q *RedisQueue) Enqueue(ctx context.Context, job *Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, q.jobsKey(), job.ID, data)
+		pipe.LPush(ctx, q.pendingKey(job.Type), job.ID)
+		return nil
+	})
+	return err
+}
+
+// Dequeue 按 types 顺序取出一个任务
+func (q *RedisQueue) Dequeue(ctx context.Context, types []string, visibility time.Duration) (*Job, error) {
+	deadline := time.Now().Add(visibility).UnixMilli()
+	for _, jobType := range types {
+		keys := []string{q.pendingKey(jobType), q.inflightKey(), q.jobsKey(), q.attemptsKey()}
+		res, err := dequeueScript.Run(ctx, q.client, keys, deadline).Slice()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(res) != 2 {
+			return nil, fmt.Errorf("unexpected dequeue result: %v", res)
+		}
+
+		data, _ := res[0].(string)
+		job := &Job{}
+		if err := json.Unmarshal([]byte(data), job); err != nil {
+			return nil, err
+		}
+		attempts, _ := res[1].(int64)
+		job.Attempts = int(attempts)
+		return job, nil
+	}
+	return nil, nil
+}
+
+// Ack 确认任务已完成并删除
+func (q *RedisQueue) Ack(ctx context.Context, job *Job) error {
+	_, err := q.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, q.inflightKey(), job.ID)
+		pipe.HDel(ctx, q.jobsKey(), job.ID)
+		pipe.HDel(ctx, q.attemptsKey(), job.ID)
+		return nil
+	})
+	return err
+}
+
+// Nack 任务执行失败，delay 后重新投递
+func (q *RedisQueue) Nack(ctx context.Context, job *Job, delay time.Duration) error {
+	var readyAt int64
+	if delay > 0 {
+		readyAt = time.Now().Add(delay).UnixMilli()
+	}
+	keys := []string{q.inflightKey(), q.pendingKey(job.Type), q.delayedKey()}
+	return nackScript.Run(ctx, q.client, keys, job.ID, readyAt).Err()
+}
+
+// Extend 延长执行中任务的可见性超时
+func (q *RedisQueue) Extend(ctx context.Context, job *Job, visibility time.Duration) (bool, error) {
+	deadline := time.Now().Add(visibility).UnixMilli()
+	n, err := extendScript.Run(ctx, q.client, []string{q.inflightKey()}, job.ID, deadline).Int()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// Recover 将可见性超时及延迟到期的任务放回队列
+//
+// 任务的类型只有读取任务数据后才知道，待处理队列的键无法预先传入脚本，
+// 因此先查询到期的任务，再逐个通过 requeueScript 移动，脚本访问的键都通过 KEYS 传入。
+func (q *RedisQueue) Recover(ctx context.Context) (int, error) {
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	count := 0
+	for _, set := range []string{q.inflightKey(), q.delayedKey()} {
+		ids, err := q.client.ZRangeByScore(ctx, set, &redis.ZRangeBy{
+			Min:   "-inf",
+			Max:   now,
+			Count: recoverBatchSize,
+		}).Result()
+		if err != nil {
+			return count, err
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		values, err := q.client.HMGet(ctx, q.jobsKey(), ids...).Result()
+		if err != nil {
+			return count, err
+		}
+		for i, id := range ids {
+			data, _ := values[i].(string)
+			job := &Job{}
+			if data == "" || json.Unmarshal([]byte(data), job) != nil {
+				// 任务数据已删除，只移除残留的 id
+				if err := q.client.ZRem(ctx, set, id).Err(); err != nil {
+					return count, err
+				}
+				continue
+			}
+			n, err := requeueScript.Run(ctx, q.client, []string{set, q.pendingKey(job.Type)}, id, now).Int()
+			if err != nil {
+				return count, err
+			}
+			count += n
+		}
+	}
+	return count, nil
+}
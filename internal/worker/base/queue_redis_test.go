@@ -0,0 +1,49 @@
+package base
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisQueue_ExtendAndRecover(t *testing.T) {
+	mr := miniredis.RunT(t)
+	q := NewRedisQueue(redis.NewClient(&redis.Options{Addr: mr.Addr()}), "test")
+	ctx := context.Background()
+
+	job, err := NewJob("order.process", nil)
+	assert.NoError(t, err)
+	assert.NoError(t, q.Enqueue(ctx, job))
+	got, err := q.Dequeue(ctx, []string{"order.process"}, 20*time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, job.ID, got.ID)
+
+	// 续期后到达原可见性超时也不会被恢复
+	ok, err := q.Extend(ctx, got, time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	time.Sleep(30 * time.Millisecond)
+	n, err := q.Recover(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	// 可见性超时后放回对应类型的待处理队列，已恢复的任务不能再续期
+	ok, err = q.Extend(ctx, got, time.Millisecond)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	time.Sleep(5 * time.Millisecond)
+	n, err = q.Recover(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	ok, err = q.Extend(ctx, got, time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	got, err = q.Dequeue(ctx, []string{"order.process"}, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, got.Attempts)
+}
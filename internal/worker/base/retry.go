@@ -0,0 +1,72 @@
+package base
+
+import (
+	"fmt"
+	"time"
+)
+
+// RetryPolicy 重试策略
+type RetryPolicy struct {
+	MaxAttempts int           `json:"max_attempts" mapstructure:"max_attempts"` // 最大尝试次数（含首次执行），小于1时只执行一次
+	Backoff     time.Duration `json:"backoff" mapstructure:"backoff"`           // 首次重试间隔，之后按指数增长
+	MaxBackoff  time.Duration `json:"max_backoff" mapstructure:"max_backoff"`   // 重试间隔上限，0 表示不限制
+}
+
+// Attempts 最大尝试次数
+func (p RetryPolicy) Attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// Delay 第 attempt 次执行失败后到下次重试的间隔
+func (p RetryPolicy) Delay(attempt int) time.Duration {
+	if p.Backoff <= 0 || attempt < 1 {
+		return 0
+	}
+	delay := p.Backoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if p.MaxBackoff > 0 && delay >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return delay
+}
+
+// PanicError 任务执行过程中发生 panic
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("task panic: %v", e.Value)
+}
+
+// HandlerOptions 持久化任务处理器选项
+type HandlerOptions struct {
+	Retry   *RetryPolicy
+	Timeout time.Duration
+}
+
+// HandlerOption 设置处理器选项
+type HandlerOption func(*HandlerOptions)
+
+// WithRetry 设置任务类型的重试策略，未设置时使用 worker.retry 配置
+func WithRetry(policy RetryPolicy) HandlerOption {
+	return func(o *HandlerOptions) {
+		o.Retry = &policy
+	}
+}
+
+// WithTimeout 设置单次执行的超时时间
+func WithTimeout(timeout time.Duration) HandlerOption {
+	return func(o *HandlerOptions) {
+		o.Timeout = timeout
+	}
+}
@@ -0,0 +1,365 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+	"golang.org/x/time/rate"
+)
+
+// Priority 任务优先级，高优先级任务优先获得空闲 worker
+type Priority int
+
+const (
+	PriorityLow    Priority = -1
+	PriorityNormal Priority = 0
+	PriorityHigh   Priority = 1
+)
+
+// priorities 调度时按从高到低的顺序扫描
+var priorities = []Priority{PriorityHigh, PriorityNormal, PriorityLow}
+
+// ParsePriority 解析配置中的优先级名称
+func ParsePriority(name string) (Priority, error) {
+	switch strings.ToLower(name) {
+	case "high":
+		return PriorityHigh, nil
+	case "", "normal":
+		return PriorityNormal, nil
+	case "low":
+		return PriorityLow, nil
+	default:
+		return PriorityNormal, fmt.Errorf("unknown task priority: %s", name)
+	}
+}
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityHigh:
+		return "high"
+	case PriorityLow:
+		return "low"
+	default:
+		return "normal"
+	}
+}
+
+// ErrSchedulerFull 等待调度的任务数达到上限
+var ErrSchedulerFull = errors.New("task queue is full")
+
+// TypeConfig 任务类型的调度配置
+type TypeConfig struct {
+	Priority    string  `json:"priority" mapstructure:"priority"`       // high, normal, low
+	Concurrency int     `json:"concurrency" mapstructure:"concurrency"` // 最大并发数，0 表示不限制
+	Rate        float64 `json:"rate" mapstructure:"rate"`               // 每秒最多启动的任务数，0 表示不限制
+	Burst       int     `json:"burst" mapstructure:"burst"`             // 令牌桶容量，默认 1
+}
+
+// typeLimit 任务类型的并发及速率限制
+type typeLimit struct {
+	priority    Priority
+	concurrency int
+	running     int
+	limiter     *rate.Limiter
+}
+
+// available 是否可以再启动一个该类型的任务，不消耗令牌
+func (l *typeLimit) available() bool {
+	if l.concurrency > 0 && l.running >= l.concurrency {
+		return false
+	}
+	return l.limiter == nil || l.limiter.Tokens() >= 1
+}
+
+// scheduledTask 等待调度的任务
+type scheduledTask struct {
+	id       string
+	taskType string
+	run      func()
+	abandon  func() // 关闭时仍未执行的任务被丢弃时调用
+}
+
+// scheduler 按优先级及任务类型限制将任务派发到线程池
+type scheduler struct {
+	mu         sync.Mutex
+	queues     map[Priority][]*scheduledTask
+	waiting    int
+	capacity   int
+	running    int
+	maxWorkers int
+	limits     map[string]*typeLimit
+	active     map[*scheduledTask]struct{}
+	delayed    map[*scheduledTask]*time.Timer // 等待退避结束后重新加入队列的任务
+	wake       chan struct{}
+}
+
+func newScheduler(maxWorkers, capacity int) *scheduler {
+	return &scheduler{
+		queues:     make(map[Priority][]*scheduledTask),
+		capacity:   capacity,
+		maxWorkers: maxWorkers,
+		limits:     make(map[string]*typeLimit),
+		active:     make(map[*scheduledTask]struct{}),
+		delayed:    make(map[*scheduledTask]*time.Timer),
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+// setLimit 设置任务类型的调度配置
+func (s *scheduler) setLimit(taskType string, cfg TypeConfig) error {
+	priority, err := ParsePriority(cfg.Priority)
+	if err != nil {
+		return err
+	}
+
+	limit := &typeLimit{priority: priority, concurrency: cfg.Concurrency}
+	if cfg.Rate > 0 {
+		burst := cfg.Burst
+		if burst < 1 {
+			burst = 1
+		}
+		limit.limiter = rate.NewLimiter(rate.Limit(cfg.Rate), burst)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if old, ok := s.limits[taskType]; ok {
+		limit.running = old.running
+	}
+	s.limits[taskType] = limit
+	return nil
+}
+
+// priority 任务的实际优先级，未指定时使用任务类型配置
+func (s *scheduler) priority(taskType string, priority Priority) Priority {
+	if priority != PriorityNormal {
+		return priority
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if limit, ok := s.limits[taskType]; ok {
+		return limit.priority
+	}
+	return PriorityNormal
+}
+
+// push 加入等待队列
+func (s *scheduler) push(task *scheduledTask, priority Priority) error {
+	priority = s.priority(task.taskType, priority)
+
+	s.mu.Lock()
+	if s.capacity > 0 && s.waiting >= s.capacity {
+		s.mu.Unlock()
+		return ErrSchedulerFull
+	}
+	s.queues[priority] = append(s.queues[priority], task)
+	s.waiting++
+	s.mu.Unlock()
+
+	s.notify()
+	return nil
+}
+
+// pushAfter 在 delay 后将任务加入等待队列，用于重试退避
+//
+// 等待期间不占用 worker；已接收的任务重试时不受 capacity 限制。
+func (s *scheduler) pushAfter(task *scheduledTask, priority Priority, delay time.Duration) {
+	priority = s.priority(task.taskType, priority)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.delayed[task] = time.AfterFunc(delay, func() {
+		s.mu.Lock()
+		if _, ok := s.delayed[task]; !ok {
+			// 已被 drain 取出
+			s.mu.Unlock()
+			return
+		}
+		delete(s.delayed, task)
+		s.queues[priority] = append(s.queues[priority], task)
+		s.waiting++
+		s.mu.Unlock()
+
+		s.notify()
+	})
+}
+
+// next 取出优先级最高且所属类型未达到限制的任务
+func (s *scheduler) next() *scheduledTask {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running >= s.maxWorkers {
+		return nil
+	}
+	for _, priority := range priorities {
+		queue := s.queues[priority]
+		for i, task := range queue {
+			limit := s.limits[task.taskType]
+			if limit != nil {
+				if !limit.available() || (limit.limiter != nil && !limit.limiter.Allow()) {
+					continue
+				}
+				limit.running++
+			}
+
+			s.queues[priority] = append(queue[:i:i], queue[i+1:]...)
+			s.waiting--
+			s.running++
+			s.active[task] = struct{}{}
+			return task
+		}
+	}
+	return nil
+}
+
+// done 任务执行结束，释放 worker 及类型并发名额
+func (s *scheduler) done(task *scheduledTask) {
+	s.mu.Lock()
+	s.running--
+	delete(s.active, task)
+	if limit, ok := s.limits[task.taskType]; ok {
+		limit.running--
+	}
+	s.mu.Unlock()
+
+	s.notify()
+}
+
+// idle 当前没有等待任务且仍有空闲 worker
+func (s *scheduler) idle() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.waiting == 0 && s.running < s.maxWorkers
+}
+
+// empty 没有等待、退避及执行中的任务
+func (s *scheduler) empty() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.waiting == 0 && s.running == 0 && len(s.delayed) == 0
+}
+
+// drain 清空等待队列及退避中的任务并返回被移除的任务
+func (s *scheduler) drain() []*scheduledTask {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var tasks []*scheduledTask
+	for _, priority := range priorities {
+		tasks = append(tasks, s.queues[priority]...)
+		delete(s.queues, priority)
+	}
+	s.waiting = 0
+	for task, timer := range s.delayed {
+		timer.Stop()
+		tasks = append(tasks, task)
+	}
+	s.delayed = make(map[*scheduledTask]*time.Timer)
+	return tasks
+}
+
+// activeIDs 正在执行的任务ID
+func (s *scheduler) activeIDs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]string, 0, len(s.active))
+	for task := range s.active {
+		ids = append(ids, task.id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// ready 过滤出可以立即启动的任务类型，并按优先级从高到低排序
+func (s *scheduler) ready(types []string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]string, 0, len(types))
+	for _, priority := range priorities {
+		for _, taskType := range types {
+			limit, ok := s.limits[taskType]
+			if !ok {
+				if priority == PriorityNormal {
+					result = append(result, taskType)
+				}
+				continue
+			}
+			if limit.priority == priority && limit.available() {
+				result = append(result, taskType)
+			}
+		}
+	}
+	return result
+}
+
+func (s *scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// dispatchInterval 有任务因速率限制等待时的重新检查间隔
+const dispatchInterval = 50 * time.Millisecond
+
+// dispatch 持续将任务派发到 submit，直到 ctx 取消
+func (s *scheduler) dispatch(ctx context.Context, submit func(task *scheduledTask)) {
+	ticker := time.NewTicker(dispatchInterval)
+	defer ticker.Stop()
+	for {
+		for task := s.next(); task != nil; task = s.next() {
+			submit(task)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// 未配置 worker.max_workers、worker.queue_size 时的默认值
+const (
+	defaultMaxWorkers = 10
+	defaultQueueSize  = 100
+)
+
+// NewTaskManagerFromViper 根据 worker 配置创建任务管理器，并应用 worker.types 中的任务类型限制
+func NewTaskManagerFromViper() (*TaskManager, error) {
+	maxWorkers := viper.GetInt("worker.max_workers")
+	if maxWorkers <= 0 {
+		maxWorkers = defaultMaxWorkers
+	}
+	queueSize := viper.GetInt("worker.queue_size")
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+
+	types := make(map[string]TypeConfig)
+	if err := viper.UnmarshalKey("worker.types", &types); err != nil {
+		return nil, err
+	}
+
+	manager := NewTaskManager(maxWorkers, queueSize)
+	if timeout := viper.GetDuration("worker.shutdown_timeout"); timeout > 0 {
+		manager.SetDrainTimeout(timeout)
+	}
+	for taskType, cfg := range types {
+		if err := manager.SetTypeConfig(taskType, cfg); err != nil {
+			manager.Shutdown()
+			return nil, fmt.Errorf("worker.types.%s: %w", taskType, err)
+		}
+	}
+	return manager, nil
+}
@@ -0,0 +1,37 @@
+package base
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedulerPriorityAndConcurrency(t *testing.T) {
+	s := newScheduler(3, 10)
+	assert.NoError(t, s.setLimit("report", TypeConfig{Priority: "low", Concurrency: 1}))
+	assert.NoError(t, s.setLimit("chat", TypeConfig{Priority: "high"}))
+	assert.Error(t, s.setLimit("bad", TypeConfig{Priority: "urgent"}))
+
+	for _, taskType := range []string{"report", "report", "default", "chat"} {
+		assert.NoError(t, s.push(&scheduledTask{taskType: taskType, run: func() {}}, PriorityNormal))
+	}
+
+	// 高优先级先调度，report 类型最多同时执行 1 个
+	var order []string
+	var report *scheduledTask
+	for task := s.next(); task != nil; task = s.next() {
+		order = append(order, task.taskType)
+		if task.taskType == "report" {
+			report = task
+		}
+	}
+	assert.Equal(t, []string{"chat", "default", "report"}, order)
+	assert.False(t, s.idle())
+
+	s.done(report)
+	task := s.next()
+	if assert.NotNil(t, task) {
+		assert.Equal(t, "report", task.taskType)
+	}
+	assert.Equal(t, []string{"chat", "default"}, s.ready([]string{"default", "report", "chat"}))
+}
@@ -0,0 +1,163 @@
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+// TaskState 任务状态
+type TaskState string
+
+const (
+	TaskStateQueued    TaskState = "queued"
+	TaskStateRunning   TaskState = "running"
+	TaskStateSucceeded TaskState = "succeeded"
+	TaskStateFailed    TaskState = "failed"
+	TaskStateCancelled TaskState = "cancelled"
+)
+
+// Finished 是否为终态
+func (s TaskState) Finished() bool {
+	return s == TaskStateSucceeded || s == TaskStateFailed || s == TaskStateCancelled
+}
+
+// ErrTaskNotFound 任务状态不存在或已过期
+var ErrTaskNotFound = errors.New("task not found")
+
+// ErrTaskFinished 任务已结束，无法取消
+var ErrTaskFinished = errors.New("task already finished")
+
+// TaskRecord 任务状态记录
+type TaskRecord struct {
+	ID         string          `json:"id"`
+	Name       string          `json:"name"`
+	Owner      string          `json:"owner,omitempty"` // 提交任务的用户ID，为空表示系统任务
+	State      TaskState       `json:"state"`
+	Result     json.RawMessage `json:"result,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	Attempts   int             `json:"attempts"`
+	CreatedAt  time.Time       `json:"created_at"`
+	StartedAt  *time.Time      `json:"started_at,omitempty"`
+	FinishedAt *time.Time      `json:"finished_at,omitempty"`
+}
+
+// StatusStore 任务状态存储
+type StatusStore interface {
+	// Save 保存任务状态
+	Save(ctx context.Context, record *TaskRecord) error
+	// Get 获取任务状态，不存在返回 ErrTaskNotFound
+	Get(ctx context.Context, id string) (*TaskRecord, error)
+}
+
+// resultKey 任务结果在 context 中的键
+type resultKey struct{}
+
+// resultHolder 保存任务函数通过 SetResult 写入的结果
+type resultHolder struct {
+	mu    sync.Mutex
+	value json.RawMessage
+}
+
+// SetResult 在任务函数中设置执行结果，结果会被编码为 JSON 保存到任务状态中
+func SetResult(ctx context.Context, v any) error {
+	holder, ok := ctx.Value(resultKey{}).(*resultHolder)
+	if !ok {
+		return errors.New("not in task context")
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	holder.mu.Lock()
+	defer holder.mu.Unlock()
+	holder.value = data
+	return nil
+}
+
+// running 当前进程中正在执行的任务，用于取消
+var running sync.Map
+
+// CancelTask 取消任务
+//
+// 排队中的任务不会再执行；正在当前进程执行的任务会立即取消其 context，
+// 在其他 worker 进程执行的持久化任务由其轮询状态后取消。
+func CancelTask(ctx context.Context, store StatusStore, id string) (*TaskRecord, error) {
+	record, err := store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if record.State.Finished() {
+		return record, ErrTaskFinished
+	}
+
+	now := time.Now()
+	record.State = TaskStateCancelled
+	record.FinishedAt = &now
+	if err := store.Save(ctx, record); err != nil {
+		return nil, err
+	}
+
+	if cancel, ok := running.Load(id); ok {
+		cancel.(context.CancelFunc)()
+	}
+	return record, nil
+}
+
+// MemoryStatusStore 进程内任务状态存储，过期记录在写入时清理
+type MemoryStatusStore struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	records   map[string]memoryStatus
+	lastPrune time.Time
+}
+
+type memoryStatus struct {
+	record    TaskRecord
+	expiresAt time.Time
+}
+
+// NewMemoryStatusStore 实例化MemoryStatusStore
+func NewMemoryStatusStore(ttl time.Duration) *MemoryStatusStore {
+	return &MemoryStatusStore{
+		ttl:       ttl,
+		records:   make(map[string]memoryStatus),
+		lastPrune: time.Now(),
+	}
+}
+
+// Save 保存任务状态
+func (s *MemoryStatusStore) Save(_ context.Context, record *TaskRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastPrune) > time.Minute {
+		for id, status := range s.records {
+			if now.After(status.expiresAt) {
+				delete(s.records, id)
+			}
+		}
+		s.lastPrune = now
+	}
+
+	s.records[record.ID] = memoryStatus{record: *record, expiresAt: now.Add(s.ttl)}
+	return nil
+}
+
+// Get 获取任务状态
+func (s *MemoryStatusStore) Get(_ context.Context, id string) (*TaskRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status, ok := s.records[id]
+	if !ok || time.Now().After(status.expiresAt) {
+		return nil, ErrTaskNotFound
+	}
+	record := status.record
+	return &record, nil
+}
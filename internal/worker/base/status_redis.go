@@ -0,0 +1,56 @@
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// RedisStatusStore 基于 Redis 的任务状态存储，API 进程与 worker 进程共享
+type RedisStatusStore struct {
+	client redis.UniversalClient
+	prefix string
+	ttl    time.Duration
+}
+
+// NewRedisStatusStore 实例化RedisStatusStore
+func NewRedisStatusStore(client redis.UniversalClient, prefix string, ttl time.Duration) *RedisStatusStore {
+	return &RedisStatusStore{
+		client: client,
+		prefix: "{" + prefix + "}",
+		ttl:    ttl,
+	}
+}
+
+func (s *RedisStatusStore) statusKey(id string) string {
+	return s.prefix + ":status:" + id
+}
+
+// Save 保存任务状态
+func (s *RedisStatusStore) Save(ctx context.Context, record *TaskRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, s.statusKey(record.ID), data, s.ttl).Err()
+}
+
+// Get 获取任务状态
+func (s *RedisStatusStore) Get(ctx context.Context, id string) (*TaskRecord, error) {
+	data, err := s.client.Get(ctx, s.statusKey(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	record := &TaskRecord{}
+	if err := json.Unmarshal(data, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
@@ -0,0 +1,34 @@
+package worker
+
+import (
+	"context"
+
+	"go-agent/gopkg/log"
+	"go-agent/internal/worker/base"
+)
+
+// Consumer 持续消费持久化队列的 worker 进程，实现 graceful.Graceful
+type Consumer struct {
+	manager *base.TaskManager
+	types   []string
+}
+
+// NewConsumer 实例化Consumer，types 为空时消费全部已注册类型
+func NewConsumer(manager *base.TaskManager, types []string) *Consumer {
+	return &Consumer{
+		manager: manager,
+		types:   types,
+	}
+}
+
+// GracefulStart 消费任务直到 ctx 取消，随后等待已取出的任务执行完毕并关闭任务管理器
+func (c *Consumer) GracefulStart(ctx context.Context) {
+	defer c.manager.Shutdown()
+
+	log.Sugar().Infof("worker 已启动, 任务类型: %v", c.types)
+	if err := c.manager.Consume(ctx, c.types...); err != nil {
+		log.Sugar().Errorf("worker 消费任务失败: %v", err)
+		return
+	}
+	log.Sugar().Info("worker 已停止")
+}
@@ -0,0 +1,75 @@
+package order
+
+import (
+	"context"
+	"time"
+
+	"go-agent/gopkg/log"
+	"go-agent/internal/worker/base"
+)
+
+// 订单相关的持久化任务类型
+const (
+	TypeProcessOrder = "order.process"
+	TypeRefund       = "order.refund"
+)
+
+// OrderPayload 处理订单任务载荷
+type OrderPayload struct {
+	OrderID string `json:"order_id"`
+}
+
+// RefundPayload 处理退款任务载荷
+type RefundPayload struct {
+	RefundID string `json:"refund_id"`
+}
+
+// RegisterHandlers 注册订单相关的任务处理器
+func RegisterHandlers(registry *base.HandlerRegistry) {
+	registry.Add(TypeProcessOrder, HandleProcessOrder, base.WithTimeout(time.Minute))
+	registry.Add(TypeRefund, HandleRefund, base.WithTimeout(time.Minute))
+}
+
+// HandleProcessOrder 处理订单
+func HandleProcessOrder(ctx context.Context, job *base.Job) error {
+	var payload OrderPayload
+	if err := job.Bind(&payload); err != nil {
+		return err
+	}
+
+	log.Sugar().Infof("开始处理订单: %s", payload.OrderID)
+	// 模拟订单处理逻辑
+	if err := sleep(ctx, 2*time.Second); err != nil {
+		return err
+	}
+	log.Sugar().Infof("订单处理完成: %s", payload.OrderID)
+	return nil
+}
+
+// HandleRefund 处理退款
+func HandleRefund(ctx context.Context, job *base.Job) error {
+	var payload RefundPayload
+	if err := job.Bind(&payload); err != nil {
+		return err
+	}
+
+	log.Sugar().Infof("开始处理退款: %s", payload.RefundID)
+	// 模拟退款处理逻辑
+	if err := sleep(ctx, 3*time.Second); err != nil {
+		return err
+	}
+	log.Sugar().Infof("退款处理完成: %s", payload.RefundID)
+	return nil
+}
+
+// sleep 等待 d，ctx 取消时提前返回
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -13,6 +13,7 @@ func ProcessOrderTask(orderID string) *base.Task {
 	return &base.Task{
 		ID:   fmt.Sprintf("order_%s", orderID),
 		Name: "处理订单任务",
+		Type: "order.process",
 		Function: func(ctx context.Context) error {
 			// 模拟订单处理逻辑
 			fmt.Printf("开始处理订单: %s\n", orderID)
@@ -33,6 +34,7 @@ func ProcessRefundTask(refundID string) *base.Task {
 	return &base.Task{
 		ID:   fmt.Sprintf("refund_%s", refundID),
 		Name: "处理退款任务",
+		Type: "order.refund",
 		Function: func(ctx context.Context) error {
 			// 模拟退款处理逻辑
 			fmt.Printf("开始处理退款: %s\n", refundID)
@@ -0,0 +1,51 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+
+	"go-agent/gopkg/cache/queue"
+	"go-agent/internal/dao"
+	outboxDao "go-agent/internal/dao/outbox"
+	"go-agent/internal/model"
+)
+
+// store 发件箱存储
+var store dao.Outbox = outboxDao.NewDao()
+
+// Add 在业务写入所在的事务中记录待发送事件，事务提交后由 Relay 发送
+//
+//	err := dao.Q.Transaction(func(tx *dao.Query) error {
+//		if err := tx.SOrder.WithContext(ctx).Create(order); err != nil {
+//			return err
+//		}
+//		return outbox.Add(ctx, tx, "orders", OrderCreated{OrderID: order.Id})
+//	})
+//
+// 发送为至少一次，消费方应按 Envelope.ID 去重（见 queue.Idempotent）。
+func Add[T queue.Contract](ctx context.Context, tx *dao.Query, topic string, payload T) error {
+	env, err := queue.NewEnvelope(ctx, payload)
+	if err != nil {
+		return err
+	}
+	msg, err := env.Msg()
+	if err != nil {
+		return err
+	}
+	headers, err := json.Marshal(msg.Headers)
+	if err != nil {
+		return err
+	}
+
+	return store.Create(ctx, tx, &model.SOutbox{
+		MessageId:   msg.ID,
+		Topic:       topic,
+		Type:        env.Type,
+		Body:        string(msg.Body),
+		Headers:     string(headers),
+		Status:      dao.OutboxStatusPending,
+		NextRetryAt: env.Timestamp,
+		CreatedAt:   env.Timestamp,
+		UpdatedAt:   env.Timestamp,
+	})
+}
@@ -0,0 +1,209 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go-agent/gopkg/cache/queue"
+	"go-agent/gopkg/log"
+	"go-agent/internal/dao"
+	"go-agent/internal/model"
+	"go-agent/internal/worker/base"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// Config 发件箱配置，对应 outbox
+type Config struct {
+	Switch         bool             `json:"switch" mapstructure:"switch"`                   // 是否在 worker 进程中启动 relay
+	Bus            string           `json:"bus" mapstructure:"bus"`                         // 发送使用的消息总线名称，见 bus 配置
+	Interval       time.Duration    `json:"interval" mapstructure:"interval"`               // 轮询间隔
+	BatchSize      int              `json:"batch_size" mapstructure:"batch_size"`           // 单次发送的最大消息数
+	PublishTimeout time.Duration    `json:"publish_timeout" mapstructure:"publish_timeout"` // 单条消息发送超时
+	Retention      time.Duration    `json:"retention" mapstructure:"retention"`             // 已发送消息的保留时间
+	Retry          base.RetryPolicy `json:"retry" mapstructure:"retry"`                     // 发送失败的重试策略，超过次数后标记为 failed
+}
+
+const (
+	defaultInterval       = time.Second
+	defaultBatchSize      = 100
+	defaultPublishTimeout = 5 * time.Second
+	defaultRetention      = 7 * 24 * time.Hour
+	cleanupInterval       = time.Hour
+)
+
+func (c *Config) format() {
+	if c.Interval <= 0 {
+		c.Interval = defaultInterval
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = defaultBatchSize
+	}
+	if c.PublishTimeout <= 0 {
+		c.PublishTimeout = defaultPublishTimeout
+	}
+	if c.Retention <= 0 {
+		c.Retention = defaultRetention
+	}
+	if c.Retry.MaxAttempts <= 0 {
+		c.Retry = base.RetryPolicy{MaxAttempts: 10, Backoff: time.Second, MaxBackoff: 5 * time.Minute}
+	}
+}
+
+// ConfigFromViper 读取 outbox 配置
+func ConfigFromViper() (Config, error) {
+	var cfg Config
+	if err := viper.UnmarshalKey("outbox", &cfg); err != nil {
+		return cfg, err
+	}
+	cfg.format()
+	return cfg, nil
+}
+
+// Relay 轮询发件箱并通过消息总线发送，实现 graceful.Graceful
+//
+// 多个节点同时运行时通过 SELECT ... FOR UPDATE SKIP LOCKED 分摊消息，同一条消息不会被并发发送。
+type Relay struct {
+	cfg         Config
+	publisher   queue.Publisher
+	store       dao.Outbox
+	transaction func(fn func(tx *dao.Query) error) error // 在事务中执行 fn，测试时替换
+	logger      *zap.SugaredLogger
+}
+
+// NewRelay 实例化Relay
+func NewRelay(cfg Config, publisher queue.Publisher) *Relay {
+	cfg.format()
+	return &Relay{
+		cfg:       cfg,
+		publisher: publisher,
+		store:     store,
+		transaction: func(fn func(tx *dao.Query) error) error {
+			return dao.Q.Transaction(fn)
+		},
+		logger: log.Sugar(),
+	}
+}
+
+// NewRelayFromViper 根据 outbox 配置实例化Relay
+func NewRelayFromViper() (*Relay, error) {
+	cfg, err := ConfigFromViper()
+	if err != nil {
+		return nil, err
+	}
+	bus, err := queue.Bus(cfg.Bus)
+	if err != nil {
+		return nil, err
+	}
+	return NewRelay(cfg, bus), nil
+}
+
+// GracefulStart 持续发送待发送消息直到 ctx 取消
+func (r *Relay) GracefulStart(ctx context.Context) {
+	r.logger.Infof("outbox relay 已启动, 消息总线: %s", r.cfg.Bus)
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	var lastCleanup time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			r.logger.Info("outbox relay 已停止")
+			return
+		case <-ticker.C:
+		}
+
+		// 一批发满说明可能还有积压，继续发送直到取完
+		for ctx.Err() == nil {
+			n, err := r.RelayOnce(ctx)
+			if err != nil {
+				r.logger.Errorf("outbox 发送失败: %v", err)
+				break
+			}
+			if n < r.cfg.BatchSize {
+				break
+			}
+		}
+
+		if time.Since(lastCleanup) >= cleanupInterval {
+			lastCleanup = time.Now()
+			r.cleanup(ctx)
+		}
+	}
+}
+
+// RelayOnce 在一个事务中锁定并发送一批到期消息，返回处理的数量
+//
+// 发送成功但事务提交失败时消息会被再次发送，消费方按消息ID去重。
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	var n int
+	err := r.transaction(func(tx *dao.Query) error {
+		rows, err := r.store.Pending(ctx, tx, r.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+		n = len(rows)
+
+		sent := make([]uint64, 0, len(rows))
+		for _, row := range rows {
+			if err := r.publish(ctx, row); err != nil {
+				r.retry(row, err)
+				if err := r.store.MarkRetry(ctx, tx, row); err != nil {
+					return err
+				}
+				continue
+			}
+			sent = append(sent, row.Id)
+		}
+		return r.store.MarkSent(ctx, tx, sent)
+	})
+	return n, err
+}
+
+// publish 发送一条消息
+func (r *Relay) publish(ctx context.Context, row *model.SOutbox) error {
+	msg := &queue.Msg{
+		ID:        row.MessageId,
+		Body:      []byte(row.Body),
+		Timestamp: row.CreatedAt,
+	}
+	if row.Headers != "" {
+		if err := json.Unmarshal([]byte(row.Headers), &msg.Headers); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.PublishTimeout)
+	defer cancel()
+	return r.publisher.Publish(ctx, row.Topic, msg)
+}
+
+// retry 记录发送失败，超过最大次数后标记为 failed
+func (r *Relay) retry(row *model.SOutbox, err error) {
+	row.Attempts++
+	row.LastError = err.Error()
+	if row.Attempts >= r.cfg.Retry.Attempts() {
+		row.Status = dao.OutboxStatusFailed
+		r.logger.Errorf("outbox 消息发送失败，不再重试: %s (ID: %s), 错误: %v", row.Topic, row.MessageId, err)
+		return
+	}
+	row.NextRetryAt = time.Now().Add(r.cfg.Retry.Delay(row.Attempts))
+	r.logger.Warnf("outbox 消息发送失败，%v 后重试: %s (ID: %s), 错误: %v", r.cfg.Retry.Delay(row.Attempts), row.Topic, row.MessageId, err)
+}
+
+// cleanup 删除超过保留时间的已发送消息
+func (r *Relay) cleanup(ctx context.Context) {
+	before := time.Now().Add(-r.cfg.Retention)
+	for ctx.Err() == nil {
+		n, err := r.store.DeleteSent(ctx, before, r.cfg.BatchSize)
+		if err != nil {
+			r.logger.Errorf("outbox 清理已发送消息失败: %v", err)
+			return
+		}
+		if n < int64(r.cfg.BatchSize) {
+			return
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-agent/gopkg/cache/queue"
+	"go-agent/internal/dao"
+	"go-agent/internal/model"
+	"go-agent/internal/worker/base"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelayRetry(t *testing.T) {
+	relay := NewRelay(Config{Retry: base.RetryPolicy{MaxAttempts: 2, Backoff: time.Minute}}, nil)
+	row := &model.SOutbox{Status: dao.OutboxStatusPending}
+
+	relay.retry(row, errors.New("broker down"))
+	assert.Equal(t, dao.OutboxStatusPending, row.Status)
+	assert.Equal(t, 1, row.Attempts)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), row.NextRetryAt, time.Second)
+
+	relay.retry(row, errors.New("broker down"))
+	assert.Equal(t, dao.OutboxStatusFailed, row.Status)
+	assert.Equal(t, "broker down", row.LastError)
+}
+
+type memoryOutbox struct {
+	dao.Outbox
+	rows []*model.SOutbox
+}
+
+func (m *memoryOutbox) Pending(_ context.Context, _ *dao.Query, limit int) ([]*model.SOutbox, error) {
+	var rows []*model.SOutbox
+	for _, row := range m.rows {
+		if row.Status == dao.OutboxStatusPending && !row.NextRetryAt.After(time.Now()) && len(rows) < limit {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (m *memoryOutbox) MarkSent(_ context.Context, _ *dao.Query, ids []uint64) error {
+	for _, row := range m.rows {
+		for _, id := range ids {
+			if row.Id == id {
+				row.Status = dao.OutboxStatusSent
+			}
+		}
+	}
+	return nil
+}
+
+func (m *memoryOutbox) MarkRetry(_ context.Context, _ *dao.Query, _ *model.SOutbox) error {
+	return nil
+}
+
+func TestRelayOnce(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	bus := queue.NewMemoryBus(1)
+	relay := NewRelay(Config{}, bus)
+	relay.transaction = func(fn func(tx *dao.Query) error) error { return fn(nil) }
+	relay.store = &memoryOutbox{rows: []*model.SOutbox{
+		{Id: 1, MessageId: "m1", Topic: "orders", Body: "a", Headers: `{"x-message-type":"order.created"}`, Status: dao.OutboxStatusPending},
+		{Id: 2, MessageId: "m2", Topic: "orders", Body: "b", Headers: "invalid", Status: dao.OutboxStatusPending},
+	}}
+
+	got := make(chan *queue.Msg, 2)
+	go func() {
+		_ = bus.Subscribe(ctx, "orders", func(ctx context.Context, msg *queue.Msg) error {
+			got <- msg
+			return nil
+		})
+	}()
+
+	n, err := relay.RelayOnce(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	msg := <-got
+	assert.Equal(t, "m1", msg.ID)
+	assert.Equal(t, "a", string(msg.Body))
+	assert.Equal(t, "order.created", msg.Headers["x-message-type"])
+
+	// 发送成功的标记为已发送，失败的等待重试
+	rows := relay.store.(*memoryOutbox).rows
+	assert.Equal(t, dao.OutboxStatusSent, rows[0].Status)
+	assert.Equal(t, dao.OutboxStatusPending, rows[1].Status)
+	assert.Equal(t, 1, rows[1].Attempts)
+
+	n, err = relay.RelayOnce(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
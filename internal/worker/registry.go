@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"fmt"
+	"strings"
+
+	"go-agent/internal/worker/base"
+	"go-agent/internal/worker/order"
+)
+
+// RegisterHandlers 注册全部持久化任务处理器，新增任务类型时在这里注册
+func RegisterHandlers(registry *base.HandlerRegistry) {
+	order.RegisterHandlers(registry)
+}
+
+// SelectTypes 根据 --type 过滤要消费的任务类型
+//
+// 支持完整类型 order.process 及前缀 order（匹配 order.*），filters 为空时返回全部已注册类型。
+func SelectTypes(registry *base.HandlerRegistry, filters []string) ([]string, error) {
+	types := registry.Types()
+	if len(filters) == 0 {
+		return types, nil
+	}
+
+	selected := make([]string, 0, len(types))
+	seen := make(map[string]bool, len(types))
+	for _, filter := range filters {
+		filter = strings.ToLower(strings.TrimSpace(filter))
+		if filter == "" {
+			continue
+		}
+
+		matched := false
+		for _, jobType := range types {
+			if jobType != filter && !strings.HasPrefix(jobType, filter+".") {
+				continue
+			}
+			matched = true
+			if !seen[jobType] {
+				seen[jobType] = true
+				selected = append(selected, jobType)
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("unknown worker type %q, available: %s", filter, strings.Join(types, ", "))
+		}
+	}
+	return selected, nil
+}
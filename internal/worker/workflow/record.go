@@ -0,0 +1,147 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	rxRedis "go-agent/gopkg/cache/redis"
+	"go-agent/internal/worker/base"
+)
+
+// ErrWorkflowNotFound 工作流记录不存在或已过期
+var ErrWorkflowNotFound = errors.New("workflow not found")
+
+// StepRecord 步骤执行状态
+type StepRecord struct {
+	Name       string          `json:"name"`
+	State      base.TaskState  `json:"state"`
+	After      []string        `json:"after,omitempty"`
+	TaskIDs    []string        `json:"task_ids,omitempty"`
+	Items      int             `json:"items,omitempty"` // 扇出元素数
+	Output     json.RawMessage `json:"output,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	StartedAt  *time.Time      `json:"started_at,omitempty"`
+	FinishedAt *time.Time      `json:"finished_at,omitempty"`
+}
+
+// Record 工作流执行记录
+type Record struct {
+	ID         string                 `json:"id"`
+	Name       string                 `json:"name"`
+	State      base.TaskState         `json:"state"`
+	Input      json.RawMessage        `json:"input,omitempty"`
+	Steps      map[string]*StepRecord `json:"steps"`
+	Error      string                 `json:"error,omitempty"`
+	CreatedAt  time.Time              `json:"created_at"`
+	FinishedAt *time.Time             `json:"finished_at,omitempty"`
+}
+
+// clone 深拷贝记录，保存到进程内存储时避免与执行中的记录共享
+func (r *Record) clone() *Record {
+	record := *r
+	record.Steps = make(map[string]*StepRecord, len(r.Steps))
+	for name, step := range r.Steps {
+		s := *step
+		record.Steps[name] = &s
+	}
+	return &record
+}
+
+// Store 工作流记录存储
+type Store interface {
+	// Save 保存工作流记录
+	Save(ctx context.Context, record *Record) error
+	// Get 获取工作流记录，不存在返回 ErrWorkflowNotFound
+	Get(ctx context.Context, id string) (*Record, error)
+}
+
+// NewStore 根据 worker.queue 配置创建工作流记录存储，与任务状态使用相同的后端及过期时间
+func NewStore(cfg base.QueueConfig) (Store, error) {
+	switch cfg.Backend {
+	case "redis":
+		client, err := rxRedis.ClientAndErr(cfg.Client)
+		if err != nil {
+			return nil, err
+		}
+		return NewRedisStore(client, cfg.Prefix, cfg.StatusTTL), nil
+	case "", "memory":
+		return NewMemoryStore(cfg.StatusTTL), nil
+	default:
+		return nil, fmt.Errorf("unknown task queue backend: %s", cfg.Backend)
+	}
+}
+
+// defaultRecordTTL 未初始化时进程内记录的保存时间
+const defaultRecordTTL = 24 * time.Hour
+
+var defaultStore Store = NewMemoryStore(defaultRecordTTL)
+
+// InitFromViper 根据 worker.queue 配置初始化默认的工作流记录存储
+func InitFromViper() error {
+	cfg, err := base.QueueConfigFromViper()
+	if err != nil {
+		return err
+	}
+	store, err := NewStore(cfg)
+	if err != nil {
+		return err
+	}
+	defaultStore = store
+	return nil
+}
+
+// DefaultStore 默认工作流记录存储
+func DefaultStore() Store {
+	return defaultStore
+}
+
+// MemoryStore 进程内工作流记录存储
+type MemoryStore struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	records map[string]memoryRecord
+}
+
+type memoryRecord struct {
+	record    *Record
+	expiresAt time.Time
+}
+
+// NewMemoryStore 实例化MemoryStore
+func NewMemoryStore(ttl time.Duration) *MemoryStore {
+	return &MemoryStore{
+		ttl:     ttl,
+		records: make(map[string]memoryRecord),
+	}
+}
+
+// Save 保存工作流记录，同时清理过期记录
+func (s *MemoryStore) Save(_ context.Context, record *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for id, item := range s.records {
+		if now.After(item.expiresAt) {
+			delete(s.records, id)
+		}
+	}
+	s.records[record.ID] = memoryRecord{record: record.clone(), expiresAt: now.Add(s.ttl)}
+	return nil
+}
+
+// Get 获取工作流记录
+func (s *MemoryStore) Get(_ context.Context, id string) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, ok := s.records[id]
+	if !ok || time.Now().After(item.expiresAt) {
+		return nil, ErrWorkflowNotFound
+	}
+	return item.record.clone(), nil
+}
@@ -0,0 +1,56 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// RedisStore 基于 Redis 的工作流记录存储，API 进程与 worker 进程共享
+type RedisStore struct {
+	client redis.UniversalClient
+	prefix string
+	ttl    time.Duration
+}
+
+// NewRedisStore 实例化RedisStore
+func NewRedisStore(client redis.UniversalClient, prefix string, ttl time.Duration) *RedisStore {
+	return &RedisStore{
+		client: client,
+		prefix: "{" + prefix + "}",
+		ttl:    ttl,
+	}
+}
+
+func (s *RedisStore) recordKey(id string) string {
+	return s.prefix + ":workflow:" + id
+}
+
+// Save 保存工作流记录
+func (s *RedisStore) Save(ctx context.Context, record *Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, s.recordKey(record.ID), data, s.ttl).Err()
+}
+
+// Get 获取工作流记录
+func (s *RedisStore) Get(ctx context.Context, id string) (*Record, error) {
+	data, err := s.client.Get(ctx, s.recordKey(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrWorkflowNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	record := &Record{}
+	if err := json.Unmarshal(data, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
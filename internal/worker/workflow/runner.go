@@ -0,0 +1,354 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"go-agent/gopkg/log"
+	"go-agent/gopkg/utils"
+	"go-agent/internal/worker/base"
+
+	"go.uber.org/zap"
+)
+
+// Runner 在 TaskManager 上执行工作流，每个步骤（扇出时每个元素）作为一个任务提交
+type Runner struct {
+	tm     *base.TaskManager
+	store  Store
+	logger *zap.SugaredLogger
+}
+
+// NewRunner 实例化Runner
+func NewRunner(tm *base.TaskManager) *Runner {
+	return &Runner{
+		tm:     tm,
+		logger: log.Sugar(),
+	}
+}
+
+// SetStore 设置工作流记录存储，未设置时使用 InitFromViper 初始化的默认存储
+func (r *Runner) SetStore(store Store) {
+	r.store = store
+}
+
+// Store 当前使用的工作流记录存储
+func (r *Runner) Store() Store {
+	if r.store != nil {
+		return r.store
+	}
+	return defaultStore
+}
+
+// Execution 工作流执行句柄
+type Execution struct {
+	id     string
+	store  Store
+	done   <-chan struct{}
+	cancel context.CancelFunc
+}
+
+// ID 工作流执行ID
+func (e *Execution) ID() string {
+	return e.id
+}
+
+// Status 查询工作流记录
+func (e *Execution) Status(ctx context.Context) (*Record, error) {
+	return e.store.Get(ctx, e.id)
+}
+
+// Wait 等待工作流结束并返回最终记录
+func (e *Execution) Wait(ctx context.Context) (*Record, error) {
+	select {
+	case <-e.done:
+		return e.Status(ctx)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Cancel 取消工作流，正在执行的步骤任务会被取消，未开始的步骤不再执行
+func (e *Execution) Cancel() {
+	e.cancel()
+}
+
+// Start 校验并启动工作流，input 会被编码为 JSON 供步骤通过 Input.Bind 读取
+func (r *Runner) Start(ctx context.Context, wf *Workflow, input any) (*Execution, error) {
+	if err := wf.Validate(); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("marshal workflow input: %w", err)
+	}
+
+	record := &Record{
+		ID:        utils.GenUUIDWithoutUnderline(),
+		Name:      wf.name,
+		State:     base.TaskStateQueued,
+		Input:     data,
+		Steps:     make(map[string]*StepRecord, len(wf.steps)),
+		CreatedAt: time.Now(),
+	}
+	for _, name := range wf.order {
+		record.Steps[name] = &StepRecord{
+			Name:  name,
+			State: base.TaskStateQueued,
+			After: wf.steps[name].after,
+		}
+	}
+	if err := r.Store().Save(ctx, record); err != nil {
+		return nil, err
+	}
+
+	// 工作流的生命周期不跟随调用方的请求 ctx，通过 Execution.Cancel 取消
+	runCtx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer cancel()
+		r.run(runCtx, wf, record)
+	}()
+
+	return &Execution{id: record.ID, store: r.Store(), done: done, cancel: cancel}, nil
+}
+
+// Run 启动工作流并等待结束
+func (r *Runner) Run(ctx context.Context, wf *Workflow, input any) (*Record, error) {
+	execution, err := r.Start(ctx, wf, input)
+	if err != nil {
+		return nil, err
+	}
+	record, err := execution.Wait(ctx)
+	if err != nil {
+		execution.Cancel()
+		return nil, err
+	}
+	return record, nil
+}
+
+// stepResult 步骤执行结果
+type stepResult struct {
+	name    string
+	output  json.RawMessage
+	taskIDs []string
+	err     error
+}
+
+// run 按依赖关系调度步骤，任一步骤失败后取消其余步骤
+func (r *Runner) run(ctx context.Context, wf *Workflow, record *Record) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	record.State = base.TaskStateRunning
+	r.save(record)
+
+	outputs := make(map[string]json.RawMessage, len(wf.steps))
+	results := make(chan stepResult)
+	running := 0
+	var failed error
+
+	for {
+		if failed == nil && ctx.Err() == nil {
+			for _, name := range wf.order {
+				if !r.ready(wf.steps[name], record) {
+					continue
+				}
+				in := &Input{input: record.Input, outputs: make(map[string]json.RawMessage)}
+				for _, dep := range wf.steps[name].after {
+					in.outputs[dep] = outputs[dep]
+				}
+
+				now := time.Now()
+				record.Steps[name].State = base.TaskStateRunning
+				record.Steps[name].StartedAt = &now
+				running++
+				go func(step *Step) {
+					results <- r.runStep(ctx, wf, record.ID, step, in)
+				}(wf.steps[name])
+			}
+			r.save(record)
+		}
+		if running == 0 {
+			break
+		}
+
+		result := <-results
+		running--
+
+		now := time.Now()
+		step := record.Steps[result.name]
+		step.TaskIDs = result.taskIDs
+		step.FinishedAt = &now
+		if result.err != nil {
+			step.State = base.TaskStateFailed
+			if ctx.Err() != nil {
+				step.State = base.TaskStateCancelled
+			}
+			step.Error = result.err.Error()
+			if failed == nil && ctx.Err() == nil {
+				failed = fmt.Errorf("step %s: %w", result.name, result.err)
+				r.logger.Errorf("工作流步骤失败: %s (ID: %s), 步骤: %s, 错误: %v", wf.name, record.ID, result.name, result.err)
+				cancel()
+			}
+		} else {
+			step.State = base.TaskStateSucceeded
+			step.Output = result.output
+			if wf.steps[result.name].source != "" {
+				step.Items = len(result.taskIDs)
+			}
+			outputs[result.name] = result.output
+		}
+		r.save(record)
+	}
+
+	// 未执行的步骤标记为已取消
+	now := time.Now()
+	for _, step := range record.Steps {
+		if step.State == base.TaskStateQueued {
+			step.State = base.TaskStateCancelled
+		}
+	}
+	record.FinishedAt = &now
+	switch {
+	case failed != nil:
+		record.State = base.TaskStateFailed
+		record.Error = failed.Error()
+	case ctx.Err() != nil:
+		record.State = base.TaskStateCancelled
+		record.Error = context.Canceled.Error()
+	default:
+		record.State = base.TaskStateSucceeded
+		r.logger.Infof("工作流执行成功: %s (ID: %s)", wf.name, record.ID)
+	}
+	r.save(record)
+}
+
+// ready 步骤尚未执行且依赖的步骤全部成功
+func (r *Runner) ready(step *Step, record *Record) bool {
+	if record.Steps[step.name].State != base.TaskStateQueued {
+		return false
+	}
+	for _, dep := range step.after {
+		if record.Steps[dep].State != base.TaskStateSucceeded {
+			return false
+		}
+	}
+	return true
+}
+
+// runStep 执行步骤，扇出步骤对来源数组的每个元素提交一个任务
+func (r *Runner) runStep(ctx context.Context, wf *Workflow, id string, step *Step, in *Input) stepResult {
+	result := stepResult{name: step.name}
+	if step.source == "" {
+		taskID := fmt.Sprintf("%s_%s", id, step.name)
+		result.taskIDs = []string{taskID}
+		result.output, result.err = r.submit(ctx, wf, step, taskID, in)
+		return result
+	}
+
+	var items []json.RawMessage
+	if err := in.Output(step.source, &items); err != nil {
+		result.err = fmt.Errorf("fan-out source %s must output an array: %w", step.source, err)
+		return result
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	outputs := make([]json.RawMessage, len(items))
+	result.taskIDs = make([]string, len(items))
+	sem := make(chan struct{}, step.concurrency)
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	for i, item := range items {
+		taskID := fmt.Sprintf("%s_%s_%d", id, step.name, i)
+		result.taskIDs[i] = taskID
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, item json.RawMessage) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			itemIn := &Input{input: in.input, outputs: in.outputs, item: item}
+			output, err := r.submit(ctx, wf, step, taskID, itemIn)
+			if err != nil {
+				once.Do(func() {
+					firstErr = fmt.Errorf("item %d: %w", i, err)
+					cancel()
+				})
+				return
+			}
+			outputs[i] = output
+		}(i, item)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		result.err = firstErr
+		return result
+	}
+	if err := ctx.Err(); err != nil {
+		result.err = err
+		return result
+	}
+	result.output, result.err = json.Marshal(outputs)
+	return result
+}
+
+// submit 将步骤提交为任务并等待结束，ctx 取消时同时取消任务
+func (r *Runner) submit(ctx context.Context, wf *Workflow, step *Step, taskID string, in *Input) (json.RawMessage, error) {
+	handle, err := r.tm.SubmitAsync(&base.Task{
+		ID:      taskID,
+		Name:    wf.name + "." + step.name,
+		Type:    wf.name + "." + step.name,
+		Timeout: step.timeout,
+		Retry:   step.retry,
+		Function: func(ctx context.Context) error {
+			output, err := step.fn(ctx, in)
+			if err != nil {
+				return err
+			}
+			if output == nil {
+				return nil
+			}
+			return base.SetResult(ctx, output)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := handle.Wait(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			_ = handle.Cancel(context.Background())
+		}
+		return nil, err
+	}
+	if record.State != base.TaskStateSucceeded {
+		return nil, fmt.Errorf("task %s %s: %s", taskID, record.State, record.Error)
+	}
+	return record.Result, nil
+}
+
+// save 保存工作流记录，失败只记录日志
+func (r *Runner) save(record *Record) {
+	if err := r.Store().Save(context.Background(), record); err != nil {
+		r.logger.Errorf("保存工作流记录失败: %s (ID: %s), 错误: %v", record.Name, record.ID, err)
+	}
+}
@@ -0,0 +1,199 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go-agent/internal/worker/base"
+)
+
+// StepFunc 步骤函数，返回值会被编码为 JSON 作为步骤输出传递给后续步骤
+type StepFunc func(ctx context.Context, in *Input) (any, error)
+
+// Input 步骤的输入，包括工作流输入、依赖步骤的输出以及扇出时的当前元素
+type Input struct {
+	input   json.RawMessage
+	outputs map[string]json.RawMessage
+	item    json.RawMessage
+}
+
+// Bind 将工作流输入解码到 v
+func (in *Input) Bind(v any) error {
+	if len(in.input) == 0 {
+		return nil
+	}
+	return json.Unmarshal(in.input, v)
+}
+
+// Output 将依赖步骤的输出解码到 v，只能读取 After 声明的步骤
+func (in *Input) Output(step string, v any) error {
+	data, ok := in.outputs[step]
+	if !ok {
+		return fmt.Errorf("step %s is not a dependency", step)
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
+
+// Item 将扇出步骤当前处理的元素解码到 v
+func (in *Input) Item(v any) error {
+	if in.item == nil {
+		return fmt.Errorf("not in fan-out step")
+	}
+	return json.Unmarshal(in.item, v)
+}
+
+// Step 工作流步骤
+type Step struct {
+	name        string
+	fn          StepFunc
+	after       []string
+	source      string // 扇出步骤的数据来源步骤，其输出必须为数组
+	retry       base.RetryPolicy
+	timeout     time.Duration
+	concurrency int // 扇出时同时执行的元素数
+}
+
+// StepOption 步骤选项
+type StepOption func(*Step)
+
+// After 声明依赖的步骤，依赖步骤全部成功后才会执行
+func After(steps ...string) StepOption {
+	return func(s *Step) {
+		s.after = append(s.after, steps...)
+	}
+}
+
+// WithRetry 设置步骤的重试策略，扇出时对每个元素单独生效
+func WithRetry(policy base.RetryPolicy) StepOption {
+	return func(s *Step) {
+		s.retry = policy
+	}
+}
+
+// WithTimeout 设置步骤单次执行超时
+func WithTimeout(timeout time.Duration) StepOption {
+	return func(s *Step) {
+		s.timeout = timeout
+	}
+}
+
+// WithConcurrency 设置扇出步骤同时执行的元素数
+func WithConcurrency(n int) StepOption {
+	return func(s *Step) {
+		s.concurrency = n
+	}
+}
+
+// defaultConcurrency 扇出步骤默认同时执行的元素数
+const defaultConcurrency = 10
+
+// Workflow 由步骤及其依赖组成的有向无环图
+//
+//	wf := workflow.New("ingest_book").
+//		Step("download", download).
+//		Step("extract", extract, workflow.After("download")).
+//		Step("chunk", chunk, workflow.After("extract")).
+//		FanOut("embed", "chunk", embed).
+//		Step("index", index, workflow.After("embed"))
+type Workflow struct {
+	name  string
+	steps map[string]*Step
+	order []string // 按声明顺序
+	err   error
+}
+
+// New 创建工作流
+func New(name string) *Workflow {
+	return &Workflow{
+		name:  name,
+		steps: make(map[string]*Step),
+	}
+}
+
+// Name 工作流名称
+func (w *Workflow) Name() string {
+	return w.name
+}
+
+// Step 添加步骤
+func (w *Workflow) Step(name string, fn StepFunc, opts ...StepOption) *Workflow {
+	return w.add(&Step{name: name, fn: fn}, opts)
+}
+
+// FanOut 添加扇出步骤，对 source 步骤输出数组中的每个元素并行执行 fn，
+// 全部成功后按原顺序汇总为数组作为本步骤的输出，依赖本步骤即可完成汇聚
+func (w *Workflow) FanOut(name, source string, fn StepFunc, opts ...StepOption) *Workflow {
+	return w.add(&Step{name: name, fn: fn, source: source, after: []string{source}}, opts)
+}
+
+func (w *Workflow) add(step *Step, opts []StepOption) *Workflow {
+	for _, opt := range opts {
+		opt(step)
+	}
+	if w.err != nil {
+		return w
+	}
+	if step.name == "" || step.fn == nil {
+		w.err = fmt.Errorf("workflow %s: step name and function are required", w.name)
+		return w
+	}
+	if _, ok := w.steps[step.name]; ok {
+		w.err = fmt.Errorf("workflow %s: duplicate step %s", w.name, step.name)
+		return w
+	}
+	if step.concurrency <= 0 {
+		step.concurrency = defaultConcurrency
+	}
+	w.steps[step.name] = step
+	w.order = append(w.order, step.name)
+	return w
+}
+
+// Validate 检查步骤定义、依赖是否存在以及是否有环
+func (w *Workflow) Validate() error {
+	if w.err != nil {
+		return w.err
+	}
+	if len(w.steps) == 0 {
+		return fmt.Errorf("workflow %s: no steps", w.name)
+	}
+
+	for _, name := range w.order {
+		for _, dep := range w.steps[name].after {
+			if _, ok := w.steps[dep]; !ok {
+				return fmt.Errorf("workflow %s: step %s depends on unknown step %s", w.name, name, dep)
+			}
+		}
+	}
+
+	// 0 未访问，1 访问中，2 已完成
+	visited := make(map[string]int, len(w.steps))
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch visited[name] {
+		case 1:
+			return fmt.Errorf("workflow %s: cycle detected at step %s", w.name, name)
+		case 2:
+			return nil
+		}
+		visited[name] = 1
+		for _, dep := range w.steps[name].after {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		visited[name] = 2
+		return nil
+	}
+	for _, name := range w.order {
+		if err := visit(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go-agent/internal/worker/base"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowValidate(t *testing.T) {
+	noop := func(ctx context.Context, in *Input) (any, error) { return nil, nil }
+
+	assert.NoError(t, New("ok").Step("a", noop).Step("b", noop, After("a")).Validate())
+	assert.Error(t, New("dup").Step("a", noop).Step("a", noop).Validate())
+	assert.Error(t, New("unknown").Step("a", noop, After("missing")).Validate())
+	assert.Error(t, New("cycle").Step("a", noop, After("b")).Step("b", noop, After("a")).Validate())
+}
+
+func TestRunnerFanOutAndJoin(t *testing.T) {
+	tm := base.NewTaskManager(4, 100)
+	defer tm.Shutdown()
+	runner := NewRunner(tm)
+	runner.SetStore(NewMemoryStore(time.Minute))
+
+	wf := New("ingest").
+		Step("chunk", func(ctx context.Context, in *Input) (any, error) {
+			var text string
+			if err := in.Bind(&text); err != nil {
+				return nil, err
+			}
+			return strings.Fields(text), nil
+		}).
+		FanOut("embed", "chunk", func(ctx context.Context, in *Input) (any, error) {
+			var word string
+			if err := in.Item(&word); err != nil {
+				return nil, err
+			}
+			return len(word), nil
+		}, WithConcurrency(2)).
+		Step("index", func(ctx context.Context, in *Input) (any, error) {
+			var sizes []int
+			if err := in.Output("embed", &sizes); err != nil {
+				return nil, err
+			}
+			total := 0
+			for _, size := range sizes {
+				total += size
+			}
+			return total, nil
+		}, After("embed"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	record, err := runner.Run(ctx, wf, "a bb ccc")
+	assert.NoError(t, err)
+	assert.Equal(t, base.TaskStateSucceeded, record.State)
+	assert.Equal(t, 3, record.Steps["embed"].Items)
+	assert.JSONEq(t, "[1,2,3]", string(record.Steps["embed"].Output))
+	assert.JSONEq(t, "6", string(record.Steps["index"].Output))
+
+	failing := New("failing").
+		Step("a", func(ctx context.Context, in *Input) (any, error) { return nil, errors.New("boom") }).
+		Step("b", func(ctx context.Context, in *Input) (any, error) { return nil, nil }, After("a"))
+	record, err = runner.Run(ctx, failing, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, base.TaskStateFailed, record.State)
+	assert.Equal(t, base.TaskStateFailed, record.Steps["a"].State)
+	assert.Equal(t, base.TaskStateCancelled, record.Steps["b"].State)
+}